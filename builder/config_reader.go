@@ -116,6 +116,38 @@ func ReadConfig(path string) (config Config, warnings []string, err error) {
 	return config, warnings, nil
 }
 
+// OrderConfig is an order file, defining the order of the buildpacks and extensions of a builder as a builder
+// configuration does
+type OrderConfig struct {
+	Order           dist.Order `toml:"order"`
+	OrderExtensions dist.Order `toml:"order-extensions"`
+}
+
+// ReadOrderConfig reads an order file from the file path provided
+func ReadOrderConfig(path string) (OrderConfig, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return OrderConfig{}, errors.Wrap(err, "opening order file")
+	}
+	defer file.Close()
+
+	orderConfig := OrderConfig{}
+	tomlMetadata, err := toml.NewDecoder(file).Decode(&orderConfig)
+	if err != nil {
+		return OrderConfig{}, errors.Wrapf(err, "parse contents of '%s'", path)
+	}
+
+	if undecodedKeys := tomlMetadata.Undecoded(); len(undecodedKeys) > 0 {
+		return OrderConfig{}, errors.Errorf("%s in %s", config.FormatUndecodedKeys(undecodedKeys), style.Symbol(path))
+	}
+
+	if len(orderConfig.Order) == 0 {
+		return OrderConfig{}, errors.Errorf("%s in %s is empty", style.Symbol("order"), style.Symbol(path))
+	}
+
+	return orderConfig, nil
+}
+
 // ValidateConfig validates the config
 func ValidateConfig(c Config) error {
 	if c.Build.Image == "" && c.Stack.BuildImage == "" {
@@ -159,6 +159,68 @@ uri = "noop-buildpack.tgz"
 		})
 	})
 
+	when("#ReadOrderConfig", func() {
+		var (
+			tmpDir    string
+			orderPath string
+			err       error
+		)
+
+		it.Before(func() {
+			tmpDir, err = os.MkdirTemp("", "order-config-test")
+			h.AssertNil(t, err)
+			orderPath = filepath.Join(tmpDir, "order.toml")
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(tmpDir))
+		})
+
+		it("returns the order", func() {
+			h.AssertNil(t, os.WriteFile(orderPath, []byte(`
+[[order]]
+[[order.group]]
+  id = "buildpack/1"
+  version = "0.0.1"
+[[order.group]]
+  id = "buildpack/2"
+  optional = true
+
+[[order-extensions]]
+[[order-extensions.group]]
+  id = "extension/1"
+`), 0666))
+
+			orderConfig, err := builder.ReadOrderConfig(orderPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(orderConfig.Order[0].Group), 2)
+			h.AssertEq(t, orderConfig.Order[0].Group[0].FullName(), "buildpack/1@0.0.1")
+			h.AssertTrue(t, orderConfig.Order[0].Group[1].Optional)
+			h.AssertEq(t, orderConfig.OrderExtensions[0].Group[0].ID, "extension/1")
+		})
+
+		it("errors when the order is empty", func() {
+			h.AssertNil(t, os.WriteFile(orderPath, []byte(`
+[[order-extensions]]
+[[order-extensions.group]]
+  id = "extension/1"
+`), 0666))
+
+			_, err := builder.ReadOrderConfig(orderPath)
+			h.AssertError(t, err, "'order' in")
+		})
+
+		it("errors on unknown elements", func() {
+			h.AssertNil(t, os.WriteFile(orderPath, []byte(`
+[[buildpacks]]
+  uri = "noop-buildpack.tgz"
+`), 0666))
+
+			_, err := builder.ReadOrderConfig(orderPath)
+			h.AssertError(t, err, "unknown configuration element 'buildpacks'")
+		})
+	})
+
 	when("#ValidateConfig()", func() {
 		var (
 			testID         = "testID"
@@ -11,6 +11,7 @@ import (
 	"github.com/buildpacks/pack/internal/config"
 	imagewriter "github.com/buildpacks/pack/internal/inspectimage/writer"
 	"github.com/buildpacks/pack/internal/term"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 )
@@ -21,6 +22,7 @@ type ConfigurableLogger interface {
 	WantTime(f bool)
 	WantQuiet(f bool)
 	WantVerbose(f bool)
+	WantJSON(f bool)
 }
 
 // NewPackCommand generates a Pack command
@@ -53,6 +55,10 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 						color.Disable(true)
 					}
 				}
+				if format, err := fs.GetString("output-format"); err == nil && format == "jsonl" {
+					color.Disable(true)
+					logger.WantJSON(true)
+				}
 				if flag, err := fs.GetBool("quiet"); err == nil {
 					logger.WantQuiet(flag)
 				}
@@ -81,9 +87,11 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.AddCommand(commands.NewExtensionCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
 	rootCmd.AddCommand(commands.NewConfigCommand(logger, cfg, cfgPath, packClient))
 	rootCmd.AddCommand(commands.InspectImage(logger, imagewriter.NewFactory(), cfg, packClient))
+	rootCmd.AddCommand(commands.NewImageCommand(logger, imagewriter.NewFactory(), packClient))
 	rootCmd.AddCommand(commands.NewStackCommand(logger))
 	rootCmd.AddCommand(commands.Rebase(logger, cfg, packClient))
-	rootCmd.AddCommand(commands.NewSBOMCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.Run(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewSBOMCommand(logger, cfg, imagewriter.NewFactory(), packClient))
 
 	rootCmd.AddCommand(commands.InspectBuildpack(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.InspectBuilder(logger, cfg, packClient, builderwriter.NewFactory()))
@@ -105,6 +113,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 		rootCmd.AddCommand(commands.RemoveRegistry(logger, cfg, cfgPath))
 		rootCmd.AddCommand(commands.YankBuildpack(logger, cfg, packClient))
 		rootCmd.AddCommand(commands.NewManifestCommand(logger, packClient))
+		rootCmd.AddCommand(commands.NewCacheCommand(logger, cfg, packClient))
 	}
 
 	packHome, err := config.PackHome()
@@ -146,5 +155,28 @@ func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc))
+	opts := []client.Option{client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc)}
+	// an invalid cache policy must not prevent running the commands used to fix the config
+	if cachePolicy, err := parseCachePolicy(cfg.CachePolicy); err != nil {
+		logger.Warnf("Ignoring invalid cache-policy in pack config: %s", err)
+	} else {
+		opts = append(opts, client.WithCachePolicy(cachePolicy))
+	}
+	return client.NewClient(opts...)
+}
+
+func parseCachePolicy(policy config.CachePolicy) (cache.PruneOptions, error) {
+	opts := cache.PruneOptions{MaxImages: policy.MaxImages}
+	var err error
+	if policy.MaxAge != "" {
+		if opts.MaxAge, err = cache.ParseAge(policy.MaxAge); err != nil {
+			return opts, err
+		}
+	}
+	if policy.MaxTotalSize != "" {
+		if opts.MaxTotalSize, err = cache.ParseSize(policy.MaxTotalSize); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
 }
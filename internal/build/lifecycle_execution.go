@@ -3,10 +3,12 @@ package build
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -42,6 +44,8 @@ type LifecycleExecution struct {
 	mountPaths   mountPaths
 	opts         LifecycleOptions
 	tmpDir       string
+	eventSinks   []logging.EventSink
+	eventsMutex  sync.Mutex
 }
 
 func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir string, opts LifecycleOptions) (*LifecycleExecution, error) {
@@ -74,6 +78,13 @@ func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir st
 		exec.logger = opts.Termui
 	}
 
+	if opts.EventSink != nil {
+		exec.eventSinks = append(exec.eventSinks, opts.EventSink)
+	}
+	if sink := logging.GetEventSink(exec.logger); sink != nil {
+		exec.eventSinks = append(exec.eventSinks, sink)
+	}
+
 	return exec, nil
 }
 
@@ -141,7 +152,7 @@ func (l *LifecycleExecution) AppPath() string {
 	return l.opts.AppPath
 }
 
-func (l LifecycleExecution) AppDir() string {
+func (l *LifecycleExecution) AppDir() string {
 	return l.mountPaths.appDir()
 }
 
@@ -193,6 +204,9 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		case cache.CacheBind:
 			buildCache = cache.NewBindCache(l.opts.Cache.Build, l.docker)
 			l.logger.Debugf("Using build cache dir %s", style.Symbol(buildCache.Name()))
+		case cache.CacheLayout:
+			buildCache = cache.NewLayoutCache(l.opts.Cache.Build, filepath.Join(l.tmpDir, "build-cache"), l.os)
+			l.logger.Debugf("Using build cache layout %s", style.Symbol(l.opts.Cache.Build.Source))
 		}
 	}
 
@@ -203,6 +217,12 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		l.logger.Debugf("Build cache %s cleared", style.Symbol(buildCache.Name()))
 	}
 
+	if layoutCache, ok := buildCache.(*cache.LayoutCache); ok {
+		if err := layoutCache.Restore(); err != nil {
+			return errors.Wrap(err, "restoring build cache from OCI layout")
+		}
+	}
+
 	launchCache, err := cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Launch, "launch", l.docker, l.logger)
 	if err != nil {
 		return err
@@ -340,13 +360,82 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		}
 
 		l.logger.Info(style.Step("EXPORTING"))
-		return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory)
+		if err := l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory); err != nil {
+			return err
+		}
+		return l.saveLayoutCache(buildCache)
 	}
 
 	if l.platformAPI.AtLeast("0.10") && l.hasExtensions() && !l.opts.UseCreatorWithExtensions {
 		return errors.New("builder has an order for extensions which is not supported when using the creator; re-run without '--trust-builder' or re-tag builder to avoid trusting it")
 	}
-	return l.Create(ctx, buildCache, launchCache, phaseFactory)
+	if err := l.Create(ctx, buildCache, launchCache, phaseFactory); err != nil {
+		return err
+	}
+	return l.saveLayoutCache(buildCache)
+}
+
+// saveLayoutCache writes the build cache back to its OCI layout once the lifecycle has exported it
+func (l *LifecycleExecution) saveLayoutCache(buildCache Cache) error {
+	layoutCache, ok := buildCache.(*cache.LayoutCache)
+	if !ok {
+		return nil
+	}
+	if err := layoutCache.Save(); err != nil {
+		return errors.Wrap(err, "saving build cache to OCI layout")
+	}
+	l.logger.Debugf("Build cache saved to %s", style.Symbol(layoutCache.Path()))
+	return nil
+}
+
+// runPhase runs the given phase and cleans it up, reporting when it starts and finishes
+func (l *LifecycleExecution) runPhase(ctx context.Context, name string, phase RunnerCleaner) error {
+	defer phase.Cleanup()
+
+	l.emit(logging.Event{Type: logging.EventPhaseStarted, Phase: name})
+	start := time.Now()
+	err := phase.Run(ctx)
+
+	finished := logging.Event{Type: logging.EventPhaseFinished, Phase: name, Duration: time.Since(start)}
+	if err != nil {
+		finished.Error = err.Error()
+	}
+	l.emit(finished)
+	return err
+}
+
+// reportAppUpload reports how long the given operation copying the app into the container takes, when the build
+// reports events
+func (l *LifecycleExecution) reportAppUpload(phase string, copyApp ContainerOperation) ContainerOperation {
+	if len(l.eventSinks) == 0 {
+		return copyApp
+	}
+
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		start := time.Now()
+		if err := copyApp(ctrClient, ctx, containerID, stdout, stderr); err != nil {
+			return err
+		}
+		l.emit(logging.Event{Type: logging.EventAppUploaded, Phase: phase, Duration: time.Since(start)})
+		return nil
+	}
+}
+
+// emit reports the given event to the event sinks of the build
+func (l *LifecycleExecution) emit(event logging.Event) {
+	if len(l.eventSinks) == 0 {
+		return
+	}
+
+	l.eventsMutex.Lock()
+	defer l.eventsMutex.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	for _, sink := range l.eventSinks {
+		sink(event)
+	}
 }
 
 func (l *LifecycleExecution) Cleanup() error {
@@ -417,7 +506,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	case cache.Image:
 		flags = append(flags, "-cache-image", buildCache.Name())
 		cacheBindOp = WithBinds(l.opts.Volumes...)
-	case cache.Volume, cache.Bind:
+	case cache.Volume, cache.Bind, cache.Layout:
 		cacheBindOp = WithBinds(append(l.opts.Volumes, fmt.Sprintf("%s:%s", buildCache.Name(), l.mountPaths.cacheDir()))...)
 	}
 
@@ -432,7 +521,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 		WithNetwork(l.opts.Network),
 		cacheBindOp,
 		WithContainerOperations(WriteProjectMetadata(l.mountPaths.projectPath(), l.opts.ProjectMetadata, l.os)),
-		WithContainerOperations(CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)),
+		WithContainerOperations(l.reportAppUpload("creator", CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter))),
 		If(l.opts.SBOMDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.sbomDir(), l.opts.SBOMDestinationDir))),
@@ -469,8 +558,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	}
 
 	create := phaseFactory.New(NewPhaseConfigProvider("creator", l, opts...))
-	defer create.Cleanup()
-	return create.Run(ctx)
+	return l.runPhase(ctx, "creator", create)
 }
 
 func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFactory) error {
@@ -492,7 +580,7 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 		WithBinds(l.opts.Volumes...),
 		WithContainerOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
-			CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter),
+			l.reportAppUpload("detector", CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)),
 		),
 		WithFlags(flags...),
 		If(l.hasExtensions(), WithPostContainerRunOperations(
@@ -503,8 +591,7 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 	)
 
 	detect := phaseFactory.New(configProvider)
-	defer detect.Cleanup()
-	return detect.Run(ctx)
+	return l.runPhase(ctx, "detector", detect)
 }
 
 func (l *LifecycleExecution) extensionsAreExperimental() bool {
@@ -525,7 +612,7 @@ func (l *LifecycleExecution) Restore(ctx context.Context, buildCache Cache, kani
 	case cache.Image:
 		flags = append(flags, "-cache-image", buildCache.Name())
 		registryImages = append(registryImages, buildCache.Name())
-	case cache.Volume:
+	case cache.Volume, cache.Layout:
 		flags = append(flags, "-cache-dir", l.mountPaths.cacheDir())
 		cacheBindOp = WithBinds(fmt.Sprintf("%s:%s", buildCache.Name(), l.mountPaths.cacheDir()))
 	}
@@ -604,8 +691,7 @@ func (l *LifecycleExecution) Restore(ctx context.Context, buildCache Cache, kani
 	)
 
 	restore := phaseFactory.New(configProvider)
-	defer restore.Cleanup()
-	return restore.Run(ctx)
+	return l.runPhase(ctx, "restorer", restore)
 }
 
 func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCache Cache, phaseFactory PhaseFactory) error {
@@ -622,7 +708,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 		switch buildCache.Type() {
 		case cache.Image:
 			flags = append(flags, "-cache-image", buildCache.Name())
-		case cache.Volume:
+		case cache.Volume, cache.Layout:
 			if platformAPILessThan07 {
 				args = append([]string{"-cache-dir", l.mountPaths.cacheDir()}, args...)
 				cacheBindOp = WithBinds(fmt.Sprintf("%s:%s", buildCache.Name(), l.mountPaths.cacheDir()))
@@ -748,8 +834,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 		analyze = phaseFactory.New(configProvider)
 	}
 
-	defer analyze.Cleanup()
-	return analyze.Run(ctx)
+	return l.runPhase(ctx, "analyzer", analyze)
 }
 
 func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactory) error {
@@ -765,8 +850,7 @@ func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactor
 	)
 
 	build := phaseFactory.New(configProvider)
-	defer build.Cleanup()
-	return build.Run(ctx)
+	return l.runPhase(ctx, "builder", build)
 }
 
 func (l *LifecycleExecution) ExtendBuild(ctx context.Context, kanikoCache Cache, phaseFactory PhaseFactory, experimental bool) error {
@@ -786,8 +870,7 @@ func (l *LifecycleExecution) ExtendBuild(ctx context.Context, kanikoCache Cache,
 	)
 
 	extend := phaseFactory.New(configProvider)
-	defer extend.Cleanup()
-	return extend.Run(ctx)
+	return l.runPhase(ctx, "extender", extend)
 }
 
 func (l *LifecycleExecution) ExtendRun(ctx context.Context, kanikoCache Cache, phaseFactory PhaseFactory, runImageName string, experimental bool) error {
@@ -808,8 +891,7 @@ func (l *LifecycleExecution) ExtendRun(ctx context.Context, kanikoCache Cache, p
 	)
 
 	extend := phaseFactory.New(configProvider)
-	defer extend.Cleanup()
-	return extend.Run(ctx)
+	return l.runPhase(ctx, "extender", extend)
 }
 
 func determineDefaultProcessType(platformAPI *api.Version, providedValue string) string {
@@ -859,7 +941,7 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 	switch buildCache.Type() {
 	case cache.Image:
 		flags = append(flags, "-cache-image", buildCache.Name())
-	case cache.Volume:
+	case cache.Volume, cache.Layout:
 		cacheBindOp = WithBinds(fmt.Sprintf("%s:%s", buildCache.Name(), l.mountPaths.cacheDir()))
 	}
 
@@ -931,8 +1013,7 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 		export = phaseFactory.New(NewPhaseConfigProvider("exporter", l, opts...))
 	}
 
-	defer export.Cleanup()
-	return export.Run(ctx)
+	return l.runPhase(ctx, "exporter", export)
 }
 
 func (l *LifecycleExecution) withLogLevel(args ...string) []string {
@@ -583,6 +583,34 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("layout build cache", func() {
+				var layoutPath string
+
+				providedUseCreator = false
+				lifecycleOps = append(lifecycleOps, func(options *build.LifecycleOptions) {
+					layoutPath = filepath.Join(tmpDir, "layout-cache")
+					options.Cache.Build = cache.CacheInfo{Format: cache.CacheLayout, Source: layoutPath}
+				})
+
+				it("mounts the cache working directory and saves it to the layout after exporting", func() {
+					err := lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					for _, entry := range fakePhaseFactory.NewCalledWithProvider {
+						switch entry.Name() {
+						case "restorer":
+							h.AssertSliceContainsInOrder(t, entry.ContainerConfig().Cmd, "-cache-dir", "/cache")
+							h.AssertSliceContains(t, entry.HostConfig().Binds, filepath.Join(tmpDir, "build-cache")+":/cache")
+						case "exporter":
+							h.AssertSliceContains(t, entry.HostConfig().Binds, filepath.Join(tmpDir, "build-cache")+":/cache")
+						}
+					}
+					h.AssertEq(t, len(h.ReadIndexManifest(t, layoutPath).Manifests), 1)
+				})
+			})
+
 			when("extensions", func() {
 				providedUseCreator = false
 				providedOrderExt = dist.Order{dist.OrderEntry{Group: []dist.ModuleRef{ /* don't care */ }}}
@@ -1362,6 +1390,28 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("an event sink is set", func() {
+		it("reports when phases start and finish", func() {
+			var events []logging.Event
+			lifecycle := newTestLifecycleExec(t, false, tmpDir, func(opts *build.LifecycleOptions) {
+				opts.EventSink = func(event logging.Event) {
+					events = append(events, event)
+				}
+			})
+
+			h.AssertNil(t, lifecycle.Detect(context.Background(), fakePhaseFactory))
+
+			h.AssertEq(t, len(events), 2)
+			h.AssertEq(t, events[0].Type, logging.EventPhaseStarted)
+			h.AssertEq(t, events[0].Phase, "detector")
+			h.AssertEq(t, events[1].Type, logging.EventPhaseFinished)
+			h.AssertEq(t, events[1].Phase, "detector")
+			h.AssertEq(t, events[1].Error, "")
+			h.AssertFalse(t, events[1].Time.Before(events[0].Time))
+			h.AssertEq(t, fakePhase.CleanupCallCount, 1)
+		})
+	})
+
 	when("#Detect", func() {
 		it.Before(func() {
 			err := lifecycle.Detect(context.Background(), fakePhaseFactory)
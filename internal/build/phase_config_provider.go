@@ -68,6 +68,11 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		op(provider)
 	}
 
+	if len(lifecycleExec.eventSinks) > 0 {
+		provider.infoWriter = newPhaseEventWriter(provider.infoWriter, name, lifecycleExec)
+		provider.errorWriter = newPhaseEventWriter(provider.errorWriter, name, lifecycleExec)
+	}
+
 	provider.ctrConf.Entrypoint = []string{""} // override entrypoint in case it is set
 	provider.ctrConf.Cmd = append([]string{"/cnb/lifecycle/" + name}, provider.ctrConf.Cmd...)
 
@@ -205,8 +210,8 @@ func WithImage(image string) PhaseConfigProviderOperation {
 func WithLogPrefix(prefix string) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
 		if prefix != "" {
-			provider.infoWriter = logging.NewPrefixWriter(provider.infoWriter, prefix)
-			provider.errorWriter = logging.NewPrefixWriter(provider.errorWriter, prefix)
+			provider.infoWriter = logging.NewPrefixedWriter(provider.infoWriter, prefix)
+			provider.errorWriter = logging.NewPrefixedWriter(provider.errorWriter, prefix)
 		}
 	}
 }
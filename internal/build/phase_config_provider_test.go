@@ -9,6 +9,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -294,6 +295,87 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("an event sink is set", func() {
+			var (
+				events        []logging.Event
+				outBuf        bytes.Buffer
+				lifecycleExec *build.LifecycleExecution
+			)
+
+			it.Before(func() {
+				events = nil
+				outBuf.Reset()
+
+				docker, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.38"))
+				h.AssertNil(t, err)
+
+				defaultBuilder, err := fakes.NewFakeBuilder()
+				h.AssertNil(t, err)
+
+				imageRef, err := name.ParseReference("some/image", name.WeakValidation)
+				h.AssertNil(t, err)
+
+				opts := build.LifecycleOptions{
+					AppPath: "some-app-path",
+					Builder: defaultBuilder,
+					Image:   imageRef,
+					EventSink: func(event logging.Event) {
+						events = append(events, event)
+					},
+				}
+
+				lifecycleExec, err = build.NewLifecycleExecution(logging.NewJSONLogger(&outBuf), docker, "some-temp-dir", opts)
+				h.AssertNil(t, err)
+			})
+
+			it("reports the build events found in the phase output", func() {
+				phaseConfigProvider := build.NewPhaseConfigProvider("exporter", lifecycleExec, build.WithLogPrefix("exporter"))
+
+				_, err := phaseConfigProvider.InfoWriter().Write([]byte("Reusing layer 'some/buildpack:some-layer'\nReusing cache layer 'some/buildpack:cached'\n*** Digest: sha256:abc"))
+				h.AssertNil(t, err)
+				_, err = phaseConfigProvider.InfoWriter().Write([]byte("123\n"))
+				h.AssertNil(t, err)
+				_, err = phaseConfigProvider.ErrorWriter().Write([]byte("ERROR: some error\n"))
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, len(events), 4)
+				h.AssertEq(t, events[0].Type, logging.EventLayerReused)
+				h.AssertEq(t, events[0].Buildpack, "some/buildpack")
+				h.AssertEq(t, events[0].Layer, "some/buildpack:some-layer")
+				h.AssertEq(t, events[1].Type, logging.EventCacheHit)
+				h.AssertEq(t, events[1].Layer, "some/buildpack:cached")
+				h.AssertEq(t, events[2].Type, logging.EventImageExported)
+				h.AssertEq(t, events[2].Image, "index.docker.io/some/image:latest")
+				h.AssertEq(t, events[2].Digest, "sha256:abc123")
+				h.AssertEq(t, events[3].Type, logging.EventError)
+				h.AssertEq(t, events[3].Message, "some error")
+				for _, event := range events {
+					h.AssertEq(t, event.Phase, "exporter")
+				}
+
+				// the output and the events are written by the logger too
+				h.AssertContains(t, outBuf.String(), `"type":"output","phase":"exporter","level":"info","message":"Reusing layer 'some/buildpack:some-layer'"`)
+				h.AssertContains(t, outBuf.String(), `"type":"image-exported","phase":"exporter","image":"index.docker.io/some/image:latest","digest":"sha256:abc123"`)
+			})
+
+			it("attributes the creator output to the step it runs", func() {
+				phaseConfigProvider := build.NewPhaseConfigProvider("creator", lifecycleExec)
+
+				_, err := phaseConfigProvider.InfoWriter().Write([]byte("===> DETECTING\npass: some/buildpack@1.0\nfail: other/buildpack@2.0\n===> RESTORING\nRestoring data for \"some/buildpack:cached\" from cache\n"))
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, len(events), 3)
+				h.AssertEq(t, events[0].Type, logging.EventDetectPassed)
+				h.AssertEq(t, events[0].Buildpack, "some/buildpack@1.0")
+				h.AssertEq(t, events[0].Phase, "detector")
+				h.AssertEq(t, events[1].Type, logging.EventDetectFailed)
+				h.AssertEq(t, events[1].Buildpack, "other/buildpack@2.0")
+				h.AssertEq(t, events[2].Type, logging.EventCacheHit)
+				h.AssertEq(t, events[2].Layer, "some/buildpack:cached")
+				h.AssertEq(t, events[2].Phase, "restorer")
+			})
+		})
+
 		when("verbose", func() {
 			it("prints debug information about the phase", func() {
 				var outBuf bytes.Buffer
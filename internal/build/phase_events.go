@@ -0,0 +1,128 @@
+package build
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+var (
+	colorCodeMatcher    = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	creatorStepMatcher  = regexp.MustCompile(`^===> ([A-Z]+)`)
+	detectResultMatcher = regexp.MustCompile(`^(pass|fail): (\S+)$`)
+	reusedLayerMatcher  = regexp.MustCompile(`^Reusing layer '(.+)'$`)
+	cachedLayerMatcher  = regexp.MustCompile(`^(?:Reusing cache layer '(.+)'|Restoring data for "(.+)" from cache)$`)
+	digestMatcher       = regexp.MustCompile(`^\*\*\* Digest: (\S+)$`)
+	warningMatcher      = regexp.MustCompile(`^Warning: (.*)$`)
+	errorMatcher        = regexp.MustCompile(`^ERROR: (.*)$`)
+)
+
+// creatorSteps maps the steps announced by the creator to the phases they run
+var creatorSteps = map[string]string{
+	"ANALYZING": "analyzer",
+	"DETECTING": "detector",
+	"RESTORING": "restorer",
+	"BUILDING":  "builder",
+	"EXPORTING": "exporter",
+}
+
+// phaseEventWriter passes the output of a lifecycle phase through, reporting the build events found in it
+type phaseEventWriter struct {
+	out     io.Writer
+	phase   string
+	creator bool
+	image   string
+	emit    func(logging.Event)
+	buf     *bytes.Buffer
+}
+
+func newPhaseEventWriter(out io.Writer, phase string, lifecycleExec *LifecycleExecution) *phaseEventWriter {
+	var image string
+	if lifecycleExec.opts.Image != nil {
+		image = lifecycleExec.opts.Image.Name()
+	}
+	return &phaseEventWriter{
+		out:     out,
+		phase:   phase,
+		creator: phase == "creator",
+		image:   image,
+		emit:    lifecycleExec.emit,
+		buf:     &bytes.Buffer{},
+	}
+}
+
+func (w *phaseEventWriter) Write(data []byte) (int, error) {
+	n, err := w.out.Write(data)
+	if err != nil {
+		return n, err
+	}
+
+	w.buf.Write(data)
+	for {
+		line, err := w.buf.ReadBytes('\n')
+		if err != nil {
+			// keep the incomplete line for the next write
+			w.buf.Write(line)
+			break
+		}
+		w.parse(string(line))
+	}
+
+	return n, nil
+}
+
+func (w *phaseEventWriter) parse(line string) {
+	line = strings.TrimRight(colorCodeMatcher.ReplaceAllString(line, ""), "\r\n")
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+
+	if m := creatorStepMatcher.FindStringSubmatch(line); m != nil {
+		if phase, ok := creatorSteps[m[1]]; ok && w.creator {
+			w.phase = phase
+		}
+		return
+	}
+
+	if event, ok := w.eventFor(line); ok {
+		event.Phase = w.phase
+		w.emit(event)
+	}
+}
+
+func (w *phaseEventWriter) eventFor(line string) (logging.Event, bool) {
+	if m := detectResultMatcher.FindStringSubmatch(line); m != nil {
+		if m[1] == "pass" {
+			return logging.Event{Type: logging.EventDetectPassed, Buildpack: m[2]}, true
+		}
+		return logging.Event{Type: logging.EventDetectFailed, Buildpack: m[2]}, true
+	}
+	if m := reusedLayerMatcher.FindStringSubmatch(line); m != nil {
+		return layerEvent(logging.EventLayerReused, m[1]), true
+	}
+	if m := cachedLayerMatcher.FindStringSubmatch(line); m != nil {
+		return layerEvent(logging.EventCacheHit, m[1]+m[2]), true
+	}
+	if m := digestMatcher.FindStringSubmatch(line); m != nil {
+		return logging.Event{Type: logging.EventImageExported, Image: w.image, Digest: m[1]}, true
+	}
+	if m := warningMatcher.FindStringSubmatch(line); m != nil {
+		return logging.Event{Type: logging.EventWarning, Message: m[1]}, true
+	}
+	if m := errorMatcher.FindStringSubmatch(line); m != nil {
+		return logging.Event{Type: logging.EventError, Message: m[1]}, true
+	}
+	return logging.Event{}, false
+}
+
+// layerEvent creates an event for a layer named '<buildpack-id>:<layer-name>' by the lifecycle
+func layerEvent(eventType logging.EventType, layer string) logging.Event {
+	event := logging.Event{Type: eventType, Layer: layer}
+	if i := strings.LastIndexByte(layer, ':'); i > 0 {
+		event.Buildpack = layer[:i]
+	}
+	return event
+}
@@ -76,6 +76,8 @@ type Builder struct {
 	lifecycleDescriptor  LifecycleDescriptor
 	additionalBuildpacks buildpack.ManagedCollection
 	additionalExtensions buildpack.ManagedCollection
+	removedBuildpacks    []dist.ModuleInfo
+	removedExtensions    []dist.ModuleInfo
 	metadata             Metadata
 	mixins               []string
 	env                  map[string]string
@@ -86,6 +88,7 @@ type Builder struct {
 	orderExtensions      dist.Order
 	validateMixins       bool
 	saveProhibited       bool
+	revalidateModules    bool
 }
 
 type orderTOML struct {
@@ -135,12 +138,20 @@ func New(baseImage imgutil.Image, name string, ops ...BuilderOption) (*Builder,
 
 func constructBuilder(img imgutil.Image, newName string, errOnMissingLabel bool, ops ...BuilderOption) (*Builder, error) {
 	var metadata Metadata
-	if ok, err := dist.GetLabel(img, metadataLabel, &metadata); err != nil {
+	ok, err := dist.GetLabel(img, metadataLabel, &metadata)
+	if err != nil {
 		return nil, errors.Wrapf(err, "getting label %s", metadataLabel)
 	} else if !ok && errOnMissingLabel {
 		return nil, fmt.Errorf("builder %s missing label %s -- try recreating builder", style.Symbol(img.Name()), style.Symbol(metadataLabel))
 	}
 
+	if !ok {
+		// recording the top layer of the build image allows the builder to be rebased onto another build image
+		if topLayer, err := img.TopLayer(); err == nil && topLayer != "" {
+			metadata.BuildImage = &BuildImageMetadata{TopLayer: topLayer}
+		}
+	}
+
 	opts := &options{}
 	for _, op := range ops {
 		if err := op(opts); err != nil {
@@ -375,12 +386,33 @@ func (b *Builder) AddExtension(bp buildpack.BuildModule) {
 	b.metadata.Extensions = append(b.metadata.Extensions, bp.Descriptor().Info())
 }
 
+// RemoveBuildpack removes a buildpack already on the builder. Unless a buildpack with the same ID and version is added,
+// its layer is whited out when the builder is saved.
+func (b *Builder) RemoveBuildpack(info dist.ModuleInfo) {
+	b.metadata.Buildpacks = removeModuleInfo(b.metadata.Buildpacks, info)
+	b.removedBuildpacks = append(b.removedBuildpacks, info)
+}
+
+// RemoveExtension removes an extension already on the builder. Unless an extension with the same ID and version is
+// added, its layer is whited out when the builder is saved.
+func (b *Builder) RemoveExtension(info dist.ModuleInfo) {
+	b.metadata.Extensions = removeModuleInfo(b.metadata.Extensions, info)
+	b.removedExtensions = append(b.removedExtensions, info)
+}
+
 // SetLifecycle sets the lifecycle of the builder
 func (b *Builder) SetLifecycle(lifecycle Lifecycle) {
 	b.lifecycle = lifecycle
 	b.lifecycleDescriptor = lifecycle.Descriptor()
 }
 
+// ReplaceLifecycle sets the lifecycle of an existing builder, validating the modules on the builder against it when
+// the builder is saved
+func (b *Builder) ReplaceLifecycle(lifecycle Lifecycle) {
+	b.SetLifecycle(lifecycle)
+	b.revalidateModules = true
+}
+
 // SetEnv sets an environment variable to a value
 func (b *Builder) SetEnv(env map[string]string) {
 	b.env = env
@@ -442,6 +474,96 @@ func (b *Builder) SetValidateMixins(to bool) {
 	b.validateMixins = to
 }
 
+// Rebase replaces the build image of the builder, keeping the layers added to the builder on top of the new build
+// image. The stack, mixins and distribution of the builder are read from the new build image, and the modules on the
+// builder are validated against it when the builder is saved.
+func (b *Builder) Rebase(newBase imgutil.Image) error {
+	topLayer, err := b.BuildImageTopLayer()
+	if err != nil {
+		return err
+	}
+
+	platform, err := imagePlatform(b.image)
+	if err != nil {
+		return errors.Wrapf(err, "getting platform of builder %s", style.Symbol(b.Name()))
+	}
+	newPlatform, err := imagePlatform(newBase)
+	if err != nil {
+		return errors.Wrapf(err, "getting platform of build image %s", style.Symbol(newBase.Name()))
+	}
+	if !samePlatform(newPlatform, platform) {
+		return fmt.Errorf(
+			"build image %s is for %s but builder %s is for %s",
+			style.Symbol(newBase.Name()),
+			style.Symbol(newPlatform.ValuesAsPlatform()),
+			style.Symbol(b.Name()),
+			style.Symbol(platform.ValuesAsPlatform()),
+		)
+	}
+
+	uid, gid, err := userAndGroupIDs(newBase)
+	if err != nil {
+		return err
+	}
+	if uid != b.uid || gid != b.gid {
+		return fmt.Errorf(
+			"build image %s runs as %s but builder %s was created for %s",
+			style.Symbol(newBase.Name()),
+			style.Symbol(fmt.Sprintf("%d:%d", uid, gid)),
+			style.Symbol(b.Name()),
+			style.Symbol(fmt.Sprintf("%d:%d", b.uid, b.gid)),
+		)
+	}
+
+	stackID, err := newBase.Label(stackLabel)
+	if err != nil {
+		return errors.Wrapf(err, "get label %s from image %s", style.Symbol(stackLabel), style.Symbol(newBase.Name()))
+	}
+	if b.StackID != "" && stackID != b.StackID {
+		return fmt.Errorf(
+			"stack %s of builder is incompatible with stack %s from build image %s",
+			style.Symbol(b.StackID),
+			style.Symbol(stackID),
+			style.Symbol(newBase.Name()),
+		)
+	}
+
+	var mixins []string
+	if _, err = dist.GetLabel(newBase, stack.MixinsLabel, &mixins); err != nil {
+		return errors.Wrapf(err, "getting label %s", stack.MixinsLabel)
+	}
+
+	newTopLayer, err := newBase.TopLayer()
+	if err != nil {
+		return errors.Wrapf(err, "getting top layer of build image %s", style.Symbol(newBase.Name()))
+	}
+
+	if err := b.image.Rebase(topLayer, newBase); err != nil {
+		return errors.Wrapf(err, "rebasing builder onto build image %s", style.Symbol(newBase.Name()))
+	}
+
+	for _, label := range []string{stackLabel, lifecycleplatform.OSDistroNameLabel, lifecycleplatform.OSDistroVersionLabel} {
+		value, err := newBase.Label(label)
+		if err != nil {
+			return errors.Wrapf(err, "get label %s from image %s", style.Symbol(label), style.Symbol(newBase.Name()))
+		}
+		if value == "" {
+			err = b.image.RemoveLabel(label)
+		} else {
+			err = b.image.SetLabel(label, value)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "setting label %s", style.Symbol(label))
+		}
+	}
+
+	b.StackID = stackID
+	b.mixins = mixins
+	b.metadata.BuildImage = &BuildImageMetadata{TopLayer: newTopLayer}
+	b.revalidateModules = true
+	return nil
+}
+
 // Save saves the builder
 func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) error {
 	if b.saveProhibited {
@@ -491,11 +613,22 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		return errors.Wrap(err, "validating extensions")
 	}
 
+	// modules already on the builder are validated again when its build image or lifecycle is replaced
+	if b.revalidateModules {
+		if err := b.validateModulesOnBuilder(); err != nil {
+			return errors.Wrap(err, "validating modules on builder")
+		}
+	}
+
 	bpLayers := dist.ModuleLayers{}
 	if _, err := dist.GetLabel(b.image, dist.BuildpackLayersLabel, &bpLayers); err != nil {
 		return errors.Wrapf(err, "getting label %s", dist.BuildpackLayersLabel)
 	}
 
+	if err := b.removeModules(buildpack.KindBuildpack, tmpDir, b.removedBuildpacks, bpLayers); err != nil {
+		return err
+	}
+
 	var excludedBuildpacks []buildpack.BuildModule
 	excludedBuildpacks, err = b.addFlattenedModules(buildpack.KindBuildpack, logger, tmpDir, b.image, b.additionalBuildpacks.FlattenedModules(), bpLayers)
 	if err != nil {
@@ -506,6 +639,9 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 	if err != nil {
 		return err
 	}
+	if err := validateNestedOrders(bpLayers); err != nil {
+		return errors.Wrap(err, "validating buildpacks")
+	}
 	if err := dist.SetLabel(b.image, dist.BuildpackLayersLabel, bpLayers); err != nil {
 		return err
 	}
@@ -515,6 +651,10 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		return errors.Wrapf(err, "getting label %s", dist.ExtensionLayersLabel)
 	}
 
+	if err := b.removeModules(buildpack.KindExtension, tmpDir, b.removedExtensions, extLayers); err != nil {
+		return err
+	}
+
 	var excludedExtensions []buildpack.BuildModule
 	excludedExtensions, err = b.addFlattenedModules(buildpack.KindExtension, logger, tmpDir, b.image, b.additionalExtensions.FlattenedModules(), extLayers)
 	if err != nil {
@@ -581,6 +721,13 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		if err := b.image.AddLayer(buildConfigEnvTar); err != nil {
 			return errors.Wrap(err, "adding build-config-env layer")
 		}
+
+		if b.metadata.BuildConfigEnv == nil {
+			b.metadata.BuildConfigEnv = map[string]string{}
+		}
+		for name, value := range b.buildConfigEnv {
+			b.metadata.BuildConfigEnv[name] = value
+		}
 	}
 
 	if len(b.env) > 0 {
@@ -685,6 +832,100 @@ func (b *Builder) addExplodedModules(kind string, logger logging.Logger, tmpDir
 	return nil
 }
 
+// validateNestedOrders checks that the buildpacks in the orders of the composite buildpacks on the builder, whether
+// already on the builder or added to it, are still on the builder once modules are added and removed
+func validateNestedOrders(layers dist.ModuleLayers) error {
+	ids := make([]string, 0, len(layers))
+	for id := range layers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		versions := make([]string, 0, len(layers[id]))
+		for version := range layers[id] {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+
+		for _, version := range versions {
+			for _, group := range layers[id][version].Order {
+				for _, ref := range group.Group {
+					found := len(layers[ref.ID]) > 0
+					if ref.Version != "" {
+						_, found = layers[ref.ID][ref.Version]
+					}
+					if !found {
+						return fmt.Errorf(
+							"buildpack %s refers to buildpack %s, which is not on the builder",
+							style.Symbol(dist.ModuleInfo{ID: id, Version: version}.FullName()),
+							style.Symbol(ref.FullName()),
+						)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// removeModules whites out the directories of the removed modules and drops them from the layers metadata. Modules
+// added again with the same ID and version are left for addExplodedModules to replace.
+func (b *Builder) removeModules(kind, tmpDir string, removed []dist.ModuleInfo, layers dist.ModuleLayers) error {
+	added := map[string]bool{}
+	for _, module := range b.AllModules(kind) {
+		added[module.Descriptor().Info().FullName()] = true
+	}
+
+	var toWhiteout []dist.ModuleInfo
+	for _, info := range removed {
+		if _, ok := layers[info.ID][info.Version]; !ok || added[info.FullName()] {
+			continue
+		}
+		toWhiteout = append(toWhiteout, info)
+		delete(layers[info.ID], info.Version)
+		if len(layers[info.ID]) == 0 {
+			delete(layers, info.ID)
+		}
+	}
+	if len(toWhiteout) == 0 {
+		return nil
+	}
+
+	modulesDir := buildpacksDir
+	if kind == buildpack.KindExtension {
+		modulesDir = dist.ExtensionsDir
+	}
+
+	fh, err := os.Create(filepath.Join(tmpDir, kind+"-removed.tar"))
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	lw := b.layerWriterFactory.NewWriter(fh)
+	defer lw.Close()
+
+	for _, info := range toWhiteout {
+		if err := lw.WriteHeader(&tar.Header{
+			Name:    path.Join(modulesDir, strings.ReplaceAll(info.ID, "/", "_"), fmt.Sprintf(".wh.%s", info.Version)),
+			Size:    int64(0),
+			Mode:    0644,
+			ModTime: archive.NormalizedDateTime,
+		}); err != nil {
+			return errors.Wrapf(err, "creating whiteout for %s %s", kind, style.Symbol(info.FullName()))
+		}
+	}
+	if err := lw.Close(); err != nil {
+		return err
+	}
+
+	if err := b.image.AddLayer(fh.Name()); err != nil {
+		return errors.Wrapf(err, "adding layer removing %ss", kind)
+	}
+	return nil
+}
+
 func (b *Builder) addFlattenedModules(kind string, logger logging.Logger, tmpDir string, image imgutil.Image, flattenModules [][]buildpack.BuildModule, layers dist.ModuleLayers) ([]buildpack.BuildModule, error) {
 	collectionToAdd := map[string]moduleWithDiffID{}
 	var (
@@ -796,6 +1037,16 @@ func resolveRef(moduleList []dist.ModuleInfo, ref dist.ModuleRef, kind string) (
 	return ref, nil
 }
 
+// removeModuleInfo returns the list without the first module matching info
+func removeModuleInfo(moduleList []dist.ModuleInfo, info dist.ModuleInfo) []dist.ModuleInfo {
+	for i, el := range moduleList {
+		if el.ID == info.ID && el.Version == info.Version {
+			return append(moduleList[:i:i], moduleList[i+1:]...)
+		}
+	}
+	return moduleList
+}
+
 func hasElementWithVersion(moduleList []dist.ModuleInfo, version string) bool {
 	for _, el := range moduleList {
 		if el.Version == version {
@@ -814,7 +1065,7 @@ func (b *Builder) validateBuildpacks() error {
 
 	for _, bp := range b.AllModules(buildpack.KindBuildpack) {
 		bpd := bp.Descriptor()
-		if err := validateLifecycleCompat(bpd, b.LifecycleDescriptor()); err != nil {
+		if err := ValidateLifecycleCompat(bpd, b.LifecycleDescriptor()); err != nil {
 			return err
 		}
 
@@ -859,6 +1110,132 @@ func (b *Builder) validateBuildpacks() error {
 	return nil
 }
 
+// validateModulesOnBuilder validates the modules in the layers of the builder, as recorded in its layers metadata,
+// against the lifecycle and the build image
+func (b *Builder) validateModulesOnBuilder() error {
+	buildOS, err := b.Image().OS()
+	if err != nil {
+		return err
+	}
+	buildArch, err := b.Image().Architecture()
+	if err != nil {
+		return err
+	}
+	buildDistroName, err := b.Image().Label(lifecycleplatform.OSDistroNameLabel)
+	if err != nil {
+		return err
+	}
+	buildDistroVersion, err := b.Image().Label(lifecycleplatform.OSDistroVersionLabel)
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range []string{buildpack.KindBuildpack, buildpack.KindExtension} {
+		label := dist.BuildpackLayersLabel
+		if kind == buildpack.KindExtension {
+			label = dist.ExtensionLayersLabel
+		}
+		layers := dist.ModuleLayers{}
+		if _, err := dist.GetLabel(b.image, label, &layers); err != nil {
+			return errors.Wrapf(err, "getting label %s", label)
+		}
+
+		for _, id := range sortedModuleIDs(layers) {
+			for _, version := range sortedModuleVersions(layers[id]) {
+				info := layers[id][version]
+				var descriptor buildpack.Descriptor = &dist.ExtensionDescriptor{
+					WithAPI:  info.API,
+					WithInfo: dist.ModuleInfo{ID: id, Version: version},
+				}
+				if kind == buildpack.KindBuildpack {
+					descriptor = &dist.BuildpackDescriptor{
+						WithAPI:     info.API,
+						WithInfo:    dist.ModuleInfo{ID: id, Version: version},
+						WithStacks:  info.Stacks,
+						WithTargets: info.Targets,
+						WithOrder:   info.Order,
+					}
+				}
+
+				if info.API != nil {
+					if err := ValidateLifecycleCompat(descriptor, b.LifecycleDescriptor()); err != nil {
+						return err
+					}
+				}
+				if !b.validateMixins || len(descriptor.Order()) > 0 {
+					continue
+				}
+				if err := descriptor.EnsureStackSupport(b.StackID, b.Mixins(), false); err != nil {
+					return err
+				}
+				if err := descriptor.EnsureTargetSupport(buildOS, buildArch, buildDistroName, buildDistroVersion); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func sortedModuleIDs(layers dist.ModuleLayers) []string {
+	var ids []string
+	for id := range layers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedModuleVersions(versions map[string]dist.ModuleLayerInfo) []string {
+	var keys []string
+	for version := range versions {
+		keys = append(keys, version)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// BuildImageTopLayer returns the diffID of the top layer of the build image of the builder. For builders created before
+// it was recorded, it is the layer below the first layer with the default directories pack adds to builders.
+func (b *Builder) BuildImageTopLayer() (string, error) {
+	if b.metadata.BuildImage != nil && b.metadata.BuildImage.TopLayer != "" {
+		return b.metadata.BuildImage.TopLayer, nil
+	}
+
+	notFound := fmt.Errorf("unable to find the layers of the build image of builder %s -- try recreating builder", style.Symbol(b.Name()))
+	underlyingImage := b.image.UnderlyingImage()
+	if underlyingImage == nil {
+		return "", notFound
+	}
+	configFile, err := underlyingImage.ConfigFile()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading config of builder %s", style.Symbol(b.Name()))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "builder-dirs")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dirsTar, err := b.defaultDirsLayer(tmpDir)
+	if err != nil {
+		return "", err
+	}
+	dirsDiffID, err := dist.LayerDiffID(dirsTar)
+	if err != nil {
+		return "", err
+	}
+
+	for i, diffID := range configFile.RootFS.DiffIDs {
+		if diffID == dirsDiffID && i > 0 {
+			return configFile.RootFS.DiffIDs[i-1].String(), nil
+		}
+	}
+	return "", notFound
+}
+
 func validateExtensions(lifecycleDescriptor LifecycleDescriptor, allExtensions []dist.ModuleInfo, extsToValidate []buildpack.BuildModule) error {
 	extLookup := map[string]interface{}{}
 
@@ -868,7 +1245,7 @@ func validateExtensions(lifecycleDescriptor LifecycleDescriptor, allExtensions [
 
 	for _, ext := range extsToValidate {
 		extd := ext.Descriptor()
-		if err := validateLifecycleCompat(extd, lifecycleDescriptor); err != nil {
+		if err := ValidateLifecycleCompat(extd, lifecycleDescriptor); err != nil {
 			return err
 		}
 	}
@@ -876,7 +1253,8 @@ func validateExtensions(lifecycleDescriptor LifecycleDescriptor, allExtensions [
 	return nil
 }
 
-func validateLifecycleCompat(descriptor buildpack.Descriptor, lifecycleDescriptor LifecycleDescriptor) error {
+// ValidateLifecycleCompat checks that the Buildpack API of a module is supported by the lifecycle
+func ValidateLifecycleCompat(descriptor buildpack.Descriptor, lifecycleDescriptor LifecycleDescriptor) error {
 	compatible := false
 	for _, version := range append(lifecycleDescriptor.APIs.Buildpack.Supported, lifecycleDescriptor.APIs.Buildpack.Deprecated...) {
 		compatible = version.Compare(descriptor.API()) == 0
@@ -899,6 +1277,31 @@ func validateLifecycleCompat(descriptor buildpack.Descriptor, lifecycleDescripto
 	return nil
 }
 
+func imagePlatform(img imgutil.Image) (dist.Target, error) {
+	var (
+		target dist.Target
+		err    error
+	)
+	if target.OS, err = img.OS(); err != nil {
+		return dist.Target{}, err
+	}
+	if target.Arch, err = img.Architecture(); err != nil {
+		return dist.Target{}, err
+	}
+	if target.ArchVariant, err = img.Variant(); err != nil {
+		return dist.Target{}, err
+	}
+	return target, nil
+}
+
+// samePlatform compares the variants only when both images have one, as they are often left out of image configs.
+func samePlatform(a, b dist.Target) bool {
+	if a.OS != b.OS || a.Arch != b.Arch {
+		return false
+	}
+	return a.ArchVariant == "" || b.ArchVariant == "" || a.ArchVariant == b.ArchVariant
+}
+
 func userAndGroupIDs(img imgutil.Image) (int, int, error) {
 	sUID, err := img.Env(EnvUID)
 	if err != nil {
@@ -1184,6 +1587,33 @@ func (b *Builder) buildConfigEnvLayer(dest string, env map[string]string) (strin
 	return fh.Name(), nil
 }
 
+// ReadBuildConfigEnv adds the files of the build config env directory in a builder layer to env, by name. Files of
+// later layers override those of earlier ones, so layers are read from the bottom up.
+func ReadBuildConfigEnv(layer io.Reader, env map[string]string) error {
+	envDir := path.Join(cnbBuildConfigDir(), "env")
+	tr := tar.NewReader(layer)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// files of Windows layers are under 'Files'
+		name := path.Clean("/" + strings.TrimPrefix(strings.TrimPrefix(header.Name, "/"), "Files/"))
+		if header.Typeflag != tar.TypeReg || path.Dir(name) != envDir {
+			continue
+		}
+		value, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		env[path.Base(name)] = string(value)
+	}
+}
+
 func (b *Builder) whiteoutLayer(tmpDir string, i int, bpInfo dist.ModuleInfo) (string, error) {
 	bpWhiteoutsTmpDir := filepath.Join(tmpDir, strconv.Itoa(i)+"_whiteouts")
 	if err := os.MkdirAll(bpWhiteoutsTmpDir, os.ModePerm); err != nil {
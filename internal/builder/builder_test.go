@@ -1683,6 +1683,33 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 					h.HasModTime(archive.NormalizedDateTime),
 				)
 			})
+
+			it("reads the env vars back from the layer", func() {
+				layerTar, err := baseImage.FindLayerWithPath("/cnb/build-config/env/SOME_KEY")
+				h.AssertNil(t, err)
+				layer, err := os.Open(layerTar)
+				h.AssertNil(t, err)
+				defer layer.Close()
+
+				env := map[string]string{}
+				h.AssertNil(t, builder.ReadBuildConfigEnv(layer, env))
+				h.AssertEq(t, env, map[string]string{
+					"SOME_KEY":         "some-val",
+					"OTHER_KEY.append": "other-val",
+					"OTHER_KEY.delim":  ":",
+				})
+			})
+
+			it("records the env vars in the builder metadata", func() {
+				var metadata builder.Metadata
+				_, err := dist.GetLabel(baseImage, "io.buildpacks.builder.metadata", &metadata)
+				h.AssertNil(t, err)
+				h.AssertEq(t, metadata.BuildConfigEnv, map[string]string{
+					"SOME_KEY":         "some-val",
+					"OTHER_KEY.append": "other-val",
+					"OTHER_KEY.delim":  ":",
+				})
+			})
 		})
 
 		when("#SetEnv", func() {
@@ -1,7 +1,10 @@
 package fakes
 
 type FakeInspectable struct {
-	ReturnForLabel string
+	ReturnForLabel        string
+	ReturnForOS           string
+	ReturnForArchitecture string
+	ReturnForVariant      string
 
 	ErrorForLabel error
 
@@ -13,3 +16,15 @@ func (f *FakeInspectable) Label(name string) (string, error) {
 
 	return f.ReturnForLabel, f.ErrorForLabel
 }
+
+func (f *FakeInspectable) OS() (string, error) {
+	return f.ReturnForOS, nil
+}
+
+func (f *FakeInspectable) Architecture() (string, error) {
+	return f.ReturnForArchitecture, nil
+}
+
+func (f *FakeInspectable) Variant() (string, error) {
+	return f.ReturnForVariant, nil
+}
@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 
+	lifecycleplatform "github.com/buildpacks/lifecycle/platform"
+
 	pubbldr "github.com/buildpacks/pack/builder"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
@@ -23,12 +25,23 @@ type Info struct {
 	CreatedBy       CreatorMetadata
 	Extensions      []dist.ModuleInfo
 	OrderExtensions pubbldr.DetectionOrder
+	BuildImage      *BuildImageMetadata
+	BuildConfigEnv  map[string]string
+	Target          *dist.Target
 }
 
 type Inspectable interface {
 	Label(name string) (string, error)
 }
 
+// platformInspectable is an Inspectable whose platform can be read, such as an image
+type platformInspectable interface {
+	Inspectable
+	OS() (string, error)
+	Architecture() (string, error)
+	Variant() (string, error)
+}
+
 type InspectableFetcher interface {
 	Fetch(ctx context.Context, name string, options image.FetchOptions) (Inspectable, error)
 }
@@ -154,9 +167,35 @@ func (i *Inspector) Inspect(name string, daemon bool, orderDetectionDepth int) (
 		CreatedBy:       metadata.CreatedBy,
 		Extensions:      metadata.Extensions,
 		OrderExtensions: detectionOrderExtensions,
+		BuildImage:      metadata.BuildImage,
+		BuildConfigEnv:  metadata.BuildConfigEnv,
+		Target:          inspectTarget(inspectable),
 	}, nil
 }
 
+// inspectTarget returns the target of the builder, or nil when its platform can't be read
+func inspectTarget(inspectable Inspectable) *dist.Target {
+	img, ok := inspectable.(platformInspectable)
+	if !ok {
+		return nil
+	}
+
+	os, err := img.OS()
+	if err != nil || os == "" {
+		return nil
+	}
+	target := &dist.Target{OS: os}
+	target.Arch, _ = img.Architecture()
+	target.ArchVariant, _ = img.Variant()
+
+	distroName, _ := img.Label(lifecycleplatform.OSDistroNameLabel)
+	distroVersion, _ := img.Label(lifecycleplatform.OSDistroVersionLabel)
+	if distroName != "" {
+		target.Distributions = []dist.Distribution{{Name: distroName, Version: distroVersion}}
+	}
+	return target
+}
+
 func orderExttoPubbldrDetectionOrderExt(orderExt dist.Order) pubbldr.DetectionOrder {
 	var detectionOrderExt pubbldr.DetectionOrder
 
@@ -237,6 +237,34 @@ func testInspect(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(info.CreatedBy, testCreatorData)
 		})
 
+		it("returns the target of the builder image", func() {
+			inspectable := &fakes.FakeInspectable{
+				ReturnForOS:           "linux",
+				ReturnForArchitecture: "arm64",
+				ReturnForVariant:      "v8",
+			}
+
+			inspector := builder.NewInspector(
+				&fakes.FakeInspectableFetcher{InspectableToReturn: inspectable},
+				newDefaultLabelManagerFactory(),
+				newDefaultDetectionCalculator(),
+			)
+			info, err := inspector.Inspect(testBuilderName, true, pubbldr.OrderDetectionNone)
+			assert.Nil(err)
+
+			assert.Equal(info.Target, &dist.Target{OS: "linux", Arch: "arm64", ArchVariant: "v8"})
+		})
+
+		when("the platform of the builder image is unknown", func() {
+			it("returns no target", func() {
+				inspector := builder.NewInspector(newDefaultInspectableFetcher(), newDefaultLabelManagerFactory(), newDefaultDetectionCalculator())
+				info, err := inspector.Inspect(testBuilderName, true, pubbldr.OrderDetectionNone)
+				assert.Nil(err)
+
+				assert.Nil(info.Target)
+			})
+		})
+
 		it("sorts buildPacks by ID then Version", func() {
 			metadata := builder.Metadata{
 				Description: testBuilderDescription,
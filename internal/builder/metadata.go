@@ -8,13 +8,22 @@ const (
 )
 
 type Metadata struct {
-	Description string             `json:"description"`
-	Buildpacks  []dist.ModuleInfo  `json:"buildpacks"`
-	Extensions  []dist.ModuleInfo  `json:"extensions"`
-	Stack       StackMetadata      `json:"stack"`
-	Lifecycle   LifecycleMetadata  `json:"lifecycle"`
-	CreatedBy   CreatorMetadata    `json:"createdBy"`
-	RunImages   []RunImageMetadata `json:"images"`
+	Description string              `json:"description"`
+	Buildpacks  []dist.ModuleInfo   `json:"buildpacks"`
+	Extensions  []dist.ModuleInfo   `json:"extensions"`
+	Stack       StackMetadata       `json:"stack"`
+	Lifecycle   LifecycleMetadata   `json:"lifecycle"`
+	CreatedBy   CreatorMetadata     `json:"createdBy"`
+	RunImages   []RunImageMetadata  `json:"images"`
+	BuildImage  *BuildImageMetadata `json:"buildImage,omitempty"`
+	// BuildConfigEnv are the files in the build config env directory of the builder, by name
+	BuildConfigEnv map[string]string `json:"buildConfigEnv,omitempty"`
+}
+
+// BuildImageMetadata describes the build image the builder was created from
+type BuildImageMetadata struct {
+	// TopLayer is the diffID of the top layer of the build image, below the layers added to the builder
+	TopLayer string `json:"topLayer"`
 }
 
 type CreatorMetadata struct {
@@ -0,0 +1,255 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type DiffHumanReadable struct{}
+
+func NewDiffHumanReadable() *DiffHumanReadable {
+	return &DiffHumanReadable{}
+}
+
+func (h *DiffHumanReadable) Print(logger logging.Logger, diff *client.BuilderDiff) error {
+	logger.Infof("Comparing builder %s to %s\n", style.Symbol(diff.From), style.Symbol(diff.To))
+
+	if diff.IsEmpty() {
+		logger.Info("\n(no differences)\n")
+		return nil
+	}
+
+	order, err := detectionOrderDiffLines(diff.Order)
+	if err != nil {
+		return err
+	}
+	orderExtensions, err := detectionOrderDiffLines(diff.OrderExtensions)
+	if err != nil {
+		return err
+	}
+
+	tpl := template.Must(template.New("diff").
+		Funcs(template.FuncMap{
+			"transition": transition,
+			"apis":       apisChange,
+			"list":       listOrNone,
+			"orNone":     valueOrNone,
+		}).
+		Parse(diffTemplate))
+
+	buf := bytes.NewBuffer(nil)
+	tw := tabwriter.NewWriter(buf, writerMinWidth, writerTabWidth, 3, writerPadChar, writerFlags)
+	if err := tpl.Execute(tw, &struct {
+		*client.BuilderDiff
+		OrderLines           []string
+		OrderExtensionsLines []string
+	}{
+		BuilderDiff:          diff,
+		OrderLines:           order,
+		OrderExtensionsLines: orderExtensions,
+	}); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Info(buf.String())
+	return nil
+}
+
+// transition describes how a value changed, e.g. "1.0.0 -> 1.1.0"
+func transition(change client.Change, from, to string) string {
+	switch change {
+	case client.ChangeAdded:
+		return to
+	case client.ChangeRemoved:
+		return from
+	default:
+		return fmt.Sprintf("%s -> %s", from, to)
+	}
+}
+
+// apisChange describes how the supported APIs of a lifecycle changed, e.g. "added 0.10; deprecated 0.2"
+func apisChange(diff client.APIsDiff) string {
+	var changes []string
+	for _, change := range []struct {
+		verb string
+		apis []string
+	}{
+		{"added", diff.Added},
+		{"removed", diff.Removed},
+		{"deprecated", diff.Deprecated},
+	} {
+		if len(change.apis) > 0 {
+			changes = append(changes, fmt.Sprintf("%s %s", change.verb, strings.Join(change.apis, ", ")))
+		}
+	}
+	if len(changes) == 0 {
+		return "(unchanged)"
+	}
+	return strings.Join(changes, "; ")
+}
+
+func listOrNone(values []string) string {
+	return valueOrNone(strings.Join(values, ", "))
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return none
+	}
+	return value
+}
+
+// detectionOrderDiffLines renders both detection orders as trees and compares them line by line, prefixing lines
+// only in the order compared from with "-" and lines only in the order compared to with "+"
+func detectionOrderDiffLines(diff *client.DetectionOrderDiff) ([]string, error) {
+	if diff == nil {
+		return nil, nil
+	}
+
+	from, err := detectionOrderLines(diff.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := detectionOrderLines(diff.To)
+	if err != nil {
+		return nil, err
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			lines = append(lines, "  "+from[i])
+			i++
+			j++
+		case i < len(from) && (j == len(to) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+from[i])
+			i++
+		default:
+			lines = append(lines, "+ "+to[j])
+			j++
+		}
+	}
+	return lines, nil
+}
+
+func detectionOrderLines(order pubbldr.DetectionOrder) ([]string, error) {
+	if len(order) == 0 {
+		return []string{none}, nil
+	}
+
+	buf := bytes.Buffer{}
+	if err := writeDetectionOrderGroup(&buf, order, ""); err != nil {
+		return nil, fmt.Errorf("writing detection order group: %w", err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		lines = append(lines, strings.TrimRight(strings.ReplaceAll(line, "\t", " "), " "))
+	}
+	return lines, nil
+}
+
+var diffTemplate = `
+{{- if .Lifecycle }}
+Lifecycle:
+{{- if .Lifecycle.Change }}
+  Version:	{{ transition "changed" .Lifecycle.FromVersion .Lifecycle.ToVersion }}
+{{- else }}
+  Version:	{{ .Lifecycle.ToVersion }}
+{{- end }}
+  Buildpack APIs:	{{ apis .Lifecycle.BuildpackAPIs }}
+  Platform APIs:	{{ apis .Lifecycle.PlatformAPIs }}
+{{ end }}
+{{- if .BuildImage }}
+Build Image:
+{{- if ne .BuildImage.FromStack .BuildImage.ToStack }}
+  Stack:	{{ transition "changed" .BuildImage.FromStack .BuildImage.ToStack }}
+{{- end }}
+{{- if .BuildImage.LayersUnknown }}
+  Layers:	unknown
+{{- end }}
+{{- if ne .BuildImage.FromTopLayer .BuildImage.ToTopLayer }}
+  Top Layer:	{{ transition "changed" (orNone .BuildImage.FromTopLayer) (orNone .BuildImage.ToTopLayer) }}
+{{- end }}
+{{- if .BuildImage.AddedMixins }}
+  Added Mixins:	{{ list .BuildImage.AddedMixins }}
+{{- end }}
+{{- if .BuildImage.RemovedMixins }}
+  Removed Mixins:	{{ list .BuildImage.RemovedMixins }}
+{{- end }}
+{{ end }}
+{{- if .RunImages }}
+Run Images:
+  CHANGE	IMAGE	MIRRORS
+{{- range $_, $r := .RunImages }}
+  {{ $r.Change }}	{{ $r.Image }}	{{ transition $r.Change (list $r.FromMirrors) (list $r.ToMirrors) }}
+{{- end }}
+{{ end }}
+{{- if .Buildpacks }}
+Buildpacks:
+  CHANGE	ID	VERSION
+{{- range $_, $b := .Buildpacks }}
+  {{ $b.Change }}	{{ $b.ID }}	{{ transition $b.Change $b.FromVersion $b.ToVersion }}
+{{- end }}
+{{ end }}
+{{- if .Extensions }}
+Extensions:
+  CHANGE	ID	VERSION
+{{- range $_, $e := .Extensions }}
+  {{ $e.Change }}	{{ $e.ID }}	{{ transition $e.Change $e.FromVersion $e.ToVersion }}
+{{- end }}
+{{ end }}
+{{- if .OrderLines }}
+Detection Order:
+{{- range $_, $l := .OrderLines }}
+  {{ $l }}
+{{- end }}
+{{ end }}
+{{- if .OrderExtensionsLines }}
+Detection Order (Extensions):
+{{- range $_, $l := .OrderExtensionsLines }}
+  {{ $l }}
+{{- end }}
+{{ end }}
+{{- if .BuildEnvUnknown }}
+Build Env:
+  unknown
+{{ else if .BuildEnv }}
+Build Env:
+  CHANGE	NAME	VALUE
+{{- range $_, $e := .BuildEnv }}
+  {{ $e.Change }}	{{ $e.Name }}	{{ transition $e.Change $e.FromValue $e.ToValue }}
+{{- end }}
+{{ end }}
+{{- if .Target }}
+Target:
+  {{ transition "changed" (orNone .Target.From) (orNone .Target.To) }}
+{{ end }}`
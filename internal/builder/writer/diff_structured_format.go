@@ -0,0 +1,188 @@
+package writer
+
+import (
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type DiffOutput struct {
+	From            string                     `json:"from" yaml:"from"`
+	To              string                     `json:"to" yaml:"to"`
+	Lifecycle       *LifecycleDiffDisplay      `json:"lifecycle" yaml:"lifecycle"`
+	BuildImage      *BuildImageDiffDisplay     `json:"build_image" yaml:"build_image"`
+	RunImages       []RunImageDiffDisplay      `json:"run_images" yaml:"run_images"`
+	Buildpacks      []ModuleDiffDisplay        `json:"buildpacks" yaml:"buildpacks"`
+	Extensions      []ModuleDiffDisplay        `json:"extensions" yaml:"extensions"`
+	DetectionOrder  *DetectionOrderDiffDisplay `json:"detection_order" yaml:"detection_order"`
+	OrderExtensions *DetectionOrderDiffDisplay `json:"order_extensions" yaml:"order_extensions"`
+	BuildEnv        []EnvDiffDisplay           `json:"build_env" yaml:"build_env"`
+	BuildEnvUnknown bool                       `json:"build_env_unknown,omitempty" yaml:"build_env_unknown,omitempty"`
+	Target          *TargetDiffDisplay         `json:"target" yaml:"target"`
+}
+
+type LifecycleDiffDisplay struct {
+	Change        string          `json:"change,omitempty" yaml:"change,omitempty"`
+	FromVersion   string          `json:"from_version" yaml:"from_version"`
+	ToVersion     string          `json:"to_version" yaml:"to_version"`
+	BuildpackAPIs APIsDiffDisplay `json:"buildpack_apis" yaml:"buildpack_apis"`
+	PlatformAPIs  APIsDiffDisplay `json:"platform_apis" yaml:"platform_apis"`
+}
+
+type APIsDiffDisplay struct {
+	Added      []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed    []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Deprecated []string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+type BuildImageDiffDisplay struct {
+	FromStack     string   `json:"from_stack,omitempty" yaml:"from_stack,omitempty"`
+	ToStack       string   `json:"to_stack,omitempty" yaml:"to_stack,omitempty"`
+	FromTopLayer  string   `json:"from_top_layer,omitempty" yaml:"from_top_layer,omitempty"`
+	ToTopLayer    string   `json:"to_top_layer,omitempty" yaml:"to_top_layer,omitempty"`
+	LayersUnknown bool     `json:"layers_unknown,omitempty" yaml:"layers_unknown,omitempty"`
+	AddedMixins   []string `json:"added_mixins,omitempty" yaml:"added_mixins,omitempty"`
+	RemovedMixins []string `json:"removed_mixins,omitempty" yaml:"removed_mixins,omitempty"`
+}
+
+type RunImageDiffDisplay struct {
+	Image       string   `json:"image" yaml:"image"`
+	Change      string   `json:"change" yaml:"change"`
+	FromMirrors []string `json:"from_mirrors,omitempty" yaml:"from_mirrors,omitempty"`
+	ToMirrors   []string `json:"to_mirrors,omitempty" yaml:"to_mirrors,omitempty"`
+}
+
+type ModuleDiffDisplay struct {
+	ID          string `json:"id" yaml:"id"`
+	Change      string `json:"change" yaml:"change"`
+	FromVersion string `json:"from_version,omitempty" yaml:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty" yaml:"to_version,omitempty"`
+}
+
+type DetectionOrderDiffDisplay struct {
+	From pubbldr.DetectionOrder `json:"from" yaml:"from"`
+	To   pubbldr.DetectionOrder `json:"to" yaml:"to"`
+}
+
+type EnvDiffDisplay struct {
+	Name      string `json:"name" yaml:"name"`
+	Change    string `json:"change" yaml:"change"`
+	FromValue string `json:"from_value,omitempty" yaml:"from_value,omitempty"`
+	ToValue   string `json:"to_value,omitempty" yaml:"to_value,omitempty"`
+}
+
+type TargetDiffDisplay struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+func NewDiffOutput(diff *client.BuilderDiff) DiffOutput {
+	output := DiffOutput{
+		From:            diff.From,
+		To:              diff.To,
+		RunImages:       []RunImageDiffDisplay{},
+		Buildpacks:      moduleDiffs(diff.Buildpacks),
+		Extensions:      moduleDiffs(diff.Extensions),
+		BuildEnv:        []EnvDiffDisplay{},
+		BuildEnvUnknown: diff.BuildEnvUnknown,
+	}
+
+	if diff.Lifecycle != nil {
+		output.Lifecycle = &LifecycleDiffDisplay{
+			Change:        string(diff.Lifecycle.Change),
+			FromVersion:   diff.Lifecycle.FromVersion,
+			ToVersion:     diff.Lifecycle.ToVersion,
+			BuildpackAPIs: APIsDiffDisplay(diff.Lifecycle.BuildpackAPIs),
+			PlatformAPIs:  APIsDiffDisplay(diff.Lifecycle.PlatformAPIs),
+		}
+	}
+	if diff.BuildImage != nil {
+		output.BuildImage = &BuildImageDiffDisplay{
+			FromStack:     diff.BuildImage.FromStack,
+			ToStack:       diff.BuildImage.ToStack,
+			FromTopLayer:  diff.BuildImage.FromTopLayer,
+			ToTopLayer:    diff.BuildImage.ToTopLayer,
+			LayersUnknown: diff.BuildImage.LayersUnknown,
+			AddedMixins:   diff.BuildImage.AddedMixins,
+			RemovedMixins: diff.BuildImage.RemovedMixins,
+		}
+	}
+	for _, runImage := range diff.RunImages {
+		output.RunImages = append(output.RunImages, RunImageDiffDisplay{
+			Image:       runImage.Image,
+			Change:      string(runImage.Change),
+			FromMirrors: runImage.FromMirrors,
+			ToMirrors:   runImage.ToMirrors,
+		})
+	}
+	if diff.Order != nil {
+		output.DetectionOrder = &DetectionOrderDiffDisplay{From: diff.Order.From, To: diff.Order.To}
+	}
+	if diff.OrderExtensions != nil {
+		output.OrderExtensions = &DetectionOrderDiffDisplay{From: diff.OrderExtensions.From, To: diff.OrderExtensions.To}
+	}
+	for _, env := range diff.BuildEnv {
+		output.BuildEnv = append(output.BuildEnv, EnvDiffDisplay{
+			Name:      env.Name,
+			Change:    string(env.Change),
+			FromValue: env.FromValue,
+			ToValue:   env.ToValue,
+		})
+	}
+	if diff.Target != nil {
+		output.Target = &TargetDiffDisplay{From: diff.Target.From, To: diff.Target.To}
+	}
+
+	return output
+}
+
+func moduleDiffs(diffs []client.BuildpackDiff) []ModuleDiffDisplay {
+	modules := []ModuleDiffDisplay{}
+	for _, module := range diffs {
+		modules = append(modules, ModuleDiffDisplay{
+			ID:          module.ID,
+			Change:      string(module.Change),
+			FromVersion: module.FromVersion,
+			ToVersion:   module.ToVersion,
+		})
+	}
+	return modules
+}
+
+type StructuredDiffFormat struct {
+	MarshalFunc func(interface{}) ([]byte, error)
+}
+
+func (w *StructuredDiffFormat) Print(logger logging.Logger, diff *client.BuilderDiff) error {
+	out, err := w.MarshalFunc(NewDiffOutput(diff))
+	if err != nil {
+		return err
+	}
+
+	_, err = logger.Writer().Write(out)
+	return err
+}
+
+type JSONDiff struct {
+	StructuredDiffFormat
+}
+
+func NewJSONDiff() *JSONDiff {
+	return &JSONDiff{
+		StructuredDiffFormat: StructuredDiffFormat{
+			MarshalFunc: marshalJSON,
+		},
+	}
+}
+
+type YAMLDiff struct {
+	StructuredDiffFormat
+}
+
+func NewYAMLDiff() *YAMLDiff {
+	return &YAMLDiff{
+		StructuredDiffFormat: StructuredDiffFormat{
+			MarshalFunc: marshalYAML,
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffWriters(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Builder Diff Writers", testDiffWriters, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffWriters(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+		logger logging.Logger
+		diff   *client.BuilderDiff
+	)
+
+	entry := func(id, version string, optional bool) pubbldr.DetectionOrderEntry {
+		return pubbldr.DetectionOrderEntry{
+			ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: id, Version: version}, Optional: optional},
+		}
+	}
+
+	it.Before(func() {
+		outBuf = bytes.Buffer{}
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		diff = &client.BuilderDiff{
+			From: "some/builder:1",
+			To:   "some/builder:2",
+			Lifecycle: &client.LifecycleDiff{
+				FromVersion:   "0.19.0",
+				ToVersion:     "0.20.0",
+				Change:        client.ChangeUpgraded,
+				BuildpackAPIs: client.APIsDiff{Added: []string{"0.10"}, Deprecated: []string{"0.2"}},
+				PlatformAPIs:  client.APIsDiff{Removed: []string{"0.11"}},
+			},
+			BuildImage: &client.BuildImageDiff{
+				FromStack:    "some.stack.id",
+				ToStack:      "some.stack.id",
+				FromTopLayer: "sha256:old-top-layer",
+				ToTopLayer:   "sha256:new-top-layer",
+				AddedMixins:  []string{"mixinY"},
+			},
+			RunImages: []client.BuilderRunImageDiff{
+				{Image: "some/run-image", FromMirrors: []string{"some/mirror"}, Change: client.ChangeChanged},
+			},
+			Buildpacks: []client.BuildpackDiff{
+				{ID: "new/bp", ToVersion: "1.0.0", Change: client.ChangeAdded},
+				{ID: "some/bp", FromVersion: "1.0.0", ToVersion: "1.1.0", Change: client.ChangeUpgraded},
+			},
+			Order: &client.DetectionOrderDiff{
+				From: pubbldr.DetectionOrder{{GroupDetectionOrder: pubbldr.DetectionOrder{
+					entry("some/bp", "1.0.0", false),
+					entry("old/bp", "1.0.0", true),
+				}}},
+				To: pubbldr.DetectionOrder{{GroupDetectionOrder: pubbldr.DetectionOrder{
+					entry("some/bp", "1.0.0", false),
+					entry("new/bp", "1.0.0", false),
+				}}},
+			},
+			BuildEnv: []client.EnvDiff{
+				{Name: "SOME_VAR", FromValue: "old", ToValue: "new", Change: client.ChangeChanged},
+			},
+			Target: &client.TargetDiff{From: "linux/amd64", To: "linux/arm64"},
+		}
+	})
+
+	when("human-readable", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewDiffHumanReadable().Print(logger, diff))
+
+			assert.Contains(outBuf.String(), "Comparing builder 'some/builder:1' to 'some/builder:2'")
+			assert.Contains(outBuf.String(), `Lifecycle:
+  Version:          0.19.0 -> 0.20.0
+  Buildpack APIs:   added 0.10; deprecated 0.2
+  Platform APIs:    removed 0.11`)
+			assert.Contains(outBuf.String(), `Build Image:
+  Top Layer:      sha256:old-top-layer -> sha256:new-top-layer
+  Added Mixins:   mixinY`)
+			assert.Contains(outBuf.String(), `Run Images:
+  CHANGE    IMAGE            MIRRORS
+  changed   some/run-image   some/mirror -> (none)`)
+			assert.Contains(outBuf.String(), `Buildpacks:
+  CHANGE     ID        VERSION
+  added      new/bp    1.0.0
+  upgraded   some/bp   1.0.0 -> 1.1.0`)
+			assert.Contains(outBuf.String(), `Detection Order:
+     └ Group #1:
+        ├ some/bp@1.0.0
+  -     └ old/bp@1.0.0 (optional)
+  +     └ new/bp@1.0.0`)
+			assert.Contains(outBuf.String(), `Build Env:
+  CHANGE    NAME       VALUE
+  changed   SOME_VAR   old -> new`)
+			assert.Contains(outBuf.String(), `Target:
+  linux/amd64 -> linux/arm64`)
+			assert.NotContains(outBuf.String(), "Extensions:")
+		})
+
+		it("prints the build image layers and env that are unknown", func() {
+			diff.BuildImage = &client.BuildImageDiff{FromStack: "some.stack.id", ToStack: "some.stack.id", LayersUnknown: true}
+			diff.BuildEnv = nil
+			diff.BuildEnvUnknown = true
+
+			assert.Nil(writer.NewDiffHumanReadable().Print(logger, diff))
+
+			assert.Contains(outBuf.String(), `Build Image:
+  Layers:   unknown`)
+			assert.Contains(outBuf.String(), `Build Env:
+  unknown`)
+		})
+
+		it("prints that the builders don't differ", func() {
+			assert.Nil(writer.NewDiffHumanReadable().Print(logger, &client.BuilderDiff{From: "some/builder:1", To: "some/builder:1"}))
+
+			assert.Contains(outBuf.String(), "(no differences)")
+		})
+	})
+
+	when("json", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewJSONDiff().Print(logger, diff))
+
+			assert.ContainsJSON(outBuf.String(), `{
+  "from": "some/builder:1",
+  "to": "some/builder:2",
+  "lifecycle": {
+    "change": "upgraded",
+    "from_version": "0.19.0",
+    "to_version": "0.20.0",
+    "buildpack_apis": {"added": ["0.10"], "deprecated": ["0.2"]},
+    "platform_apis": {"removed": ["0.11"]}
+  },
+  "buildpacks": [
+    {"id": "new/bp", "change": "added", "to_version": "1.0.0"},
+    {"id": "some/bp", "change": "upgraded", "from_version": "1.0.0", "to_version": "1.1.0"}
+  ],
+  "extensions": [],
+  "target": {"from": "linux/amd64", "to": "linux/arm64"}
+}`)
+		})
+
+		it("prints the build image layers and env that are unknown", func() {
+			diff.BuildImage = &client.BuildImageDiff{FromStack: "some.stack.id", ToStack: "some.stack.id", LayersUnknown: true}
+			diff.BuildEnv = nil
+			diff.BuildEnvUnknown = true
+
+			assert.Nil(writer.NewJSONDiff().Print(logger, diff))
+
+			assert.ContainsJSON(outBuf.String(), `{
+  "build_image": {"from_stack": "some.stack.id", "to_stack": "some.stack.id", "layers_unknown": true},
+  "build_env": [],
+  "build_env_unknown": true
+}`)
+		})
+	})
+
+	when("yaml", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewYAMLDiff().Print(logger, diff))
+
+			assert.ContainsYAML(outBuf.String(), `---
+from: some/builder:1
+to: some/builder:2
+build_env:
+- name: SOME_VAR
+  change: changed
+  from_value: old
+  to_value: new
+`)
+		})
+	})
+}
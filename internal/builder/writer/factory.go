@@ -32,6 +32,14 @@ type BuilderWriterFactory interface {
 	Writer(kind string) (BuilderWriter, error)
 }
 
+type BuilderDiffWriter interface {
+	Print(logger logging.Logger, diff *client.BuilderDiff) error
+}
+
+type BuilderDiffWriterFactory interface {
+	DiffWriter(kind string) (BuilderDiffWriter, error)
+}
+
 func NewFactory() *Factory {
 	return &Factory{}
 }
@@ -50,3 +58,16 @@ func (f *Factory) Writer(kind string) (BuilderWriter, error) {
 
 	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
 }
+
+func (f *Factory) DiffWriter(kind string) (BuilderDiffWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewDiffHumanReadable(), nil
+	case "json":
+		return NewJSONDiff(), nil
+	case "yaml":
+		return NewYAMLDiff(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
+}
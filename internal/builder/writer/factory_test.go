@@ -90,4 +90,59 @@ func testFactory(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("DiffWriter", func() {
+		when("output format is human-readable", func() {
+			it("returns a DiffHumanReadable writer", func() {
+				factory := writer.NewFactory()
+
+				returnedWriter, err := factory.DiffWriter("human-readable")
+				assert.Nil(err)
+				_, ok := returnedWriter.(*writer.DiffHumanReadable)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.DiffHumanReadable`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is json", func() {
+			it("return a JSONDiff writer", func() {
+				factory := writer.NewFactory()
+
+				returnedWriter, err := factory.DiffWriter("json")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.JSONDiff)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.JSONDiff`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is yaml", func() {
+			it("return a YAMLDiff writer", func() {
+				factory := writer.NewFactory()
+
+				returnedWriter, err := factory.DiffWriter("yaml")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.YAMLDiff)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.YAMLDiff`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is not supported", func() {
+			it("returns an error", func() {
+				factory := writer.NewFactory()
+
+				_, err := factory.DiffWriter("toml")
+				assert.ErrorWithMessage(err, "output format 'toml' is not supported")
+			})
+		})
+	})
 }
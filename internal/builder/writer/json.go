@@ -12,15 +12,17 @@ type JSON struct {
 func NewJSON() BuilderWriter {
 	return &JSON{
 		StructuredFormat: StructuredFormat{
-			MarshalFunc: func(i interface{}) ([]byte, error) {
-				buf, err := json.Marshal(i)
-				if err != nil {
-					return []byte{}, err
-				}
-				formattedBuf := bytes.NewBuffer(nil)
-				err = json.Indent(formattedBuf, buf, "", "  ")
-				return formattedBuf.Bytes(), err
-			},
+			MarshalFunc: marshalJSON,
 		},
 	}
 }
+
+func marshalJSON(i interface{}) ([]byte, error) {
+	buf, err := json.Marshal(i)
+	if err != nil {
+		return []byte{}, err
+	}
+	formattedBuf := bytes.NewBuffer(nil)
+	err = json.Indent(formattedBuf, buf, "", "  ")
+	return formattedBuf.Bytes(), err
+}
@@ -13,13 +13,15 @@ type YAML struct {
 func NewYAML() BuilderWriter {
 	return &YAML{
 		StructuredFormat: StructuredFormat{
-			MarshalFunc: func(v interface{}) ([]byte, error) {
-				buf := bytes.NewBuffer(nil)
-				if err := yaml.NewEncoder(buf).Encode(v); err != nil {
-					return []byte{}, err
-				}
-				return buf.Bytes(), nil
-			},
+			MarshalFunc: marshalYAML,
 		},
 	}
 }
+
+func marshalYAML(v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := yaml.NewEncoder(buf).Encode(v); err != nil {
+		return []byte{}, err
+	}
+	return buf.Bytes(), nil
+}
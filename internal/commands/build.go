@@ -16,11 +16,15 @@ import (
 
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/internal/target"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/lockfile"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/project"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/signature"
 )
 
 type BuildFlags struct {
@@ -37,7 +41,7 @@ type BuildFlags struct {
 	Builder              string
 	Registry             string
 	RunImage             string
-	Platform             string
+	Platforms            []string
 	Policy               string
 	Network              string
 	DescriptorPath       string
@@ -56,6 +60,17 @@ type BuildFlags struct {
 	SBOMDestinationDir   string
 	ReportDestinationDir string
 	DateTime             string
+	OutputFormat         string
+	Run                  bool
+	RunPorts             []string
+	Watch                bool
+	WatchDebounce        time.Duration
+	Lockfile             string
+	LockfileMode         string
+	VerificationKeys     []string
+	AllowedDigests       []string
+	VerificationMode     string
+	SignKey              string
 	PreBuildpacks        []string
 	PostBuildpacks       []string
 }
@@ -164,6 +179,36 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			if err != nil {
 				return errors.Wrapf(err, "parsing creation time %s", flags.DateTime)
 			}
+
+			var (
+				platform string
+				targets  []dist.Target
+			)
+			switch len(flags.Platforms) {
+			case 0:
+			case 1:
+				platform = flags.Platforms[0]
+			default:
+				if targets, err = target.ParseTargets(flags.Platforms, logger); err != nil {
+					return errors.Wrap(err, "parsing platforms")
+				}
+			}
+			lockfileMode, err := lockfile.ParseMode(flags.LockfileMode)
+			if err != nil {
+				return err
+			}
+			verification, err := verificationPolicy(cfg.Verification, flags.VerificationKeys, flags.AllowedDigests, flags.VerificationMode)
+			if err != nil {
+				return err
+			}
+			var signKey *signature.PrivateKey
+			if flags.SignKey != "" {
+				key, err := signature.LoadPrivateKey(flags.SignKey, []byte(os.Getenv(signature.PasswordEnvVar)))
+				if err != nil {
+					return err
+				}
+				signKey = &key
+			}
 			if err := packClient.Build(cmd.Context(), client.BuildOptions{
 				AppPath:           flags.AppPath,
 				Builder:           builder,
@@ -175,7 +220,8 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				Image:             inputImageName.Name(),
 				Publish:           flags.Publish,
 				DockerHost:        flags.DockerHost,
-				Platform:          flags.Platform,
+				Platform:          platform,
+				Targets:           targets,
 				PullPolicy:        pullPolicy,
 				ClearCache:        flags.ClearCache,
 				TrustBuilder: func(string) bool {
@@ -204,6 +250,12 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				CreationTime:             dateTime,
 				PreBuildpacks:            flags.PreBuildpacks,
 				PostBuildpacks:           flags.PostBuildpacks,
+				Watch:                    flags.Watch,
+				WatchDebounce:            flags.WatchDebounce,
+				Lockfile:                 flags.Lockfile,
+				LockfileMode:             lockfileMode,
+				Verification:             verification,
+				SignKey:                  signKey,
 				LayoutConfig: &client.LayoutConfig{
 					Sparse:             flags.Sparse,
 					InputImage:         inputImageName,
@@ -213,7 +265,17 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			}); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
+			if flags.Watch {
+				return nil
+			}
 			logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
+
+			if flags.Run {
+				return packClient.Run(cmd.Context(), client.RunOptions{
+					Image: inputImageName.Name(),
+					Ports: flags.RunPorts,
+				})
+			}
 			return nil
 		}),
 	}
@@ -241,13 +303,14 @@ func parseTime(providedTime string) (*time.Time, error) {
 
 func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Config) {
 	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
-	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  a registry buildpack by id and version or range of versions in the form of 'urn:cnb:registry:<buildpack>@<version>' (e.g. '@^1.4' or '@>=1.2 <2'),\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
 	cmd.Flags().StringSliceVarP(&buildFlags.Extensions, "extension", "", nil, "Extension to use. One of:\n  an extension by id and version in the form of '<extension>@<version>',\n  path to an extension directory (not supported on Windows),\n  path/URL to an extension .tar or .tgz file, or\n  a packaged extension image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("extension"))
-	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image, or a builder in OCI layout format in the form of 'oci:<path>', where <path> is a layout directory or archive created by 'pack builder create --format'")
 	cmd.Flags().Var(&buildFlags.Cache, "cache",
 		`Cache options used to define cache techniques for build process.
 - Cache as bind: 'type=<build/launch>;format=bind;source=<path to directory>'
 - Cache as image (requires --publish): 'type=<build/launch>;format=image;name=<registry image name>'
+- Cache as OCI layout (build cache only): 'type=build;format=layout;source=<path to layout directory>'
 - Cache as volume: 'type=<build/launch>;format=volume;[name=<volume name>]'
     - If no name is provided, a random name will be generated.
 `)
@@ -269,7 +332,10 @@ Special value 'inherit' may be used in which case DOCKER_HOST environment variab
 This option may set DOCKER_HOST environment variable for the build container if needed.
 `)
 	cmd.Flags().StringVar(&buildFlags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for analysis, restore, and export when builder is untrusted.`)
-	cmd.Flags().StringVar(&buildFlags.Platform, "platform", "", `Platform to build on (e.g., "linux/amd64").`)
+	cmd.Flags().StringSliceVar(&buildFlags.Platforms, "platform", nil, `Platform to build on (e.g., "linux/amd64").
+When more than one platform is provided, an image index containing one image per platform is created;
+this requires --publish or an OCI layout image name. When publishing, the image of each platform is pushed
+by digest, without being tagged, before the image index.`+stringSliceHelp("platform"))
 	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
 	cmd.Flags().StringVarP(&buildFlags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
 	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
@@ -282,8 +348,19 @@ This option may set DOCKER_HOST environment variable for the build container if
 	cmd.Flags().IntVar(&buildFlags.UID, "uid", 0, `Override UID of user in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
-	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
+	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml, along with a pack-report.json recording the time spent in each phase.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
+	cmd.Flags().StringVar(&buildFlags.OutputFormat, "output-format", "text", "Format of the build output (text, jsonl).\nWith 'jsonl', logs and build events such as phase timings, detect results, layer reuse and the exported digest are written as JSON lines;\ndetect results are only reported with --verbose.")
+	cmd.Flags().BoolVar(&buildFlags.Run, "run", false, "Run the default process of the app image once it is built, until it exits or is interrupted")
+	cmd.Flags().StringArrayVar(&buildFlags.RunPorts, "run-port", nil, "Port to publish when running the app image with --run, in the form '[[host-ip:]host-port:]container-port[/protocol]'."+stringArrayHelp("port"))
+	cmd.Flags().BoolVar(&buildFlags.Watch, "watch", false, "Keep running after the build, rebuilding the app every time a file sent to the build changes, until interrupted.\nRebuilds reuse the cache and the ephemeral builder of the first build.")
+	cmd.Flags().DurationVar(&buildFlags.WatchDebounce, "watch-debounce", client.DefaultWatchDebounce, "How long the app must stay unchanged before it is rebuilt with --watch")
+	cmd.Flags().StringVar(&buildFlags.Lockfile, "lockfile", "", "Path to a lockfile (e.g. 'pack.lock') recording the digests that the builder, run image, lifecycle image and remote buildpacks resolve to.\nBuilds are verified against an existing lockfile, and new references are added to it.")
+	cmd.Flags().StringVar(&buildFlags.LockfileMode, "lockfile-mode", "verify", "What to do when a reference no longer resolves to the digest in the lockfile. Accepted values are verify (fail), warn, and update (record the new digest).")
+	cmd.Flags().StringArrayVar(&buildFlags.VerificationKeys, "verification-key", nil, "Path to a PEM encoded public key (e.g. 'cosign.pub') trusted to sign the builder, run image, lifecycle image and remote buildpacks.\nWhen keys or allowed digests are given here or in the config, the build fails if any of them is unsigned and not allowed."+stringArrayHelp("key"))
+	cmd.Flags().StringArrayVar(&buildFlags.AllowedDigests, "allowed-digest", nil, "Digest of an image or buildpack that is trusted without a signature."+stringArrayHelp("digest"))
+	cmd.Flags().StringVar(&buildFlags.VerificationMode, "verification-mode", "", "What to do when an image or buildpack fails verification. Accepted values are enforce (fail) and warn. The default is the mode in the config, or enforce.")
+	cmd.Flags().StringVar(&buildFlags.SignKey, "sign-key", "", "Path to a PEM encoded private key (e.g. 'cosign.key') to sign the app image with once it is built.\nThe signature, and signed attestations of the SBOM and SLSA provenance of the image, are attached to it as OCI referrers.\nRequires --publish or an OCI layout image name. The password of an encrypted cosign key is read from $"+signature.PasswordEnvVar+".")
 	cmd.Flags().BoolVar(&buildFlags.Sparse, "sparse", false, "Use this flag to avoid saving on disk the run-image layers when the application image is exported to OCI layout format")
 	if !cfg.Experimental {
 		cmd.Flags().MarkHidden("interactive")
@@ -312,6 +389,10 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return errors.New("cache-image flag requires the publish flag")
 	}
 
+	if flags.Cache.Build.Format == cache.CacheLayout && flags.CacheImage != "" {
+		return errors.New("'cache' flag with 'layout' format cannot be used with 'cache-image' flag.")
+	}
+
 	if flags.GID < 0 {
 		return errors.New("gid flag must be in the range of 0-2147483647")
 	}
@@ -324,10 +405,58 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return client.NewExperimentError("Interactive mode is currently experimental.")
 	}
 
+	if flags.OutputFormat != "text" && flags.OutputFormat != "jsonl" {
+		return errors.Errorf("invalid output format %s, must be one of 'text' or 'jsonl'", style.Symbol(flags.OutputFormat))
+	}
+
+	if flags.OutputFormat == "jsonl" && flags.Interactive {
+		return errors.New("'output-format' flag with 'jsonl' format cannot be used with 'interactive' flag.")
+	}
+
+	if flags.Run && flags.Publish {
+		return errors.New("'run' flag cannot be used with 'publish' flag, the image must be in the daemon to run it")
+	}
+
+	if flags.Run && inputImageRef.Layout() {
+		return errors.New("'run' flag cannot be used when exporting to OCI layout")
+	}
+
+	if len(flags.RunPorts) > 0 && !flags.Run {
+		return errors.New("'run-port' flag requires the 'run' flag")
+	}
+
+	if flags.Watch && flags.Run {
+		return errors.New("'watch' flag cannot be used with 'run' flag")
+	}
+
+	if flags.Watch && flags.Interactive {
+		return errors.New("'watch' flag cannot be used with 'interactive' flag")
+	}
+
+	if flags.Watch && len(flags.Platforms) > 1 {
+		return errors.New("'watch' flag cannot be used when building for multiple platforms")
+	}
+
+	if flags.Watch && flags.ReportDestinationDir != "" {
+		return errors.New("'watch' flag cannot be used with 'report-output-dir' flag")
+	}
+
+	if flags.WatchDebounce <= 0 {
+		return errors.New("watch-debounce flag must be a positive duration")
+	}
+
+	if flags.LockfileMode != "verify" && flags.Lockfile == "" {
+		return errors.New("'lockfile-mode' flag requires the 'lockfile' flag")
+	}
+
 	if inputImageRef.Layout() && !cfg.Experimental {
 		return client.NewExperimentError("Exporting to OCI layout is currently experimental.")
 	}
 
+	if len(flags.Platforms) > 1 && !flags.Publish && !inputImageRef.Layout() {
+		return errors.New("building for multiple platforms requires the 'publish' flag or an OCI layout image name")
+	}
+
 	return nil
 }
 
@@ -401,7 +530,8 @@ func isForbiddenTag(cfg config.Config, input, lifecycle, builder string) error {
 		return errors.Wrapf(err, "invalid image name %s", input)
 	}
 
-	if builder != "" {
+	// builders in OCI layout format are loaded into the daemon under a name of their own
+	if builder != "" && !client.ParseInputImageReference(builder).Layout() {
 		builderImage, err := name.ParseReference(builder)
 		if err != nil {
 			return errors.Wrapf(err, "parsing builder image %s", builder)
@@ -2,10 +2,16 @@ package commands_test
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,9 +29,12 @@ import (
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/lockfile"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/signature"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -210,6 +219,25 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 				command.SetArgs([]string{"image", "--builder", "my-builder", "--platform", "linux/amd64"})
 				h.AssertNil(t, command.Execute())
 			})
+
+			when("multiple platforms are provided", func() {
+				it("sets the targets", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTargets([]dist.Target{
+							{OS: "linux", Arch: "amd64"},
+							{OS: "linux", Arch: "arm64"},
+						})).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--platform", "linux/amd64", "--platform", "linux/arm64", "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("errors when not publishing or exporting to OCI layout", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--platform", "linux/amd64,linux/arm64"})
+					h.AssertError(t, command.Execute(), "building for multiple platforms requires the 'publish' flag or an OCI layout image name")
+				})
+			})
 		})
 
 		when("--pull-policy", func() {
@@ -458,6 +486,237 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("cache flag with 'format=layout' is passed", func() {
+			it("succeeds without --publish", func() {
+				layoutPath, err := filepath.Abs("layout-cache")
+				h.AssertNil(t, err)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithCacheFlags(fmt.Sprintf("type=build;format=layout;source=%s;type=launch;format=volume;", layoutPath))).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--cache", "type=build;format=layout;source=layout-cache"})
+				h.AssertNil(t, command.Execute())
+			})
+			when("used together with --cache-image", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--cache-image", "some-cache-image", "--cache", "type=build;format=layout;source=layout-cache", "--publish"})
+					err := command.Execute()
+					h.AssertError(t, err, "'cache' flag with 'layout' format cannot be used with 'cache-image' flag")
+				})
+			})
+		})
+
+		when("--output-format", func() {
+			when("an unknown format is passed", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--output-format", "xml"})
+					err := command.Execute()
+					h.AssertError(t, err, "invalid output format 'xml', must be one of 'text' or 'jsonl'")
+				})
+			})
+
+			when("'jsonl' is used together with --interactive", func() {
+				it("errors", func() {
+					cfg.Experimental = true
+					command = commands.Build(logger, cfg, mockClient)
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--output-format", "jsonl", "--interactive"})
+					err := command.Execute()
+					h.AssertError(t, err, "'output-format' flag with 'jsonl' format cannot be used with 'interactive' flag")
+				})
+			})
+		})
+
+		when("--run", func() {
+			it("runs the image once it is built", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockClient.EXPECT().
+					Run(gomock.Any(), client.RunOptions{Image: "image", Ports: []string{"8080"}}).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--run", "--run-port", "8080"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("doesn't run the image when the build fails", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), gomock.Any()).
+					Return(errors.New("some-error"))
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--run"})
+				h.AssertError(t, command.Execute(), "failed to build")
+			})
+
+			when("used together with --publish", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--run", "--publish"})
+					h.AssertError(t, command.Execute(), "'run' flag cannot be used with 'publish' flag")
+				})
+			})
+
+			when("--run-port is used without --run", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--run-port", "8080"})
+					h.AssertError(t, command.Execute(), "'run-port' flag requires the 'run' flag")
+				})
+			})
+		})
+
+		when("--watch", func() {
+			it("builds the app in watch mode", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithWatch(2*time.Second)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--watch", "--watch-debounce", "2s"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("used together with --run", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--watch", "--run"})
+					h.AssertError(t, command.Execute(), "'watch' flag cannot be used with 'run' flag")
+				})
+			})
+
+			when("building for multiple platforms", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--watch", "--publish", "--platform", "linux/amd64,linux/arm64"})
+					h.AssertError(t, command.Execute(), "'watch' flag cannot be used when building for multiple platforms")
+				})
+			})
+
+			when("used together with --report-output-dir", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--watch", "--report-output-dir", "some-dir"})
+					h.AssertError(t, command.Execute(), "'watch' flag cannot be used with 'report-output-dir' flag")
+				})
+			})
+
+			when("the debounce is not positive", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--watch", "--watch-debounce", "0s"})
+					h.AssertError(t, command.Execute(), "watch-debounce flag must be a positive duration")
+				})
+			})
+		})
+
+		when("--lockfile", func() {
+			it("verifies against the lockfile by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLockfile("pack.lock", lockfile.ModeVerify)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--lockfile", "pack.lock"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("passes the lockfile mode", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLockfile("pack.lock", lockfile.ModeUpdate)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--lockfile", "pack.lock", "--lockfile-mode", "update"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("the lockfile mode is invalid", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--lockfile", "pack.lock", "--lockfile-mode", "ignore"})
+					h.AssertError(t, command.Execute(), "invalid lockfile mode 'ignore'")
+				})
+			})
+
+			when("--lockfile-mode is used without --lockfile", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--lockfile-mode", "warn"})
+					h.AssertError(t, command.Execute(), "'lockfile-mode' flag requires the 'lockfile' flag")
+				})
+			})
+		})
+
+		when("--verification-key", func() {
+			it("adds the keys and allowed digests to the verification policy in the config", func() {
+				cfg := config.Config{Verification: config.Verification{Mode: "warn", Keys: []string{"config.pub"}}}
+				command = commands.Build(logger, cfg, mockClient)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithVerification(&signature.Policy{
+						Mode:           signature.ModeWarn,
+						Keys:           []string{"config.pub", "cosign.pub"},
+						AllowedDigests: []string{"sha256:" + strings.Repeat("a", 64)},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--verification-key", "cosign.pub", "--allowed-digest", "sha256:" + strings.Repeat("a", 64)})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("overrides the mode in the config", func() {
+				cfg := config.Config{Verification: config.Verification{Mode: "warn"}}
+				command = commands.Build(logger, cfg, mockClient)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithVerification(&signature.Policy{
+						Mode: signature.ModeEnforce,
+						Keys: []string{"cosign.pub"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--verification-key", "cosign.pub", "--verification-mode", "enforce"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("no keys or digests are configured", func() {
+				it("doesn't verify", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithVerification(nil)).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "image"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("the verification mode is invalid", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--verification-key", "cosign.pub", "--verification-mode", "ignore"})
+					h.AssertError(t, command.Execute(), "invalid verification mode 'ignore'")
+				})
+			})
+
+			when("--verification-mode is used without keys or digests", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--verification-mode", "warn"})
+					h.AssertError(t, command.Execute(), "'verification-mode' flag requires a verification key or allowed digest")
+				})
+			})
+		})
+
+		when("--sign-key", func() {
+			it("passes the loaded key", func() {
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				der, err := x509.MarshalPKCS8PrivateKey(key)
+				h.AssertNil(t, err)
+				keyPath := filepath.Join(t.TempDir(), "cosign.key")
+				h.AssertNil(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSignKey(keyPath)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--publish", "--sign-key", keyPath})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("the key can't be read", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--publish", "--sign-key", "missing.key"})
+					h.AssertError(t, command.Execute(), "reading private key 'missing.key'")
+				})
+			})
+		})
+
 		when("a valid lifecycle-image is provided", func() {
 			when("only the image repo is provided", func() {
 				it("uses the provided lifecycle-image and parses it correctly", func() {
@@ -1027,6 +1286,15 @@ func EqBuildOptionsWithPlatform(platform string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithTargets(targets []dist.Target) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Targets=%v", targets),
+		equals: func(o client.BuildOptions) bool {
+			return o.Platform == "" && reflect.DeepEqual(o.Targets, targets)
+		},
+	}
+}
+
 func EqBuildOptionsWithPullPolicy(policy image.PullPolicy) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("PullPolicy=%s", policy),
@@ -1063,6 +1331,42 @@ func EqBuildOptionsWithLifecycleImage(lifecycleImage string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithWatch(debounce time.Duration) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Watch=true WatchDebounce=%s", debounce),
+		equals: func(o client.BuildOptions) bool {
+			return o.Watch && o.WatchDebounce == debounce
+		},
+	}
+}
+
+func EqBuildOptionsWithLockfile(path string, mode lockfile.Mode) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Lockfile=%s LockfileMode=%s", path, mode),
+		equals: func(o client.BuildOptions) bool {
+			return o.Lockfile == path && o.LockfileMode == mode
+		},
+	}
+}
+
+func EqBuildOptionsWithVerification(policy *signature.Policy) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Verification=%+v", policy),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Verification, policy)
+		},
+	}
+}
+
+func EqBuildOptionsWithSignKey(path string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("SignKey=%s", path),
+		equals: func(o client.BuildOptions) bool {
+			return o.SignKey != nil && o.SignKey.Path == path
+		},
+	}
+}
+
 func EqBuildOptionsWithNetwork(network string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Network=%s", network),
@@ -17,7 +17,11 @@ func NewBuilderCommand(logger logging.Logger, cfg config.Config, client PackClie
 	}
 
 	cmd.AddCommand(BuilderCreate(logger, cfg, client))
+	cmd.AddCommand(BuilderExtend(logger, cfg, client))
+	cmd.AddCommand(BuilderUpdateBase(logger, cfg, client))
+	cmd.AddCommand(BuilderValidate(logger, cfg, client))
 	cmd.AddCommand(BuilderInspect(logger, cfg, client, builderwriter.NewFactory()))
+	cmd.AddCommand(BuilderDiff(logger, builderwriter.NewFactory(), client))
 	cmd.AddCommand(BuilderSuggest(logger, client))
 	AddHelpFlag(cmd, "builder")
 	return cmd
@@ -13,18 +13,25 @@ import (
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/lockfile"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
 // BuilderCreateFlags define flags provided to the CreateBuilder command
 type BuilderCreateFlags struct {
-	Publish         bool
-	BuilderTomlPath string
-	Registry        string
-	Policy          string
-	Flatten         []string
-	Targets         []string
-	Label           map[string]string
+	Publish          bool
+	Format           string
+	BuilderTomlPath  string
+	Registry         string
+	Policy           string
+	Flatten          []string
+	Targets          []string
+	Label            map[string]string
+	Lockfile         string
+	LockfileMode     string
+	VerificationKeys []string
+	AllowedDigests   []string
+	VerificationMode string
 }
 
 // CreateBuilder creates a builder image, based on a builder config
@@ -88,7 +95,7 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				return err
 			}
 
-			multiArchCfg, err := processMultiArchitectureConfig(logger, flags.Targets, builderConfig.Targets, !flags.Publish)
+			multiArchCfg, err := processMultiArchitectureConfig(logger, flags.Targets, builderConfig.Targets, !flags.Publish && flags.Format == client.FormatImage)
 			if err != nil {
 				return err
 			}
@@ -97,6 +104,16 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				logger.Infof("Pro tip: use --targets flag OR [[targets]] in builder.toml to specify the desired platform")
 			}
 
+			lockfileMode, err := lockfile.ParseMode(flags.LockfileMode)
+			if err != nil {
+				return err
+			}
+
+			verification, err := verificationPolicy(cfg.Verification, flags.VerificationKeys, flags.AllowedDigests, flags.VerificationMode)
+			if err != nil {
+				return err
+			}
+
 			imageName := args[0]
 			if err := pack.CreateBuilder(cmd.Context(), client.CreateBuilderOptions{
 				RelativeBaseDir: relativeBaseDir,
@@ -104,14 +121,23 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				BuilderName:     imageName,
 				Config:          builderConfig,
 				Publish:         flags.Publish,
+				Format:          flags.Format,
 				Registry:        flags.Registry,
 				PullPolicy:      pullPolicy,
 				Flatten:         toFlatten,
 				Labels:          flags.Label,
 				Targets:         multiArchCfg.Targets(),
+				Lockfile:        flags.Lockfile,
+				LockfileMode:    lockfileMode,
+				Verification:    verification,
 			}); err != nil {
 				return err
 			}
+			if flags.Format == client.FormatOCILayout || flags.Format == client.FormatFile {
+				logger.Infof("Successfully saved builder to %s", style.Symbol(imageName))
+				logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder oci:%s", imageName)))
+				return nil
+			}
 			logger.Infof("Successfully created builder image %s", style.Symbol(imageName))
 			logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder %s", imageName)))
 			return nil
@@ -124,6 +150,7 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 	}
 	cmd.Flags().StringVarP(&flags.BuilderTomlPath, "config", "c", "", "Path to builder TOML file (required)")
 	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the builder directly to the container registry specified in <image-name>, instead of the daemon.")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", client.FormatImage, "Format to save builder as. Accepted values are image, oci-layout (a directory) and file (an archive).\nWith oci-layout and file, <image-name> is the path the builder is saved to, and images are read from the registry.")
 	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringArrayVar(&flags.Flatten, "flatten", nil, "List of buildpacks to flatten together into a single layer (format: '<buildpack-id>@<buildpack-version>,<buildpack-id>@<buildpack-version>'")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to the builder image, in the form of '<name>=<value>'")
@@ -134,6 +161,12 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 - To specify multiple distribution versions: '--target "linux/arm/v6:ubuntu@14.04"  --target "linux/arm/v6:ubuntu@16.04"'
 	`)
 
+	cmd.Flags().StringVar(&flags.Lockfile, "lockfile", "", "Path to a lockfile (e.g. 'pack.lock') recording the digests that the build image, run images, lifecycle and remote buildpacks resolve to.\nBuilders are verified against an existing lockfile, and new references are added to it.")
+	cmd.Flags().StringVar(&flags.LockfileMode, "lockfile-mode", "verify", "What to do when a reference no longer resolves to the digest in the lockfile. Accepted values are verify (fail), warn, and update (record the new digest).")
+	cmd.Flags().StringArrayVar(&flags.VerificationKeys, "verification-key", nil, "Path to a PEM encoded public key (e.g. 'cosign.pub') trusted to sign the build image, run images, lifecycle and remote buildpacks.\nWhen keys or allowed digests are given here or in the config, creating the builder fails if any of them is unsigned and not allowed."+stringArrayHelp("key"))
+	cmd.Flags().StringArrayVar(&flags.AllowedDigests, "allowed-digest", nil, "Digest of an image, lifecycle or buildpack that is trusted without a signature."+stringArrayHelp("digest"))
+	cmd.Flags().StringVar(&flags.VerificationMode, "verification-mode", "", "What to do when an image, lifecycle or buildpack fails verification. Accepted values are enforce (fail) and warn. The default is the mode in the config, or enforce.")
+
 	AddHelpFlag(cmd, "create")
 	return cmd
 }
@@ -147,6 +180,17 @@ func validateCreateFlags(flags *BuilderCreateFlags, cfg config.Config) error {
 		return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
 	}
 
+	// the deprecated create-builder command has no format flag, leaving the format empty
+	switch flags.Format {
+	case "", client.FormatImage:
+	case client.FormatOCILayout, client.FormatFile:
+		if flags.Publish {
+			return errors.Errorf("--publish cannot be used with --format %s", flags.Format)
+		}
+	default:
+		return errors.Errorf("invalid format %s, must be one of image, oci-layout or file", style.Symbol(flags.Format))
+	}
+
 	if flags.Registry != "" && !cfg.Experimental {
 		return client.NewExperimentError("Support for buildpack registries is currently experimental.")
 	}
@@ -155,5 +199,10 @@ func validateCreateFlags(flags *BuilderCreateFlags, cfg config.Config) error {
 		return errors.Errorf("Please provide a builder config path, using --config.")
 	}
 
+	// the deprecated create-builder command has no lockfile flags, leaving the mode empty
+	if flags.LockfileMode != "" && flags.LockfileMode != "verify" && flags.Lockfile == "" {
+		return errors.New("'lockfile-mode' flag requires the 'lockfile' flag")
+	}
+
 	return nil
 }
@@ -20,7 +20,9 @@ import (
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/lockfile"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signature"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -462,6 +464,95 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--lockfile", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes the lockfile and its mode", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsLockfile("pack.lock", lockfile.ModeWarn)).Return(nil)
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--lockfile", "pack.lock",
+					"--lockfile-mode", "warn",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("--lockfile-mode is used without --lockfile", func() {
+				it("errors with a descriptive message", func() {
+					command.SetArgs([]string{
+						"some/builder",
+						"--config", builderConfigPath,
+						"--lockfile-mode", "update",
+					})
+					h.AssertError(t, command.Execute(), "'lockfile-mode' flag requires the 'lockfile' flag")
+				})
+			})
+		})
+
+		when("--verification-key", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes the verification policy", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsVerification(&signature.Policy{
+					Mode: signature.ModeWarn,
+					Keys: []string{"cosign.pub"},
+				})).Return(nil)
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--verification-key", "cosign.pub",
+					"--verification-mode", "warn",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--format", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes the format", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), EqCreateBuilderOptionsFormat(client.FormatFile)).Return(nil)
+
+				command.SetArgs([]string{
+					"builder.tar",
+					"--config", builderConfigPath,
+					"--format", "file",
+				})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Successfully saved builder to 'builder.tar'")
+			})
+
+			it("errors on an unknown format", func() {
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--format", "zip",
+				})
+				h.AssertError(t, command.Execute(), "invalid format 'zip', must be one of image, oci-layout or file")
+			})
+
+			when("--publish", func() {
+				it("errors with a descriptive message", func() {
+					command.SetArgs([]string{
+						"some/builder",
+						"--config", builderConfigPath,
+						"--format", "oci-layout",
+						"--publish",
+					})
+					h.AssertError(t, command.Execute(), "--publish cannot be used with --format oci-layout")
+				})
+			})
+		})
+
 		when("multi-platform builder is expected to be created", func() {
 			when("builder config has no targets defined", func() {
 				it.Before(func() {
@@ -570,6 +661,33 @@ func EqCreateBuilderOptionsTargets(targets []dist.Target) gomock.Matcher {
 	}
 }
 
+func EqCreateBuilderOptionsLockfile(path string, mode lockfile.Mode) gomock.Matcher {
+	return createbuilderOptionsMatcher{
+		description: fmt.Sprintf("Lockfile=%s LockfileMode=%s", path, mode),
+		equals: func(o client.CreateBuilderOptions) bool {
+			return o.Lockfile == path && o.LockfileMode == mode
+		},
+	}
+}
+
+func EqCreateBuilderOptionsVerification(policy *signature.Policy) gomock.Matcher {
+	return createbuilderOptionsMatcher{
+		description: fmt.Sprintf("Verification=%+v", policy),
+		equals: func(o client.CreateBuilderOptions) bool {
+			return reflect.DeepEqual(o.Verification, policy)
+		},
+	}
+}
+
+func EqCreateBuilderOptionsFormat(format string) gomock.Matcher {
+	return createbuilderOptionsMatcher{
+		description: fmt.Sprintf("Format=%s", format),
+		equals: func(o client.CreateBuilderOptions) bool {
+			return o.Format == format
+		},
+	}
+}
+
 type createbuilderOptionsMatcher struct {
 	equals      func(options client.CreateBuilderOptions) bool
 	description string
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type BuilderDiffFlags struct {
+	Remote       bool
+	Depth        int
+	OutputFormat string
+}
+
+func BuilderDiff(logger logging.Logger, writerFactory writer.BuilderDiffWriterFactory, pack PackClient) *cobra.Command {
+	var flags BuilderDiffFlags
+	cmd := &cobra.Command{
+		Use:     "diff <from-builder> <to-builder>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Show what changed between two builders",
+		Example: "pack builder diff cnbs/sample-builder:noble cnbs/sample-builder:noble-next",
+		Long: "Compare two builders, showing changes to their lifecycle, build and run images, buildpacks, extensions, " +
+			"detection order, build config env and target. Use it to review a new version of a builder before rolling it out.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			w, err := writerFactory.DiffWriter(flags.OutputFormat)
+			if err != nil {
+				return err
+			}
+
+			diff, err := pack.DiffBuilders(cmd.Context(), client.DiffBuildersOptions{
+				From:                args[0],
+				To:                  args[1],
+				Daemon:              !flags.Remote,
+				OrderDetectionDepth: flags.Depth,
+			})
+			if err != nil {
+				return err
+			}
+
+			return w.Print(logger, diff)
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Compare the builders in the registry rather than in the daemon")
+	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", builder.OrderDetectionMaxDepth, "Max depth of the Detection Order to compare.\nOmission of this flag or values < 0 will compare the entire tree.")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "diff")
+	return cmd
+}
@@ -0,0 +1,126 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderDiffCommand", testBuilderDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		diff           *client.BuilderDiff
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderDiff(logger, writer.NewFactory(), mockClient)
+
+		diff = &client.BuilderDiff{
+			From: "some/builder:1",
+			To:   "some/builder:2",
+			Buildpacks: []client.BuildpackDiff{
+				{ID: "some/bp", FromVersion: "1.0.0", ToVersion: "1.1.0", Change: client.ChangeUpgraded},
+			},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("prints the differences between the builders in the daemon", func() {
+		mockClient.EXPECT().
+			DiffBuilders(gomock.Any(), client.DiffBuildersOptions{
+				From:                "some/builder:1",
+				To:                  "some/builder:2",
+				Daemon:              true,
+				OrderDetectionDepth: builder.OrderDetectionMaxDepth,
+			}).
+			Return(diff, nil)
+
+		command.SetArgs([]string{"some/builder:1", "some/builder:2"})
+		h.AssertNil(t, command.Execute())
+
+		h.AssertContains(t, outBuf.String(), "Comparing builder 'some/builder:1' to 'some/builder:2'")
+		h.AssertContains(t, outBuf.String(), "upgraded   some/bp   1.0.0 -> 1.1.0")
+	})
+
+	when("--remote and --depth", func() {
+		it("compares the builders in the registry to the given depth", func() {
+			mockClient.EXPECT().
+				DiffBuilders(gomock.Any(), client.DiffBuildersOptions{
+					From:                "some/builder:1",
+					To:                  "some/builder:2",
+					OrderDetectionDepth: 1,
+				}).
+				Return(diff, nil)
+
+			command.SetArgs([]string{"some/builder:1", "some/builder:2", "--remote", "--depth", "1"})
+			h.AssertNil(t, command.Execute())
+		})
+	})
+
+	when("--output yaml", func() {
+		it("prints the differences as yaml", func() {
+			mockClient.EXPECT().
+				DiffBuilders(gomock.Any(), gomock.Any()).
+				Return(diff, nil)
+
+			command.SetArgs([]string{"some/builder:1", "some/builder:2", "--output", "yaml"})
+			h.AssertNil(t, command.Execute())
+
+			h.NewAssertionManager(t).ContainsYAML(outBuf.String(), `---
+buildpacks:
+- id: some/bp
+  change: upgraded
+  from_version: 1.0.0
+  to_version: 1.1.0
+`)
+		})
+	})
+
+	when("the output format is not supported", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some/builder:1", "some/builder:2", "--output", "toml"})
+			h.AssertError(t, command.Execute(), "output format 'toml' is not supported")
+		})
+	})
+
+	when("the builders can't be compared", func() {
+		it("errors", func() {
+			mockClient.EXPECT().
+				DiffBuilders(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("builder 'some/builder:2' cannot be found"))
+
+			command.SetArgs([]string{"some/builder:1", "some/builder:2"})
+			h.AssertError(t, command.Execute(), "builder 'some/builder:2' cannot be found")
+		})
+	})
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderExtendFlags define flags provided to the ExtendBuilder command
+type BuilderExtendFlags struct {
+	Publish          bool
+	Registry         string
+	Policy           string
+	Buildpacks       []string
+	Extensions       []string
+	RemoveBuildpacks []string
+	RemoveExtensions []string
+	OrderFile        string
+	BuildEnv         []string
+}
+
+// BuilderExtend creates a builder image from an existing builder, adding, replacing and removing buildpacks and extensions
+func BuilderExtend(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderExtendFlags
+
+	cmd := &cobra.Command{
+		Use:     "extend <base-builder> <image-name>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Create a builder image from an existing builder",
+		Example: "pack builder extend paketobuildpacks/builder-jammy-base my-builder --buildpack ./my-buildpack --order-file ./order.toml",
+		Long: `Create a builder image from an existing builder, without its builder.toml. Buildpacks and extensions can be added, replacing those with the same ID, or removed, and the order and build environment of the builder can be changed. The layers of the existing builder that aren't changed are reused.
+
+An order file defines the new order with the [[order]] and [[order-extensions]] tables of a builder.toml. Without one, the order of the existing builder is kept, with buildpacks that are replaced pointing to the new version.
+`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateExtendFlags(&flags, cfg); err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			relativeBaseDir, err := filepath.Abs(".")
+			if err != nil {
+				return errors.Wrap(err, "getting absolute path for current directory")
+			}
+
+			var orderConfig builder.OrderConfig
+			if flags.OrderFile != "" {
+				if orderConfig, err = builder.ReadOrderConfig(flags.OrderFile); err != nil {
+					return errors.Wrap(err, "invalid order file")
+				}
+				if len(orderConfig.OrderExtensions) > 0 && !cfg.Experimental {
+					return errors.New("order file contains image extensions; support for image extensions is currently experimental")
+				}
+			}
+
+			envMap, err := parseBuildConfigEnv(logger, flags.BuildEnv)
+			if err != nil {
+				return err
+			}
+
+			baseBuilder, imageName := args[0], args[1]
+			if err := pack.ExtendBuilder(cmd.Context(), client.ExtendBuilderOptions{
+				BaseBuilder:      baseBuilder,
+				BuilderName:      imageName,
+				RelativeBaseDir:  relativeBaseDir,
+				Buildpacks:       moduleConfigs(flags.Buildpacks),
+				Extensions:       moduleConfigs(flags.Extensions),
+				RemoveBuildpacks: moduleInfos(flags.RemoveBuildpacks),
+				RemoveExtensions: moduleInfos(flags.RemoveExtensions),
+				Order:            orderConfig.Order,
+				OrderExtensions:  orderConfig.OrderExtensions,
+				BuildConfigEnv:   envMap,
+				Publish:          flags.Publish,
+				Registry:         flags.Registry,
+				PullPolicy:       pullPolicy,
+			}); err != nil {
+				return err
+			}
+			logger.Infof("Successfully created builder image %s from %s", style.Symbol(imageName), style.Symbol(baseBuilder))
+			logging.Tip(logger, "Run %s to use this builder", style.Symbol("pack build <image-name> --builder "+imageName))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	if !cfg.Experimental {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+	cmd.Flags().StringArrayVarP(&flags.Buildpacks, "buildpack", "b", nil, "Buildpack to add to the builder, replacing buildpacks with the same ID. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>' on the registry,\n  path to a buildpack directory or archive (.tgz),\n  a packaged buildpack image name, prefixed with 'docker://',\n  or a URL"+stringArrayHelp("buildpack"))
+	cmd.Flags().StringArrayVar(&flags.Extensions, "extension", nil, "Extension to add to the builder, replacing extensions with the same ID. Accepts the same values as --buildpack"+stringArrayHelp("extension"))
+	cmd.Flags().StringArrayVar(&flags.RemoveBuildpacks, "remove-buildpack", nil, "Buildpack to remove from the builder, in the form of '<buildpack>[@<version>]'. All versions are removed when no version is given"+stringArrayHelp("buildpack"))
+	cmd.Flags().StringArrayVar(&flags.RemoveExtensions, "remove-extension", nil, "Extension to remove from the builder, in the form of '<extension>[@<version>]'. All versions are removed when no version is given"+stringArrayHelp("extension"))
+	cmd.Flags().StringVar(&flags.OrderFile, "order-file", "", "Path to a TOML file with the [[order]] (and [[order-extensions]]) of the builder, replacing the order of the base builder")
+	cmd.Flags().StringArrayVar(&flags.BuildEnv, "build-env", nil, "Build config environment variable to set on the builder, in the form of '<name>=<value>'"+stringArrayHelp("variable"))
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the builder directly to the container registry specified in <image-name>, instead of the daemon. The base builder is read from the registry.")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+
+	AddHelpFlag(cmd, "extend")
+	return cmd
+}
+
+func validateExtendFlags(flags *BuilderExtendFlags, cfg config.Config) error {
+	if flags.Publish && flags.Policy == image.PullNever.String() {
+		return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
+	}
+
+	if flags.Registry != "" && !cfg.Experimental {
+		return client.NewExperimentError("Support for buildpack registries is currently experimental.")
+	}
+
+	if (len(flags.Extensions) > 0 || len(flags.RemoveExtensions) > 0) && !cfg.Experimental {
+		return errors.New("support for image extensions is currently experimental")
+	}
+
+	if len(flags.Buildpacks) == 0 && len(flags.Extensions) == 0 && len(flags.RemoveBuildpacks) == 0 &&
+		len(flags.RemoveExtensions) == 0 && flags.OrderFile == "" && len(flags.BuildEnv) == 0 {
+		return errors.New("nothing to change, provide buildpacks or extensions to add or remove, an order file or build environment variables")
+	}
+
+	return nil
+}
+
+func moduleConfigs(uris []string) []builder.ModuleConfig {
+	var configs []builder.ModuleConfig
+	for _, uri := range uris {
+		configs = append(configs, builder.ModuleConfig{ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: uri}}})
+	}
+	return configs
+}
+
+func moduleInfos(locators []string) []dist.ModuleInfo {
+	var infos []dist.ModuleInfo
+	for _, locator := range locators {
+		id, version := buildpack.ParseIDLocator(locator)
+		infos = append(infos, dist.ModuleInfo{ID: id, Version: version})
+	}
+	return infos
+}
+
+// parseBuildConfigEnv reads build config environment variables in the form of '<name>=<value>', as they are set in the
+// [[build.env]] table of a builder.toml
+func parseBuildConfigEnv(logger logging.Logger, vars []string) (map[string]string, error) {
+	var env []builder.BuildConfigEnv
+	for _, v := range vars {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, errors.Errorf("invalid build config environment variable %s, must be in the form of '<name>=<value>'", style.Symbol(v))
+		}
+		env = append(env, builder.BuildConfigEnv{Name: name, Value: value})
+	}
+
+	envMap, warnings, err := builder.ParseBuildConfigEnv(env, "--build-env")
+	for _, w := range warnings {
+		logger.Warn(w)
+	}
+	return envMap, err
+}
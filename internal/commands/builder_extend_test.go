@@ -0,0 +1,135 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExtendCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExtendCommand", testExtendCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExtendCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		tmpDir         string
+		cfg            config.Config
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "extend-builder-test")
+		h.AssertNil(t, err)
+		cfg = config.Config{}
+
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.BuilderExtend(logger, cfg, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#Extend", func() {
+		it("passes the changes to the builder", func() {
+			orderPath := filepath.Join(tmpDir, "order.toml")
+			h.AssertNil(t, os.WriteFile(orderPath, []byte(`
+[[order]]
+[[order.group]]
+  id = "some/bp"
+`), 0666))
+
+			var opts client.ExtendBuilderOptions
+			mockClient.EXPECT().ExtendBuilder(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, o client.ExtendBuilderOptions) error {
+				opts = o
+				return nil
+			})
+
+			command.SetArgs([]string{
+				"some/base-builder",
+				"some/builder",
+				"--buildpack", "./some-bp",
+				"--buildpack", "docker://some/bp-package",
+				"--remove-buildpack", "other/bp@1.0.0",
+				"--remove-buildpack", "another/bp",
+				"--order-file", orderPath,
+				"--build-env", "SOME_VAR=some-value",
+				"--pull-policy", "never",
+			})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, opts.BaseBuilder, "some/base-builder")
+			h.AssertEq(t, opts.BuilderName, "some/builder")
+			h.AssertEq(t, len(opts.Buildpacks), 2)
+			h.AssertEq(t, opts.Buildpacks[0].URI, "./some-bp")
+			h.AssertEq(t, opts.Buildpacks[1].URI, "docker://some/bp-package")
+			h.AssertEq(t, opts.RemoveBuildpacks, []dist.ModuleInfo{{ID: "other/bp", Version: "1.0.0"}, {ID: "another/bp"}})
+			h.AssertEq(t, opts.Order[0].Group[0].ID, "some/bp")
+			h.AssertEq(t, opts.BuildConfigEnv["SOME_VAR"], "some-value")
+			h.AssertEq(t, opts.PullPolicy, image.PullNever)
+			h.AssertContains(t, outBuf.String(), "Successfully created builder image 'some/builder' from 'some/base-builder'")
+		})
+
+		when("nothing is changed", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{"some/base-builder", "some/builder"})
+				h.AssertError(t, command.Execute(), "nothing to change")
+			})
+		})
+
+		when("extensions are changed but experimental isn't set in the config", func() {
+			it("errors", func() {
+				command.SetArgs([]string{"some/base-builder", "some/builder", "--remove-extension", "some/ext"})
+				h.AssertError(t, command.Execute(), "support for image extensions is currently experimental")
+			})
+		})
+
+		when("both --publish and pull-policy=never flags are specified", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{"some/base-builder", "some/builder", "--buildpack", "./some-bp", "--publish", "--pull-policy", "never"})
+				h.AssertError(t, command.Execute(), "--publish and --pull-policy never cannot be used together")
+			})
+		})
+
+		when("--build-env is malformed", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{"some/base-builder", "some/builder", "--build-env", "SOME_VAR"})
+				h.AssertError(t, command.Execute(), "invalid build config environment variable 'SOME_VAR'")
+			})
+		})
+
+		when("--order-file doesn't exist", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{"some/base-builder", "some/builder", "--order-file", filepath.Join(tmpDir, "missing.toml")})
+				h.AssertError(t, command.Execute(), "invalid order file")
+			})
+		})
+	})
+}
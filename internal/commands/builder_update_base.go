@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderUpdateBaseFlags define flags provided to the UpdateBuilderBase command
+type BuilderUpdateBaseFlags struct {
+	Publish          bool
+	Policy           string
+	BuildImage       string
+	LifecycleVersion string
+	LifecycleURI     string
+}
+
+// BuilderUpdateBase rebases a builder onto a new build image and/or replaces its lifecycle
+func BuilderUpdateBase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderUpdateBaseFlags
+
+	cmd := &cobra.Command{
+		Use:     "update-base <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Rebase a builder onto a new build image or replace its lifecycle",
+		Example: "pack builder update-base my-builder:jammy --build-image my-build-image:jammy --lifecycle-version 0.20.0",
+		Long: `Update the build image and/or lifecycle of a builder without recreating it. The buildpacks, extensions, order and stack of the builder are reused from its layers, so nothing but the build image and lifecycle is downloaded.
+
+The buildpacks and extensions of the builder are validated against the new build image and lifecycle, and the builder is saved under the same name.
+`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateUpdateBaseFlags(&flags); err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			relativeBaseDir, err := filepath.Abs(".")
+			if err != nil {
+				return errors.Wrap(err, "getting absolute path for current directory")
+			}
+
+			imageName := args[0]
+			if err := pack.UpdateBuilderBase(cmd.Context(), client.UpdateBuilderBaseOptions{
+				BuilderName:     imageName,
+				BuildImage:      flags.BuildImage,
+				Lifecycle:       builder.LifecycleConfig{Version: flags.LifecycleVersion, URI: flags.LifecycleURI},
+				RelativeBaseDir: relativeBaseDir,
+				Publish:         flags.Publish,
+				PullPolicy:      pullPolicy,
+			}); err != nil {
+				return err
+			}
+			logger.Infof("Successfully updated builder image %s", style.Symbol(imageName))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.BuildImage, "build-image", "", "Build image to rebase the builder onto")
+	cmd.Flags().StringVar(&flags.LifecycleVersion, "lifecycle-version", "", "Version of the lifecycle to replace the lifecycle of the builder with")
+	cmd.Flags().StringVar(&flags.LifecycleURI, "lifecycle-uri", "", "URI or path of the lifecycle to replace the lifecycle of the builder with")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the builder directly to the container registry specified in <image-name>, instead of the daemon. The builder and build image are read from the registry.")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+
+	AddHelpFlag(cmd, "update-base")
+	return cmd
+}
+
+func validateUpdateBaseFlags(flags *BuilderUpdateBaseFlags) error {
+	if flags.Publish && flags.Policy == image.PullNever.String() {
+		return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
+	}
+
+	if flags.LifecycleVersion != "" && flags.LifecycleURI != "" {
+		return errors.New("--lifecycle-version and --lifecycle-uri cannot be used together")
+	}
+
+	if flags.BuildImage == "" && flags.LifecycleVersion == "" && flags.LifecycleURI == "" {
+		return errors.New("nothing to update, provide --build-image, --lifecycle-version or --lifecycle-uri")
+	}
+
+	return nil
+}
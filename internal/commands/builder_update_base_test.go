@@ -0,0 +1,94 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestUpdateBaseCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "UpdateBaseCommand", testUpdateBaseCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testUpdateBaseCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.BuilderUpdateBase(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#UpdateBase", func() {
+		it("passes the build image and lifecycle to the client", func() {
+			var opts client.UpdateBuilderBaseOptions
+			mockClient.EXPECT().UpdateBuilderBase(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, o client.UpdateBuilderBaseOptions) error {
+				opts = o
+				return nil
+			})
+
+			command.SetArgs([]string{
+				"some/builder",
+				"--build-image", "some/build-image",
+				"--lifecycle-version", "0.20.0",
+				"--pull-policy", "never",
+			})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, opts.BuilderName, "some/builder")
+			h.AssertEq(t, opts.BuildImage, "some/build-image")
+			h.AssertEq(t, opts.Lifecycle, builder.LifecycleConfig{Version: "0.20.0"})
+			h.AssertEq(t, opts.PullPolicy, image.PullNever)
+			h.AssertContains(t, outBuf.String(), "Successfully updated builder image 'some/builder'")
+		})
+
+		when("nothing is updated", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{"some/builder"})
+				h.AssertError(t, command.Execute(), "nothing to update")
+			})
+		})
+
+		when("both --lifecycle-version and --lifecycle-uri are specified", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{"some/builder", "--lifecycle-version", "0.20.0", "--lifecycle-uri", "./lifecycle.tgz"})
+				h.AssertError(t, command.Execute(), "--lifecycle-version and --lifecycle-uri cannot be used together")
+			})
+		})
+
+		when("both --publish and pull-policy=never flags are specified", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{"some/builder", "--build-image", "some/build-image", "--publish", "--pull-policy", "never"})
+				h.AssertError(t, command.Execute(), "--publish and --pull-policy never cannot be used together")
+			})
+		})
+	})
+}
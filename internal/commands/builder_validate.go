@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderValidateFlags define flags provided to the ValidateBuilder command
+type BuilderValidateFlags struct {
+	BuilderTomlPath string
+	Registry        string
+	OutputFormat    string
+}
+
+// BuilderValidate validates a builder config without creating the builder
+func BuilderValidate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderValidateFlags
+
+	cmd := &cobra.Command{
+		Use:     "validate --config <builder-config-path>",
+		Args:    cobra.NoArgs,
+		Short:   "Validate a builder config without creating the builder",
+		Example: "pack builder validate --config ./builder.toml --output json",
+		Long: `Validate a builder config without creating the builder. The order is checked against the declared buildpacks and extensions, including the orders of buildpackages, the buildpacks against the targets, build and run images and lifecycle of the builder, and the groups of the order for duplicates and groups that can't be reached.
+
+Only local files, images in the daemon and the local cache of the buildpack registry are read. Anything else, such as a lifecycle or buildpack to download, is reported as not validated.
+`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.BuilderTomlPath == "" {
+				return errors.Errorf("Please provide a builder config path, using --config.")
+			}
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("output format %s is not supported", style.Symbol(flags.OutputFormat))
+			}
+
+			diagnostics, err := pack.ValidateBuilder(cmd.Context(), client.ValidateBuilderOptions{
+				ConfigPath: flags.BuilderTomlPath,
+				Registry:   flags.Registry,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				if err := writeDiagnosticsJSON(logger, diagnostics); err != nil {
+					return err
+				}
+			} else {
+				writeDiagnostics(logger, diagnostics)
+			}
+
+			errorCount := 0
+			for _, diagnostic := range diagnostics {
+				if diagnostic.Severity == client.DiagnosticError {
+					errorCount++
+				}
+			}
+			if errorCount > 0 {
+				return errors.Errorf("builder config %s has %d error(s)", style.Symbol(flags.BuilderTomlPath), errorCount)
+			}
+			if flags.OutputFormat != "json" {
+				logger.Infof("Builder config %s is valid", style.Symbol(flags.BuilderTomlPath))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name, read from its local cache")
+	if !cfg.Experimental {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+	cmd.Flags().StringVarP(&flags.BuilderTomlPath, "config", "c", "", "Path to builder TOML file (required)")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the problems found (json, human-readable).\nOmission of this flag will display as human-readable.")
+
+	AddHelpFlag(cmd, "validate")
+	return cmd
+}
+
+func writeDiagnosticsJSON(logger logging.Logger, diagnostics []client.BuilderDiagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []client.BuilderDiagnostic{}
+	}
+
+	output, err := json.MarshalIndent(diagnostics, "", "  ")
+	if err != nil {
+		return err
+	}
+	logger.Info(string(output))
+	return nil
+}
+
+func writeDiagnostics(logger logging.Logger, diagnostics []client.BuilderDiagnostic) {
+	for _, diagnostic := range diagnostics {
+		location := diagnostic.File
+		if diagnostic.Line > 0 {
+			location = fmt.Sprintf("%s:%d", diagnostic.File, diagnostic.Line)
+		}
+		switch diagnostic.Severity {
+		case client.DiagnosticError:
+			logger.Errorf("%s: %s", location, diagnostic.Message)
+		case client.DiagnosticWarning:
+			logger.Warnf("%s: %s", location, diagnostic.Message)
+		default:
+			logger.Infof("%s: %s", location, diagnostic.Message)
+		}
+	}
+}
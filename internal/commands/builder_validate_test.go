@@ -0,0 +1,107 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestValidateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ValidateCommand", testValidateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testValidateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.BuilderValidate(logger, config.Config{DefaultRegistryName: "some-registry"}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#Validate", func() {
+		when("the config is valid", func() {
+			it("reports what isn't validated and succeeds", func() {
+				mockClient.EXPECT().ValidateBuilder(gomock.Any(), client.ValidateBuilderOptions{
+					ConfigPath: "builder.toml",
+					Registry:   "some-registry",
+				}).Return([]client.BuilderDiagnostic{
+					{Severity: client.DiagnosticInfo, File: "builder.toml", Line: 12, Message: "run image 'some/run-image' isn't in the daemon, it is not validated"},
+				}, nil)
+
+				command.SetArgs([]string{"--config", "builder.toml"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "builder.toml:12: run image 'some/run-image' isn't in the daemon, it is not validated")
+				h.AssertContains(t, outBuf.String(), "Builder config 'builder.toml' is valid")
+			})
+		})
+
+		when("the config has errors", func() {
+			diagnostics := []client.BuilderDiagnostic{
+				{Severity: client.DiagnosticWarning, File: "builder.toml", Line: 8, Message: "group 2 of the 'order' is a duplicate of group 1"},
+				{Severity: client.DiagnosticError, File: "builder.toml", Line: 10, Message: "buildpack 'missing/bp' is in the 'order' but isn't declared"},
+			}
+
+			it("reports the problems and fails", func() {
+				mockClient.EXPECT().ValidateBuilder(gomock.Any(), gomock.Any()).Return(diagnostics, nil)
+
+				command.SetArgs([]string{"--config", "builder.toml"})
+				h.AssertError(t, command.Execute(), "builder config 'builder.toml' has 1 error(s)")
+				h.AssertContains(t, outBuf.String(), "Warning: builder.toml:8: group 2 of the 'order' is a duplicate of group 1")
+				h.AssertContains(t, outBuf.String(), "ERROR: builder.toml:10: buildpack 'missing/bp' is in the 'order' but isn't declared")
+			})
+
+			it("writes the problems as JSON", func() {
+				mockClient.EXPECT().ValidateBuilder(gomock.Any(), gomock.Any()).Return(diagnostics, nil)
+
+				command.SetArgs([]string{"--config", "builder.toml", "--output", "json"})
+				h.AssertError(t, command.Execute(), "has 1 error(s)")
+				h.AssertContains(t, outBuf.String(), `{
+    "severity": "error",
+    "file": "builder.toml",
+    "line": 10,
+    "message": "buildpack 'missing/bp' is in the 'order' but isn't declared"
+  }`)
+			})
+		})
+
+		when("--config isn't given", func() {
+			it("errors", func() {
+				command.SetArgs([]string{})
+				h.AssertError(t, command.Execute(), "Please provide a builder config path")
+			})
+		})
+
+		when("the output format isn't supported", func() {
+			it("errors", func() {
+				command.SetArgs([]string{"--config", "builder.toml", "--output", "yaml"})
+				h.AssertError(t, command.Execute(), "output format 'yaml' is not supported")
+			})
+		})
+	})
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type BuildpackSearchFlags struct {
+	BuildpackRegistry string
+	OutputFormat      string
+}
+
+func BuildpackSearch(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags BuildpackSearchFlags
+
+	cmd := &cobra.Command{
+		Use:     "search [<term>]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   "Search buildpack registries for buildpacks",
+		Example: "pack buildpack search node",
+		Long: "Search buildpack registries for buildpacks whose namespace or name contains the given term. " +
+			"Every configured registry is searched, unless a registry is given with --buildpack-registry.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("output format %s is not supported", style.Symbol(flags.OutputFormat))
+			}
+
+			term := ""
+			if len(args) == 1 {
+				term = args[0]
+			}
+
+			results, err := pack.SearchBuildpack(client.SearchBuildpackOptions{
+				Term:         term,
+				RegistryName: flags.BuildpackRegistry,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				return writeSearchJSON(logger, results)
+			}
+			if len(results) == 0 {
+				logger.Infof("No buildpacks found matching %s", style.Symbol(term))
+				return nil
+			}
+			return writeSearchTable(logger, results)
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name. Omitting the flag searches every configured registry.")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the buildpacks (json, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "search")
+
+	return cmd
+}
+
+func writeSearchJSON(logger logging.Logger, results []client.BuildpackSearchResult) error {
+	if results == nil {
+		results = []client.BuildpackSearchResult{}
+	}
+
+	output, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	logger.Info(string(output))
+	return nil
+}
+
+func writeSearchTable(logger logging.Logger, results []client.BuildpackSearchResult) error {
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ID\tLATEST\tVERSIONS\tADDRESS\tREGISTRY\t")
+	for _, result := range results {
+		latest, address := result.Latest, result.Address
+		if latest == "" {
+			// every version was yanked
+			latest, address = "<none>", "<none>"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", result.ID, latest, searchVersions(result), address, result.Registry)
+	}
+	return tw.Flush()
+}
+
+// searchVersions lists the versions of a buildpack, marking the ones that were yanked
+func searchVersions(result client.BuildpackSearchResult) string {
+	yanked := map[string]bool{}
+	for _, version := range result.Yanked {
+		yanked[version] = true
+	}
+
+	var versions []string
+	for _, version := range result.Versions {
+		if yanked[version] {
+			version += " (yanked)"
+		}
+		versions = append(versions, version)
+	}
+	return strings.Join(versions, ", ")
+}
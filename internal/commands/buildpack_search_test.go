@@ -0,0 +1,122 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackSearchCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackSearchCommand", testBuildpackSearchCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackSearchCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		logger     logging.Logger
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+
+		results = []client.BuildpackSearchResult{
+			{
+				Registry: "some-registry",
+				ID:       "example/node",
+				Latest:   "2.1.3",
+				Versions: []string{"2.1.3", "1.5.0", "1.4.2"},
+				Yanked:   []string{"1.5.0"},
+				Address:  "example.com/some/node@sha256:c0ec",
+			},
+			{
+				Registry: "some-registry",
+				ID:       "example/nodejs",
+				Versions: []string{"0.1.0"},
+				Yanked:   []string{"0.1.0"},
+			},
+		}
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		cmd = commands.BuildpackSearch(logger, mockClient)
+	})
+
+	when("#BuildpackSearch", func() {
+		it("prints a table of the matching buildpacks", func() {
+			mockClient.EXPECT().
+				SearchBuildpack(client.SearchBuildpackOptions{Term: "node"}).
+				Return(results, nil)
+
+			cmd.SetArgs([]string{"node"})
+			h.AssertNil(t, cmd.Execute())
+
+			h.AssertContainsMatch(t, outBuf.String(), `ID\s+LATEST\s+VERSIONS\s+ADDRESS\s+REGISTRY`)
+			h.AssertContainsMatch(t, outBuf.String(), `example/node\s+2.1.3\s+2.1.3, 1.5.0 \(yanked\), 1.4.2\s+example.com/some/node@sha256:c0ec\s+some-registry`)
+			h.AssertContainsMatch(t, outBuf.String(), `example/nodejs\s+<none>\s+0.1.0 \(yanked\)\s+<none>\s+some-registry`)
+		})
+
+		it("searches the given registry", func() {
+			mockClient.EXPECT().
+				SearchBuildpack(client.SearchBuildpackOptions{Term: "node", RegistryName: "some-registry"}).
+				Return(nil, nil)
+
+			cmd.SetArgs([]string{"node", "--buildpack-registry", "some-registry"})
+			h.AssertNil(t, cmd.Execute())
+
+			h.AssertContains(t, outBuf.String(), "No buildpacks found matching 'node'")
+		})
+
+		when("--output json", func() {
+			it("prints the matching buildpacks as json", func() {
+				mockClient.EXPECT().
+					SearchBuildpack(client.SearchBuildpackOptions{Term: "node"}).
+					Return(results[:1], nil)
+
+				cmd.SetArgs([]string{"node", "--output", "json"})
+				h.AssertNil(t, cmd.Execute())
+
+				h.NewAssertionManager(t).ContainsJSON(outBuf.String(), `[{
+  "registry": "some-registry",
+  "id": "example/node",
+  "latest": "2.1.3",
+  "versions": ["2.1.3", "1.5.0", "1.4.2"],
+  "yanked": ["1.5.0"],
+  "address": "example.com/some/node@sha256:c0ec"
+}]`)
+			})
+
+			it("prints an empty list when nothing matches", func() {
+				mockClient.EXPECT().
+					SearchBuildpack(client.SearchBuildpackOptions{Term: "python"}).
+					Return(nil, nil)
+
+				cmd.SetArgs([]string{"python", "-o", "json"})
+				h.AssertNil(t, cmd.Execute())
+
+				h.AssertContains(t, outBuf.String(), "[]")
+			})
+		})
+
+		when("the output format isn't supported", func() {
+			it("errors", func() {
+				cmd.SetArgs([]string{"node", "--output", "yaml"})
+				h.AssertError(t, cmd.Execute(), "output format 'yaml' is not supported")
+			})
+		})
+	})
+}
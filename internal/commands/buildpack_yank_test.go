@@ -66,6 +66,7 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    true,
 				}
 
@@ -101,6 +102,7 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    true,
 				}
 
@@ -118,6 +120,7 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    false,
 				}
 				mockClient.EXPECT().
@@ -151,6 +154,7 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 						Version: "0.0.1",
 						Type:    "github",
 						URL:     "https://github.com/override/buildpack-registry",
+						Name:    "override",
 						Yank:    true,
 					}
 					mockClient.EXPECT().
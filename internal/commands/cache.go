@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewCacheCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Interact with build and launch caches",
+		Long: `'pack cache' commands manage the volumes created by 'pack build' to cache layers between builds.
+
+Caches are matched to the image they were created for using the keys stored in '$PACK_HOME/volume-keys.toml'.`,
+		RunE: nil,
+	}
+
+	cmd.AddCommand(CacheList(logger, client))
+	cmd.AddCommand(CacheInspect(logger, client))
+	cmd.AddCommand(CacheRemove(logger, client))
+	cmd.AddCommand(CachePrune(logger, client))
+	cmd.AddCommand(CacheExport(logger, cfg, client))
+	cmd.AddCommand(CacheImport(logger, cfg, client))
+
+	AddHelpFlag(cmd, "cache")
+	return cmd
+}
+
+func writeCacheTable(logger logging.Logger, volumes []cache.VolumeInfo) error {
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "VOLUME\tIMAGE\tKIND\tSIZE\tLAST USED\t")
+	for _, v := range volumes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", v.Name, valueOrUnknown(v.Image), v.Kind, cacheSize(v.Size), lastUsed(v))
+	}
+	return tw.Flush()
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "<unknown>"
+	}
+	return value
+}
+
+func cacheSize(size int64) string {
+	if size < 0 {
+		return "<unknown>"
+	}
+	return humanize.Bytes(uint64(size))
+}
+
+func lastUsed(v cache.VolumeInfo) string {
+	if v.InUse {
+		return "in use"
+	}
+	if v.LastUsed.IsZero() {
+		return "<unknown>"
+	}
+	return humanize.RelTime(v.LastUsed, time.Now(), "ago", "from now")
+}
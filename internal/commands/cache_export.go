@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheExportFlags define flags provided to the CacheExport command
+type CacheExportFlags struct {
+	Format      string
+	HelperImage string
+	Policy      string
+}
+
+// CacheExport writes the contents of a cache volume to a tarball or OCI layout
+func CacheExport(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags CacheExportFlags
+
+	cmd := &cobra.Command{
+		Use:   "export <volume-name> <path>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Export a cache volume to a tarball or OCI layout",
+		Example: `pack cache export pack-cache-library_my-app_latest-1a2b3c4d5e6f.build cache.tar
+pack cache export pack-cache-library_my-app_latest-1a2b3c4d5e6f.build ./cache --format oci-layout`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			format := cache.ArchiveFormat(flags.Format)
+			if format != cache.FormatTarball && format != cache.FormatLayout {
+				return errors.Errorf("invalid format %s; must be one of %s or %s", style.Symbol(flags.Format), style.Symbol(string(cache.FormatTarball)), style.Symbol(string(cache.FormatLayout)))
+			}
+
+			pullPolicy, err := parseCachePullPolicy(cfg, flags.Policy)
+			if err != nil {
+				return err
+			}
+
+			return pack.ExportCache(cmd.Context(), client.ExportCacheOptions{
+				Volume:      args[0],
+				Path:        args[1],
+				Format:      format,
+				HelperImage: flags.HelperImage,
+				PullPolicy:  pullPolicy,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.Format, "format", string(cache.FormatTarball), "Export format. Accepted values are tar and oci-layout")
+	cmd.Flags().StringVar(&flags.HelperImage, "helper-image", "", "Image used to access the volume contents. Defaults to the lifecycle image")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use for the helper image. Accepted values are always, never, and if-not-present. The default is always")
+
+	AddHelpFlag(cmd, "export")
+	return cmd
+}
+
+func parseCachePullPolicy(cfg config.Config, policy string) (image.PullPolicy, error) {
+	if policy == "" {
+		policy = cfg.PullPolicy
+	}
+	pullPolicy, err := image.ParsePullPolicy(policy)
+	if err != nil {
+		return pullPolicy, errors.Wrapf(err, "parsing pull policy %s", policy)
+	}
+	return pullPolicy, nil
+}
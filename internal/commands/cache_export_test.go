@@ -0,0 +1,81 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheExportCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Commands", testCacheExportCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testCacheExportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheExport(logger, config.Config{PullPolicy: "if-not-present"}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("exports the volume as a tarball by default", func() {
+		mockClient.EXPECT().ExportCache(gomock.Any(), client.ExportCacheOptions{
+			Volume:     "some-volume",
+			Path:       "cache.tar",
+			Format:     cache.FormatTarball,
+			PullPolicy: image.PullIfNotPresent,
+		}).Return(nil)
+
+		command.SetArgs([]string{"some-volume", "cache.tar"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("exports the volume as an OCI layout", func() {
+		mockClient.EXPECT().ExportCache(gomock.Any(), client.ExportCacheOptions{
+			Volume:      "some-volume",
+			Path:        "cache",
+			Format:      cache.FormatLayout,
+			HelperImage: "some/helper",
+			PullPolicy:  image.PullNever,
+		}).Return(nil)
+
+		command.SetArgs([]string{"some-volume", "cache", "--format", "oci-layout", "--helper-image", "some/helper", "--pull-policy", "never"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	when("the format is invalid", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some-volume", "cache.zip", "--format", "zip"})
+			h.AssertError(t, command.Execute(), "invalid format 'zip'")
+		})
+	})
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheImportFlags define flags provided to the CacheImport command
+type CacheImportFlags struct {
+	HelperImage string
+	Policy      string
+}
+
+// CacheImport restores a cache volume from a tarball or OCI layout created by CacheExport
+func CacheImport(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags CacheImportFlags
+
+	cmd := &cobra.Command{
+		Use:     "import <path> <volume-name>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Import a cache volume from a tarball or OCI layout",
+		Example: "pack cache import cache.tar pack-cache-library_my-app_latest-1a2b3c4d5e6f.build",
+		Long: `'cache import' restores the contents of a tarball or OCI layout created by 'pack cache export' into a volume.
+The volume is created if it doesn't exist; existing files with the same path are overwritten.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			pullPolicy, err := parseCachePullPolicy(cfg, flags.Policy)
+			if err != nil {
+				return err
+			}
+
+			return pack.ImportCache(cmd.Context(), client.ImportCacheOptions{
+				Path:        args[0],
+				Volume:      args[1],
+				HelperImage: flags.HelperImage,
+				PullPolicy:  pullPolicy,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.HelperImage, "helper-image", "", "Image used to access the volume contents. Defaults to the lifecycle image")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use for the helper image. Accepted values are always, never, and if-not-present. The default is always")
+
+	AddHelpFlag(cmd, "import")
+	return cmd
+}
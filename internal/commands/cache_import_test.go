@@ -0,0 +1,66 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheImportCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Commands", testCacheImportCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testCacheImportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheImport(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("imports the archive into the volume", func() {
+		mockClient.EXPECT().ImportCache(gomock.Any(), client.ImportCacheOptions{
+			Path:       "cache.tar",
+			Volume:     "some-volume",
+			PullPolicy: image.PullAlways,
+		}).Return(nil)
+
+		command.SetArgs([]string{"cache.tar", "some-volume"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	when("the pull policy is invalid", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"cache.tar", "some-volume", "--pull-policy", "sometimes"})
+			h.AssertError(t, command.Execute(), "parsing pull policy sometimes")
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheInspect shows the cache volumes with the given name or belonging to the given image
+func CacheInspect(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect <volume-name|image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Display information about the caches of an image",
+		Example: `pack cache inspect my-app
+pack cache inspect pack-cache-library_my-app_latest-1a2b3c4d5e6f.build`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			volumes, err := pack.InspectCache(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			for i, v := range volumes {
+				if i > 0 {
+					logger.Info("")
+				}
+				logger.Infof("Volume:     %s", v.Name)
+				logger.Infof("Image:      %s", valueOrUnknown(v.Image))
+				logger.Infof("Kind:       %s", v.Kind)
+				logger.Infof("Size:       %s", cacheSize(v.Size))
+				logger.Infof("In use:     %t", v.InUse)
+				logger.Infof("Created:    %s", v.CreatedAt.Format(time.RFC3339))
+				logger.Infof("Last used:  %s", v.LastUsed.Format(time.RFC3339))
+			}
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "inspect")
+	return cmd
+}
@@ -0,0 +1,60 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheInspectCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Commands", testCacheInspectCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testCacheInspectCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheInspect(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("prints the details of each matching cache", func() {
+		lastUsed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		mockClient.EXPECT().InspectCache(gomock.Any(), "my/app").Return([]cache.VolumeInfo{
+			{Name: "pack-cache-my_app_latest-1a2b3c4d5e6f.build", Image: "index.docker.io/my/app:latest", Kind: "build", Size: 2000000, LastUsed: lastUsed},
+		}, nil)
+
+		command.SetArgs([]string{"my/app"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "Volume:     pack-cache-my_app_latest-1a2b3c4d5e6f.build")
+		h.AssertContains(t, outBuf.String(), "Size:       2.0 MB")
+		h.AssertContains(t, outBuf.String(), "Last used:  2024-06-01T12:00:00Z")
+	})
+}
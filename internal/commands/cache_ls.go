@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheList lists the cache volumes created by pack
+func CacheList(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Args:    cobra.NoArgs,
+		Short:   "List the cache volumes created by pack",
+		Example: "pack cache ls",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			volumes, err := pack.ListCaches(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(volumes) == 0 {
+				logger.Info("No cache volumes found")
+				return nil
+			}
+			return writeCacheTable(logger, volumes)
+		}),
+	}
+
+	AddHelpFlag(cmd, "ls")
+	return cmd
+}
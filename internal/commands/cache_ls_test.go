@@ -0,0 +1,70 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheListCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Commands", testCacheListCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testCacheListCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheList(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("there are no caches", func() {
+		it("says so", func() {
+			mockClient.EXPECT().ListCaches(gomock.Any()).Return(nil, nil)
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No cache volumes found")
+		})
+	})
+
+	when("there are caches", func() {
+		it("prints a table of caches", func() {
+			mockClient.EXPECT().ListCaches(gomock.Any()).Return([]cache.VolumeInfo{
+				{Name: "pack-cache-my_app_latest-1a2b3c4d5e6f.build", Image: "index.docker.io/my/app:latest", Kind: "build", Size: 2000000, LastUsed: time.Now().Add(-2 * time.Hour)},
+				{Name: "pack-cache-orphan_latest-000000000000.launch", Kind: "launch", Size: -1, InUse: true},
+			}, nil)
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "VOLUME")
+			h.AssertContainsMatch(t, outBuf.String(), `pack-cache-my_app_latest-1a2b3c4d5e6f.build\s+index.docker.io/my/app:latest\s+build\s+2.0 MB\s+2 hours ago`)
+			h.AssertContainsMatch(t, outBuf.String(), `pack-cache-orphan_latest-000000000000.launch\s+<unknown>\s+launch\s+<unknown>\s+in use`)
+		})
+	})
+}
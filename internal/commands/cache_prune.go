@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CachePruneFlags define flags provided to the CachePrune command
+type CachePruneFlags struct {
+	MaxAge    string
+	MaxSize   string
+	MaxImages int
+	DryRun    bool
+}
+
+// CachePrune removes unused cache volumes
+func CachePrune(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags CachePruneFlags
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Args:  cobra.NoArgs,
+		Short: "Remove unused cache volumes",
+		Example: `pack cache prune --max-age 168h
+pack cache prune --max-size 10GB --dry-run`,
+		Long: `'cache prune' removes the cache volumes that aren't in use by a running build.
+When limits are given, only the least recently used volumes exceeding them are removed.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			opts := cache.PruneOptions{MaxImages: flags.MaxImages, DryRun: flags.DryRun}
+			var err error
+			if flags.MaxAge != "" {
+				if opts.MaxAge, err = cache.ParseAge(flags.MaxAge); err != nil {
+					return err
+				}
+			}
+			if flags.MaxSize != "" {
+				if opts.MaxTotalSize, err = cache.ParseSize(flags.MaxSize); err != nil {
+					return err
+				}
+			}
+
+			pruned, err := pack.PruneCaches(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			if len(pruned) == 0 {
+				logger.Info("No cache volumes to remove")
+				return nil
+			}
+
+			var reclaimed int64
+			for _, v := range pruned {
+				if v.Size > 0 {
+					reclaimed += v.Size
+				}
+			}
+			if opts.DryRun {
+				logger.Infof("Would remove %d cache volume(s) and reclaim %s:", len(pruned), humanize.Bytes(uint64(reclaimed)))
+			} else {
+				logger.Infof("Removed %d cache volume(s) and reclaimed %s:", len(pruned), humanize.Bytes(uint64(reclaimed)))
+			}
+			return writeCacheTable(logger, pruned)
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.MaxAge, "max-age", "", "Remove caches that haven't been used for longer than the given duration (e.g. 72h or 7d)")
+	cmd.Flags().StringVar(&flags.MaxSize, "max-size", "", "Remove the least recently used caches until all caches fit in the given size (e.g. 10GB)")
+	cmd.Flags().IntVar(&flags.MaxImages, "max-images", 0, "Remove the caches of the least recently used images until caches are kept for at most the given number of images")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show the caches that would be removed without removing them")
+
+	AddHelpFlag(cmd, "prune")
+	return cmd
+}
@@ -0,0 +1,93 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCachePruneCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Commands", testCachePruneCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testCachePruneCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CachePrune(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("passes the limits to the client", func() {
+		mockClient.EXPECT().PruneCaches(gomock.Any(), cache.PruneOptions{MaxAge: 7 * 24 * time.Hour, MaxTotalSize: 10000000000, MaxImages: 3}).Return([]cache.VolumeInfo{
+			{Name: "pack-cache-my_app_latest-1a2b3c4d5e6f.build", Size: 3000000},
+		}, nil)
+
+		command.SetArgs([]string{"--max-age", "7d", "--max-size", "10GB", "--max-images", "3"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "Removed 1 cache volume(s) and reclaimed 3.0 MB")
+		h.AssertContains(t, outBuf.String(), "pack-cache-my_app_latest-1a2b3c4d5e6f.build")
+	})
+
+	when("--dry-run", func() {
+		it("reports what would be removed", func() {
+			mockClient.EXPECT().PruneCaches(gomock.Any(), cache.PruneOptions{DryRun: true}).Return([]cache.VolumeInfo{
+				{Name: "pack-cache-my_app_latest-1a2b3c4d5e6f.build", Size: 3000000},
+			}, nil)
+
+			command.SetArgs([]string{"--dry-run"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Would remove 1 cache volume(s) and reclaim 3.0 MB")
+		})
+	})
+
+	when("nothing is pruned", func() {
+		it("says so", func() {
+			mockClient.EXPECT().PruneCaches(gomock.Any(), cache.PruneOptions{}).Return(nil, nil)
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No cache volumes to remove")
+		})
+	})
+
+	when("--max-age is invalid", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"--max-age", "a while"})
+			h.AssertError(t, command.Execute(), "parsing age 'a while'")
+		})
+	})
+
+	when("--max-size is invalid", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"--max-size", "lots"})
+			h.AssertError(t, command.Execute(), "parsing size 'lots'")
+		})
+	})
+}
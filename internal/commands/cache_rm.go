@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheRemove removes cache volumes by volume name or by the image they belong to
+func CacheRemove(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <volume-name|image-name> [<volume-name|image-name>...]",
+		Aliases: []string{"remove"},
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Remove cache volumes",
+		Example: "pack cache rm my-app",
+		Long: `'cache rm' removes the given cache volumes. When an image name is given, both the build and launch
+caches of the image are removed.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.RemoveCaches(cmd.Context(), args)
+		}),
+	}
+
+	AddHelpFlag(cmd, "rm")
+	return cmd
+}
@@ -0,0 +1,57 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheRemoveCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Commands", testCacheRemoveCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testCacheRemoveCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheRemove(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("removes the caches of the given volumes and images", func() {
+		mockClient.EXPECT().RemoveCaches(gomock.Any(), []string{"my/app", "pack-cache-orphan_latest-000000000000.build"}).Return(nil)
+
+		command.SetArgs([]string{"my/app", "pack-cache-orphan_latest-000000000000.build"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("requires at least one argument", func() {
+		command.SetArgs([]string{})
+		h.AssertError(t, command.Execute(), "requires at least 1 arg(s)")
+	})
+}
@@ -17,27 +17,38 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/target"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signature"
 )
 
 //go:generate mockgen -package testmocks -destination testmocks/mock_pack_client.go github.com/buildpacks/pack/internal/commands PackClient
 type PackClient interface {
 	InspectBuilder(string, bool, ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
 	InspectImage(string, bool) (*client.ImageInfo, error)
+	DiffImages(context.Context, client.DiffImagesOptions) (*client.ImageDiff, error)
 	Rebase(context.Context, client.RebaseOptions) error
+	Run(context.Context, client.RunOptions) error
+	RebaseAll(context.Context, client.RebaseAllOptions) ([]client.RebaseResult, error)
 	CreateBuilder(context.Context, client.CreateBuilderOptions) error
+	ExtendBuilder(context.Context, client.ExtendBuilderOptions) error
+	UpdateBuilderBase(context.Context, client.UpdateBuilderBaseOptions) error
+	ValidateBuilder(context.Context, client.ValidateBuilderOptions) ([]client.BuilderDiagnostic, error)
+	DiffBuilders(context.Context, client.DiffBuildersOptions) (*client.BuilderDiff, error)
 	NewBuildpack(context.Context, client.NewBuildpackOptions) error
 	PackageBuildpack(ctx context.Context, opts client.PackageBuildpackOptions) error
 	PackageExtension(ctx context.Context, opts client.PackageBuildpackOptions) error
 	Build(context.Context, client.BuildOptions) error
 	RegisterBuildpack(context.Context, client.RegisterBuildpackOptions) error
 	YankBuildpack(client.YankBuildpackOptions) error
+	SearchBuildpack(client.SearchBuildpackOptions) ([]client.BuildpackSearchResult, error)
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
 	InspectExtension(client.InspectExtensionOptions) (*client.ExtensionInfo, error)
 	PullBuildpack(context.Context, client.PullBuildpackOptions) error
 	DownloadSBOM(name string, options client.DownloadSBOMOptions) error
+	DiffSBOM(context.Context, client.DiffSBOMOptions) (*client.SBOMDiff, error)
 	CreateManifest(ctx context.Context, opts client.CreateManifestOptions) error
 	AnnotateManifest(ctx context.Context, opts client.ManifestAnnotateOptions) error
 	AddManifest(ctx context.Context, opts client.ManifestAddOptions) error
@@ -45,6 +56,12 @@ type PackClient interface {
 	RemoveManifest(name string, images []string) error
 	PushManifest(client.PushManifestOptions) error
 	InspectManifest(string) error
+	ListCaches(ctx context.Context) ([]cache.VolumeInfo, error)
+	InspectCache(ctx context.Context, volumeOrImage string) ([]cache.VolumeInfo, error)
+	RemoveCaches(ctx context.Context, volumesOrImages []string) error
+	PruneCaches(ctx context.Context, opts cache.PruneOptions) ([]cache.VolumeInfo, error)
+	ExportCache(ctx context.Context, opts client.ExportCacheOptions) error
+	ImportCache(ctx context.Context, opts client.ImportCacheOptions) error
 }
 
 func AddHelpFlag(cmd *cobra.Command, commandName string) {
@@ -117,6 +134,30 @@ func isTrustedBuilder(cfg config.Config, builderName string) bool {
 	return builder.IsKnownTrustedBuilder(builderName)
 }
 
+// verificationPolicy combines the verification policy in the config with the keys, digests and mode given as flags.
+// It returns nil when neither lists trusted keys or allowed digests.
+func verificationPolicy(cfg config.Verification, keys, allowedDigests []string, mode string) (*signature.Policy, error) {
+	policy := signature.Policy{
+		Keys:           append(append([]string(nil), cfg.Keys...), keys...),
+		AllowedDigests: append(append([]string(nil), cfg.AllowedDigests...), allowedDigests...),
+	}
+	if len(policy.Keys) == 0 && len(policy.AllowedDigests) == 0 {
+		if mode != "" {
+			return nil, errors.New("'verification-mode' flag requires a verification key or allowed digest")
+		}
+		return nil, nil
+	}
+
+	if mode == "" {
+		mode = cfg.Mode
+	}
+	var err error
+	if policy.Mode, err = signature.ParseMode(mode); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
 func deprecationWarning(logger logging.Logger, oldCmd, replacementCmd string) {
 	logger.Warnf("Command %s has been deprecated, please use %s instead", style.Symbol("pack "+oldCmd), style.Symbol("pack "+replacementCmd))
 }
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -46,7 +47,7 @@ func ConfigRegistries(logger logging.Logger, cfg config.Config, cfgPath string)
 	addCmd.Example = "pack config registries add my-registry https://github.com/buildpacks/my-registry"
 	addCmd.Long = bpRegistryExplanation + "Users can add registries from the config by using registries remove, and publish/yank buildpacks from it, as well as use those buildpacks when building applications."
 	addCmd.Flags().BoolVar(&setDefault, "default", false, "Set this buildpack registry as the default")
-	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github]")
+	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github|oci|dir]")
 	cmd.AddCommand(addCmd)
 
 	rmCmd := generateRemove("registries", logger, cfg, cfgPath, removeRegistry)
@@ -82,6 +83,15 @@ func addRegistryToConfig(logger logging.Logger, newRegistry config.Registry, set
 			strings.Join(slices.MapString(registry.Types, style.Symbol), ", "))
 	}
 
+	if newRegistry.Type == registry.TypeDir {
+		// the directory must not depend on where pack is run from
+		dir, err := filepath.Abs(newRegistry.URL)
+		if err != nil {
+			return errors.Wrapf(err, "resolving registry directory %s", style.Symbol(newRegistry.URL))
+		}
+		newRegistry.URL = dir
+	}
+
 	if registriesContains(config.GetRegistries(cfg), newRegistry.Name) {
 		return errors.Errorf("Buildpack registry %s already exists.",
 			style.Symbol(newRegistry.Name))
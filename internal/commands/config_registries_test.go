@@ -208,6 +208,38 @@ func testConfigRegistries(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("type is oci", func() {
+			it("adds a registry whose index is an OCI artifact", func() {
+				cmd.SetArgs([]string{"add", "internal", "registry.example.com/buildpacks/index:latest", "--type=oci"})
+				assert.Succeeds(cmd.Execute())
+
+				cfg, err := config.Read(configPath)
+				assert.Nil(err)
+				assert.Equal(cfg.Registries, []config.Registry{{
+					Name: "internal",
+					Type: "oci",
+					URL:  "registry.example.com/buildpacks/index:latest",
+				}})
+			})
+		})
+
+		when("type is dir", func() {
+			it("adds a registry for the absolute path of the directory", func() {
+				cmd.SetArgs([]string{"add", "local", "registry-index", "--type=dir"})
+				assert.Succeeds(cmd.Execute())
+
+				wd, err := os.Getwd()
+				assert.Nil(err)
+				cfg, err := config.Read(configPath)
+				assert.Nil(err)
+				assert.Equal(cfg.Registries, []config.Registry{{
+					Name: "local",
+					Type: "dir",
+					URL:  filepath.Join(wd, "registry-index"),
+				}})
+			})
+		})
+
 		when("validation", func() {
 			it("fails with missing args", func() {
 				cmd.SetOut(io.Discard)
@@ -221,7 +253,7 @@ func testConfigRegistries(t *testing.T, when spec.G, it spec.S) {
 				assert.Error(cmd.Execute())
 
 				output := outBuf.String()
-				assert.Contains(output, "'bogus' is not a valid type. Supported types are: 'git', 'github'.")
+				assert.Contains(output, "'bogus' is not a valid type. Supported types are: 'git', 'github', 'oci', 'dir'.")
 			})
 
 			it("should throw error when registry already exists", func() {
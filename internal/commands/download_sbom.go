@@ -1,15 +1,20 @@
 package commands
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	cpkg "github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
 )
 
 type DownloadSBOMFlags struct {
 	Remote         bool
 	DestinationDir string
+	Format         string
+	Buildpacks     []string
+	Stdout         bool
 }
 
 func DownloadSBOM(
@@ -18,16 +23,32 @@ func DownloadSBOM(
 ) *cobra.Command {
 	var flags DownloadSBOMFlags
 	cmd := &cobra.Command{
-		Use:     "download <image-name>",
-		Args:    cobra.ExactArgs(1),
-		Short:   "Download SBoM from specified image",
-		Long:    "Download layer containing structured Software Bill of Materials (SBoM) from specified image",
-		Example: "pack sbom download buildpacksio/pack",
+		Use:   "download <image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Download SBoM from specified image",
+		Long:  "Download layer containing structured Software Bill of Materials (SBoM) from specified image",
+		Example: "pack sbom download buildpacksio/pack\n" +
+			"pack sbom download buildpacksio/pack --format cyclonedx-json --stdout",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			img := args[0]
 			options := cpkg.DownloadSBOMOptions{
 				Daemon:         !flags.Remote,
 				DestinationDir: flags.DestinationDir,
+				Buildpacks:     flags.Buildpacks,
+			}
+
+			if flags.Format != "" {
+				format, err := sbom.ParseFormat(flags.Format)
+				if err != nil {
+					return err
+				}
+				options.Format = &format
+			}
+			if flags.Stdout {
+				if options.Format == nil {
+					return errors.New("'stdout' flag requires the 'format' flag")
+				}
+				options.Writer = logger.Writer()
 			}
 
 			return client.DownloadSBOM(img, options)
@@ -36,5 +57,8 @@ func DownloadSBOM(
 	AddHelpFlag(cmd, "download")
 	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Download SBoM of image in remote registry (without pulling image)")
 	cmd.Flags().StringVarP(&flags.DestinationDir, "output-dir", "o", ".", "Path to export SBoM contents.\nIt defaults export to the current working directory.")
+	cmd.Flags().StringVar(&flags.Format, "format", "", "Merge the SBoMs of all buildpacks into a single document in this format, written to 'sbom.cdx.json' or 'sbom.spdx.json' in the output dir.\nAccepted values are cyclonedx-json and spdx-json. The SBoM files of each buildpack are downloaded as they are when omitted.")
+	cmd.Flags().StringArrayVar(&flags.Buildpacks, "buildpack", nil, "Only download the SBoMs of the buildpack with this ID."+stringArrayHelp("buildpack"))
+	cmd.Flags().BoolVar(&flags.Stdout, "stdout", false, "Print the merged SBoM instead of writing it to the output dir. Requires --format")
 	return cmd
 }
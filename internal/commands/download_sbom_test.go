@@ -15,6 +15,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	cpkg "github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -84,6 +85,65 @@ func testDownloadSBOMCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the format flag is specified", func() {
+			it("merges the SBoMs into the format", func() {
+				format := sbom.FormatSPDXJSON
+				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
+					Daemon:         true,
+					DestinationDir: ".",
+					Format:         &format,
+				})
+				command.SetArgs([]string{"some/image", "--format", "spdx-json"})
+
+				err := command.Execute()
+				h.AssertNil(t, err)
+			})
+
+			it("errors on an invalid format", func() {
+				command.SetArgs([]string{"some/image", "--format", "xml"})
+
+				err := command.Execute()
+				h.AssertError(t, err, "invalid SBOM format 'xml'")
+			})
+		})
+
+		when("the buildpack flag is specified", func() {
+			it("only downloads the SBoMs of those buildpacks", func() {
+				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
+					Daemon:         true,
+					DestinationDir: ".",
+					Buildpacks:     []string{"some/buildpack", "other/buildpack"},
+				})
+				command.SetArgs([]string{"some/image", "--buildpack", "some/buildpack", "--buildpack", "other/buildpack"})
+
+				err := command.Execute()
+				h.AssertNil(t, err)
+			})
+		})
+
+		when("the stdout flag is specified", func() {
+			it("writes the merged SBoM to the output", func() {
+				format := sbom.FormatCycloneDXJSON
+				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
+					Daemon:         true,
+					DestinationDir: ".",
+					Format:         &format,
+					Writer:         logger.Writer(),
+				})
+				command.SetArgs([]string{"some/image", "--format", "cyclonedx-json", "--stdout"})
+
+				err := command.Execute()
+				h.AssertNil(t, err)
+			})
+
+			it("errors without the format flag", func() {
+				command.SetArgs([]string{"some/image", "--stdout"})
+
+				err := command.Execute()
+				h.AssertError(t, err, "'stdout' flag requires the 'format' flag")
+			})
+		})
+
 		when("the client returns an error", func() {
 			it("returns the error", func() {
 				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewImageCommand(logger logging.Logger, writerFactory ImageDiffWriterFactory, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image",
+		Short: "Interact with app images",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(ImageDiff(logger, writerFactory, client))
+	AddHelpFlag(cmd, "image")
+	return cmd
+}
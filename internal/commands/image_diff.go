@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ImageDiffWriterFactory interface {
+	DiffWriter(kind string) (writer.ImageDiffWriter, error)
+}
+
+type ImageDiffFlags struct {
+	Remote       bool
+	OutputFormat string
+}
+
+func ImageDiff(logger logging.Logger, writerFactory ImageDiffWriterFactory, pack PackClient) *cobra.Command {
+	var flags ImageDiffFlags
+	cmd := &cobra.Command{
+		Use:     "diff <from-image> <to-image>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Show what changed between two app images",
+		Example: "pack image diff my-app:v1 my-app:v2",
+		Long: "Compare two app images built with `pack build`, showing changes to their run image, buildpacks, " +
+			"buildpack layers, processes and bill of materials.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			w, err := writerFactory.DiffWriter(flags.OutputFormat)
+			if err != nil {
+				return err
+			}
+
+			diff, err := pack.DiffImages(cmd.Context(), client.DiffImagesOptions{
+				From:   args[0],
+				To:     args[1],
+				Daemon: !flags.Remote,
+			})
+			if err != nil {
+				return err
+			}
+
+			return w.Print(logger, diff)
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Compare the images in the registry rather than in the daemon")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "diff")
+	return cmd
+}
@@ -0,0 +1,117 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ImageDiffCommand", testImageDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		diff           *client.ImageDiff
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ImageDiff(logger, writer.NewFactory(), mockClient)
+
+		diff = &client.ImageDiff{
+			From: "some/app:v1",
+			To:   "some/app:v2",
+			Buildpacks: []client.BuildpackDiff{
+				{ID: "some/node", FromVersion: "1.0.0", ToVersion: "1.1.0", Change: client.ChangeUpgraded},
+			},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("prints the differences between the images in the daemon", func() {
+		mockClient.EXPECT().
+			DiffImages(gomock.Any(), client.DiffImagesOptions{From: "some/app:v1", To: "some/app:v2", Daemon: true}).
+			Return(diff, nil)
+
+		command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+		h.AssertNil(t, command.Execute())
+
+		h.AssertContains(t, outBuf.String(), "Comparing image 'some/app:v1' to 'some/app:v2'")
+		h.AssertContains(t, outBuf.String(), "upgraded   some/node   1.0.0 -> 1.1.0")
+	})
+
+	when("--remote", func() {
+		it("compares the images in the registry", func() {
+			mockClient.EXPECT().
+				DiffImages(gomock.Any(), client.DiffImagesOptions{From: "some/app:v1", To: "some/app:v2"}).
+				Return(diff, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--remote"})
+			h.AssertNil(t, command.Execute())
+		})
+	})
+
+	when("--output json", func() {
+		it("prints the differences as json", func() {
+			mockClient.EXPECT().
+				DiffImages(gomock.Any(), gomock.Any()).
+				Return(diff, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+
+			h.NewAssertionManager(t).ContainsJSON(outBuf.String(), `{"buildpacks": [{"id": "some/node", "change": "upgraded", "from_version": "1.0.0", "to_version": "1.1.0"}]}`)
+		})
+	})
+
+	when("the output format is not supported", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--output", "toml"})
+			h.AssertError(t, command.Execute(), "output format 'toml' is not supported")
+		})
+	})
+
+	when("the images can't be compared", func() {
+		it("errors", func() {
+			mockClient.EXPECT().
+				DiffImages(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("image 'some/app:v2' cannot be found"))
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+			h.AssertError(t, command.Execute(), "image 'some/app:v2' cannot be found")
+		})
+	})
+
+	when("not given two images", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some/app:v1"})
+			h.AssertError(t, command.Execute(), "accepts 2 arg(s), received 1")
+		})
+	})
+}
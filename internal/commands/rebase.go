@@ -1,6 +1,12 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -13,19 +19,34 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
+type RebaseFlags struct {
+	ImagesFile  string
+	Concurrency int
+	DryRun      bool
+}
+
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
+	var flags RebaseFlags
 	var policy string
 
 	cmd := &cobra.Command{
-		Use:     "rebase <image-name>",
-		Args:    cobra.ExactArgs(1),
-		Short:   "Rebase app image with latest run image",
-		Example: "pack rebase buildpacksio/pack",
+		Use: "rebase <image-name>...",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if flags.ImagesFile != "" {
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		Short: "Rebase app image with latest run image",
+		Example: "pack rebase buildpacksio/pack\n" +
+			"pack rebase --dry-run 'my-org/*'\n" +
+			"pack rebase --publish --images-file images.txt --concurrency 8",
 		Long: "Rebase allows you to quickly swap out the underlying OS layers (run image) of an app image generated by `pack build` " +
-			"with a newer version of the run image, without re-building the application.",
+			"with a newer version of the run image, without re-building the application.\n\n" +
+			"When several images are given, as arguments, glob patterns matching images in the daemon, or with --images-file, " +
+			"the images are grouped by run image and rebased in parallel, followed by a summary of the run image each image moved from and to.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			opts.RepoName = args[0]
 			opts.AdditionalMirrors = getMirrors(cfg)
 
 			var err error
@@ -38,6 +59,11 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
 			}
 
+			if isBatchRebase(args, flags) {
+				return rebaseAll(cmd, logger, pack, args, opts, flags)
+			}
+
+			opts.RepoName = args[0]
 			if err := pack.Rebase(cmd.Context(), opts); err != nil {
 				return err
 			}
@@ -52,7 +78,121 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 	cmd.Flags().StringVar(&opts.PreviousImage, "previous-image", "", "Image to rebase. Set to a particular tag reference, digest reference, or (when performing a daemon build) image ID. Use this flag in combination with <image-name> to avoid replacing the original image.")
 	cmd.Flags().StringVar(&opts.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Perform rebase operation without target validation (only available for API >= 0.12)")
+	cmd.Flags().StringVar(&flags.ImagesFile, "images-file", "", "Path to a file listing the images to rebase, one per line. Empty lines and lines starting with '#' are ignored.")
+	cmd.Flags().IntVar(&flags.Concurrency, "concurrency", client.DefaultRebaseConcurrency, "Number of images to rebase at the same time when rebasing several images")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Report which images would be rebased, without rebasing them")
 
 	AddHelpFlag(cmd, "rebase")
 	return cmd
 }
+
+func isBatchRebase(args []string, flags RebaseFlags) bool {
+	if len(args) != 1 || flags.ImagesFile != "" || flags.DryRun {
+		return true
+	}
+	return strings.ContainsAny(args[0], "*?[")
+}
+
+func rebaseAll(cmd *cobra.Command, logger logging.Logger, pack PackClient, args []string, opts client.RebaseOptions, flags RebaseFlags) error {
+	if opts.PreviousImage != "" {
+		return errors.New("'previous-image' flag can only be used when rebasing a single image")
+	}
+	if opts.ReportDestinationDir != "" {
+		return errors.New("'report-output-dir' flag can only be used when rebasing a single image")
+	}
+	if flags.Concurrency < 1 {
+		return errors.Errorf("invalid concurrency %d, must be at least 1", flags.Concurrency)
+	}
+
+	repoNames := args
+	if flags.ImagesFile != "" {
+		fileRepoNames, err := readImagesFile(flags.ImagesFile)
+		if err != nil {
+			return err
+		}
+		repoNames = append(repoNames, fileRepoNames...)
+	}
+
+	results, err := pack.RebaseAll(cmd.Context(), client.RebaseAllOptions{
+		RepoNames:         repoNames,
+		Publish:           opts.Publish,
+		PullPolicy:        opts.PullPolicy,
+		RunImage:          opts.RunImage,
+		AdditionalMirrors: opts.AdditionalMirrors,
+		Force:             opts.Force,
+		Concurrency:       flags.Concurrency,
+		DryRun:            flags.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "IMAGE\tRUN IMAGE\tOLD RUN IMAGE\tNEW RUN IMAGE\tSTATUS\t")
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n",
+			result.RepoName,
+			valueOrUnknown(result.RunImage),
+			shortReference(result.PreviousRunImageReference),
+			shortReference(result.RunImageReference),
+			rebaseStatus(result, flags.DryRun),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to rebase %d of %d images", failed, len(results))
+	}
+	return nil
+}
+
+func readImagesFile(path string) ([]string, error) {
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading images file %s", style.Symbol(path))
+	}
+
+	var repoNames []string
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		repoNames = append(repoNames, line)
+	}
+	return repoNames, nil
+}
+
+func rebaseStatus(result client.RebaseResult, dryRun bool) string {
+	switch {
+	case result.Err != nil:
+		return fmt.Sprintf("failed: %s", result.Err)
+	case !result.Rebased:
+		return "up to date"
+	case dryRun:
+		return "would rebase"
+	default:
+		return "rebased"
+	}
+}
+
+// shortReference shortens a run image reference to the start of its digest or image ID
+func shortReference(reference string) string {
+	if reference == "" {
+		return "<unknown>"
+	}
+	if i := strings.LastIndex(reference, "@"); i >= 0 {
+		reference = reference[i+1:]
+	}
+	digest := strings.TrimPrefix(reference, "sha256:")
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return reference
+}
@@ -2,6 +2,9 @@ package commands_test
 
 import (
 	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/heroku/color"
@@ -51,7 +54,7 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 		when("no image is provided", func() {
 			it("fails to run", func() {
 				err := command.Execute()
-				h.AssertError(t, err, "accepts 1 arg")
+				h.AssertError(t, err, "requires at least 1 arg")
 			})
 		})
 
@@ -197,5 +200,98 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("several images are provided", func() {
+			var expectedOpts client.RebaseAllOptions
+
+			it.Before(func() {
+				expectedOpts = client.RebaseAllOptions{
+					RepoNames:         []string{"some/app", "some/other-app"},
+					PullPolicy:        image.PullAlways,
+					AdditionalMirrors: map[string][]string{},
+					Concurrency:       client.DefaultRebaseConcurrency,
+				}
+			})
+
+			it("rebases them all and prints a summary", func() {
+				mockClient.EXPECT().
+					RebaseAll(gomock.Any(), expectedOpts).
+					Return([]client.RebaseResult{
+						{RepoName: "some/app", RunImage: "some/run", PreviousRunImageReference: "some/run@sha256:1111111111111111", RunImageReference: "some/run@sha256:2222222222222222", Rebased: true},
+						{RepoName: "some/other-app", RunImage: "some/run", PreviousRunImageReference: "some/run@sha256:2222222222222222", RunImageReference: "some/run@sha256:2222222222222222"},
+					}, nil)
+
+				command.SetArgs([]string{"some/app", "some/other-app"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertContains(t, outBuf.String(), "IMAGE            RUN IMAGE   OLD RUN IMAGE   NEW RUN IMAGE   STATUS")
+				h.AssertContains(t, outBuf.String(), "some/app         some/run    111111111111    222222222222    rebased")
+				h.AssertContains(t, outBuf.String(), "some/other-app   some/run    222222222222    222222222222    up to date")
+			})
+
+			it("fails when an image could not be rebased", func() {
+				mockClient.EXPECT().
+					RebaseAll(gomock.Any(), expectedOpts).
+					Return([]client.RebaseResult{
+						{RepoName: "some/app", Err: errors.New("some-error")},
+						{RepoName: "some/other-app", RunImage: "some/run", Rebased: true},
+					}, nil)
+
+				command.SetArgs([]string{"some/app", "some/other-app"})
+				h.AssertError(t, command.Execute(), "failed to rebase 1 of 2 images")
+				h.AssertContains(t, outBuf.String(), "failed: some-error")
+			})
+
+			when("--images-file", func() {
+				it("rebases the images listed in the file", func() {
+					imagesFile := filepath.Join(t.TempDir(), "images.txt")
+					h.AssertNil(t, os.WriteFile(imagesFile, []byte("# apps\nsome/app\n\n  some/other-app  \n"), 0600))
+					mockClient.EXPECT().
+						RebaseAll(gomock.Any(), expectedOpts).
+						Return([]client.RebaseResult{}, nil)
+
+					command.SetArgs([]string{"--images-file", imagesFile})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("--dry-run", func() {
+				it("reports the images that would be rebased", func() {
+					expectedOpts.RepoNames = []string{"some/*"}
+					expectedOpts.DryRun = true
+					mockClient.EXPECT().
+						RebaseAll(gomock.Any(), expectedOpts).
+						Return([]client.RebaseResult{{RepoName: "some/app", RunImage: "some/run", Rebased: true}}, nil)
+
+					command.SetArgs([]string{"some/*", "--dry-run"})
+					h.AssertNil(t, command.Execute())
+					h.AssertContains(t, outBuf.String(), "would rebase")
+				})
+			})
+
+			when("--concurrency", func() {
+				it("passes it through", func() {
+					expectedOpts.Concurrency = 8
+					mockClient.EXPECT().
+						RebaseAll(gomock.Any(), expectedOpts).
+						Return([]client.RebaseResult{}, nil)
+
+					command.SetArgs([]string{"some/app", "some/other-app", "--concurrency", "8"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("must be at least 1", func() {
+					command.SetArgs([]string{"some/app", "some/other-app", "--concurrency", "0"})
+					h.AssertError(t, command.Execute(), "invalid concurrency 0, must be at least 1")
+				})
+			})
+
+			when("--previous-image", func() {
+				it("fails", func() {
+					command.SetArgs([]string{"some/app", "some/other-app", "--previous-image", "some/previous-app"})
+					h.AssertError(t, command.Execute(), "'previous-image' flag can only be used when rebasing a single image")
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type RunFlags struct {
+	Process  string
+	Env      []string
+	EnvFiles []string
+	Ports    []string
+}
+
+// Run a process of an app image
+func Run(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags RunFlags
+
+	cmd := &cobra.Command{
+		Use:     "run <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Run an app image",
+		Example: "pack run test_img --process web --port 8080 --env PORT=8080",
+		Long: "Run starts a container from an app image in the daemon, running the default process of the image or the one given " +
+			"with `--process`. The output of the app is streamed until it exits or is interrupted, after which the container is removed.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			env, err := parseEnv(flags.EnvFiles, flags.Env)
+			if err != nil {
+				return err
+			}
+
+			return pack.Run(cmd.Context(), client.RunOptions{
+				Image:   args[0],
+				Process: flags.Process,
+				Env:     env,
+				Ports:   flags.Ports,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.Process, "process", "", "Type of the process to run. Omitting the flag runs the default process of the image.")
+	cmd.Flags().StringArrayVarP(&flags.Env, "env", "e", []string{}, "Environment variable set in the container, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env"))
+	cmd.Flags().StringArrayVar(&flags.EnvFiles, "env-file", []string{}, "Environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed")
+	cmd.Flags().StringArrayVarP(&flags.Ports, "port", "p", []string{}, "Port to publish, in the form '[[host-ip:]host-port:]container-port[/protocol]'.\nA port given alone is published on the same host port."+stringArrayHelp("port"))
+
+	AddHelpFlag(cmd, "run")
+	return cmd
+}
@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRunCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RunCommand", testRunCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRunCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.Run(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("runs the default process of the image", func() {
+		mockClient.EXPECT().
+			Run(gomock.Any(), client.RunOptions{Image: "some/app", Env: map[string]string{}, Ports: []string{}}).
+			Return(nil)
+
+		command.SetArgs([]string{"some/app"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("passes the process, environment and ports through", func() {
+		envFile := filepath.Join(t.TempDir(), "env")
+		h.AssertNil(t, os.WriteFile(envFile, []byte("FROM_FILE=file-value\nOVERRIDDEN=file-value\n"), 0600))
+		mockClient.EXPECT().
+			Run(gomock.Any(), client.RunOptions{
+				Image:   "some/app",
+				Process: "worker",
+				Env:     map[string]string{"FROM_FILE": "file-value", "OVERRIDDEN": "flag-value"},
+				Ports:   []string{"8080", "127.0.0.1:9090:9090"},
+			}).
+			Return(nil)
+
+		command.SetArgs([]string{"some/app", "--process", "worker", "--env-file", envFile, "-e", "OVERRIDDEN=flag-value", "-p", "8080", "--port", "127.0.0.1:9090:9090"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("requires an image", func() {
+		command.SetArgs([]string{})
+		h.AssertError(t, command.Execute(), "accepts 1 arg(s), received 0")
+	})
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
-func NewSBOMCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+func NewSBOMCommand(logger logging.Logger, cfg config.Config, writerFactory SBOMDiffWriterFactory, client PackClient) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sbom",
 		Short: "Interact with SBoM",
@@ -15,6 +15,7 @@ func NewSBOMCommand(logger logging.Logger, cfg config.Config, client PackClient)
 	}
 
 	cmd.AddCommand(DownloadSBOM(logger, client))
+	cmd.AddCommand(SBOMDiff(logger, writerFactory, client))
 	AddHelpFlag(cmd, "sbom")
 	return cmd
 }
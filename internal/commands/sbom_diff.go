@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type SBOMDiffWriterFactory interface {
+	SBOMDiffWriter(kind string) (writer.SBOMDiffWriter, error)
+}
+
+type SBOMDiffFlags struct {
+	Remote       bool
+	OutputFormat string
+}
+
+func SBOMDiff(logger logging.Logger, writerFactory SBOMDiffWriterFactory, pack PackClient) *cobra.Command {
+	var flags SBOMDiffFlags
+	cmd := &cobra.Command{
+		Use:     "diff <from-image> <to-image>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Show what changed between the SBoMs of two app images",
+		Example: "pack sbom diff my-app:v1 my-app:v2 --output json",
+		Long: "Compare the SBoMs of two app images, showing packages added, removed and whose version changed, " +
+			"grouped by the buildpack that listed them and by launch or build scope.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			w, err := writerFactory.SBOMDiffWriter(flags.OutputFormat)
+			if err != nil {
+				return err
+			}
+
+			diff, err := pack.DiffSBOM(cmd.Context(), client.DiffSBOMOptions{
+				From:   args[0],
+				To:     args[1],
+				Daemon: !flags.Remote,
+			})
+			if err != nil {
+				return err
+			}
+
+			return w.Print(logger, diff)
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Compare the SBoMs of the images in the registry rather than in the daemon")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "diff")
+	return cmd
+}
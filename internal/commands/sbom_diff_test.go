@@ -0,0 +1,119 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSBOMDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SBOMDiffCommand", testSBOMDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSBOMDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		diff           *client.SBOMDiff
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.SBOMDiff(logger, writer.NewFactory(), mockClient)
+
+		diff = &client.SBOMDiff{
+			From: "some/app:v1",
+			To:   "some/app:v2",
+			Groups: []client.SBOMDiffGroup{
+				{
+					Buildpack: "some/node",
+					Scope:     "launch",
+					Packages: []client.SBOMPackageDiff{
+						{Name: "node", PURL: "pkg:generic/node", FromVersion: "18.0.0", ToVersion: "20.0.0", Change: client.ChangeUpgraded},
+					},
+				},
+			},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("prints the differences between the SBoMs of the images in the daemon", func() {
+		mockClient.EXPECT().
+			DiffSBOM(gomock.Any(), client.DiffSBOMOptions{From: "some/app:v1", To: "some/app:v2", Daemon: true}).
+			Return(diff, nil)
+
+		command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+		h.AssertNil(t, command.Execute())
+
+		h.AssertContains(t, outBuf.String(), "Comparing SBoM of image 'some/app:v1' to 'some/app:v2'")
+		h.AssertContains(t, outBuf.String(), "some/node (launch):")
+		h.AssertContains(t, outBuf.String(), "upgraded   node   18.0.0 -> 20.0.0")
+	})
+
+	when("--remote", func() {
+		it("compares the images in the registry", func() {
+			mockClient.EXPECT().
+				DiffSBOM(gomock.Any(), client.DiffSBOMOptions{From: "some/app:v1", To: "some/app:v2"}).
+				Return(diff, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--remote"})
+			h.AssertNil(t, command.Execute())
+		})
+	})
+
+	when("--output json", func() {
+		it("prints the differences as json", func() {
+			mockClient.EXPECT().
+				DiffSBOM(gomock.Any(), gomock.Any()).
+				Return(diff, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+
+			h.NewAssertionManager(t).ContainsJSON(outBuf.String(), `{"groups": [{"buildpack": "some/node", "scope": "launch", "packages": [
+  {"name": "node", "purl": "pkg:generic/node", "change": "upgraded", "from_version": "18.0.0", "to_version": "20.0.0"}
+]}]}`)
+		})
+	})
+
+	when("the output format is not supported", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--output", "toml"})
+			h.AssertError(t, command.Execute(), "output format 'toml' is not supported")
+		})
+	})
+
+	when("the SBoMs can't be compared", func() {
+		it("errors", func() {
+			mockClient.EXPECT().
+				DiffSBOM(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("could not find SBoM information on 'some/app:v2'"))
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+			h.AssertError(t, command.Execute(), "could not find SBoM information on 'some/app:v2'")
+		})
+	})
+}
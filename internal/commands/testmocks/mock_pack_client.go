@@ -8,9 +8,9 @@ import (
 	context "context"
 	reflect "reflect"
 
-	gomock "github.com/golang/mock/gomock"
-
+	cache "github.com/buildpacks/pack/pkg/cache"
 	client "github.com/buildpacks/pack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
 )
 
 // MockPackClient is a mock of PackClient interface.
@@ -120,6 +120,51 @@ func (mr *MockPackClientMockRecorder) DeleteManifest(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManifest", reflect.TypeOf((*MockPackClient)(nil).DeleteManifest), arg0)
 }
 
+// DiffBuilders mocks base method.
+func (m *MockPackClient) DiffBuilders(arg0 context.Context, arg1 client.DiffBuildersOptions) (*client.BuilderDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffBuilders", arg0, arg1)
+	ret0, _ := ret[0].(*client.BuilderDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffBuilders indicates an expected call of DiffBuilders.
+func (mr *MockPackClientMockRecorder) DiffBuilders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffBuilders", reflect.TypeOf((*MockPackClient)(nil).DiffBuilders), arg0, arg1)
+}
+
+// DiffImages mocks base method.
+func (m *MockPackClient) DiffImages(arg0 context.Context, arg1 client.DiffImagesOptions) (*client.ImageDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffImages", arg0, arg1)
+	ret0, _ := ret[0].(*client.ImageDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffImages indicates an expected call of DiffImages.
+func (mr *MockPackClientMockRecorder) DiffImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffImages", reflect.TypeOf((*MockPackClient)(nil).DiffImages), arg0, arg1)
+}
+
+// DiffSBOM mocks base method.
+func (m *MockPackClient) DiffSBOM(arg0 context.Context, arg1 client.DiffSBOMOptions) (*client.SBOMDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffSBOM", arg0, arg1)
+	ret0, _ := ret[0].(*client.SBOMDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffSBOM indicates an expected call of DiffSBOM.
+func (mr *MockPackClientMockRecorder) DiffSBOM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffSBOM", reflect.TypeOf((*MockPackClient)(nil).DiffSBOM), arg0, arg1)
+}
+
 // DownloadSBOM mocks base method.
 func (m *MockPackClient) DownloadSBOM(arg0 string, arg1 client.DownloadSBOMOptions) error {
 	m.ctrl.T.Helper()
@@ -134,6 +179,48 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// ExportCache mocks base method.
+func (m *MockPackClient) ExportCache(arg0 context.Context, arg1 client.ExportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCache indicates an expected call of ExportCache.
+func (mr *MockPackClientMockRecorder) ExportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCache", reflect.TypeOf((*MockPackClient)(nil).ExportCache), arg0, arg1)
+}
+
+// ExtendBuilder mocks base method.
+func (m *MockPackClient) ExtendBuilder(arg0 context.Context, arg1 client.ExtendBuilderOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendBuilder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendBuilder indicates an expected call of ExtendBuilder.
+func (mr *MockPackClientMockRecorder) ExtendBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendBuilder", reflect.TypeOf((*MockPackClient)(nil).ExtendBuilder), arg0, arg1)
+}
+
+// ImportCache mocks base method.
+func (m *MockPackClient) ImportCache(arg0 context.Context, arg1 client.ImportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCache indicates an expected call of ImportCache.
+func (mr *MockPackClientMockRecorder) ImportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCache", reflect.TypeOf((*MockPackClient)(nil).ImportCache), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +256,21 @@ func (mr *MockPackClientMockRecorder) InspectBuildpack(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuildpack", reflect.TypeOf((*MockPackClient)(nil).InspectBuildpack), arg0)
 }
 
+// InspectCache mocks base method.
+func (m *MockPackClient) InspectCache(arg0 context.Context, arg1 string) ([]cache.VolumeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectCache", arg0, arg1)
+	ret0, _ := ret[0].([]cache.VolumeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectCache indicates an expected call of InspectCache.
+func (mr *MockPackClientMockRecorder) InspectCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectCache", reflect.TypeOf((*MockPackClient)(nil).InspectCache), arg0, arg1)
+}
+
 // InspectExtension mocks base method.
 func (m *MockPackClient) InspectExtension(arg0 client.InspectExtensionOptions) (*client.ExtensionInfo, error) {
 	m.ctrl.T.Helper()
@@ -213,6 +315,21 @@ func (mr *MockPackClientMockRecorder) InspectManifest(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectManifest", reflect.TypeOf((*MockPackClient)(nil).InspectManifest), arg0)
 }
 
+// ListCaches mocks base method.
+func (m *MockPackClient) ListCaches(arg0 context.Context) ([]cache.VolumeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCaches", arg0)
+	ret0, _ := ret[0].([]cache.VolumeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCaches indicates an expected call of ListCaches.
+func (mr *MockPackClientMockRecorder) ListCaches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCaches", reflect.TypeOf((*MockPackClient)(nil).ListCaches), arg0)
+}
+
 // NewBuildpack mocks base method.
 func (m *MockPackClient) NewBuildpack(arg0 context.Context, arg1 client.NewBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -255,6 +372,21 @@ func (mr *MockPackClientMockRecorder) PackageExtension(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PackageExtension", reflect.TypeOf((*MockPackClient)(nil).PackageExtension), arg0, arg1)
 }
 
+// PruneCaches mocks base method.
+func (m *MockPackClient) PruneCaches(arg0 context.Context, arg1 cache.PruneOptions) ([]cache.VolumeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneCaches", arg0, arg1)
+	ret0, _ := ret[0].([]cache.VolumeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneCaches indicates an expected call of PruneCaches.
+func (mr *MockPackClientMockRecorder) PruneCaches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneCaches", reflect.TypeOf((*MockPackClient)(nil).PruneCaches), arg0, arg1)
+}
+
 // PullBuildpack mocks base method.
 func (m *MockPackClient) PullBuildpack(arg0 context.Context, arg1 client.PullBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -297,6 +429,21 @@ func (mr *MockPackClientMockRecorder) Rebase(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockPackClient)(nil).Rebase), arg0, arg1)
 }
 
+// RebaseAll mocks base method.
+func (m *MockPackClient) RebaseAll(arg0 context.Context, arg1 client.RebaseAllOptions) ([]client.RebaseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseAll", arg0, arg1)
+	ret0, _ := ret[0].([]client.RebaseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebaseAll indicates an expected call of RebaseAll.
+func (mr *MockPackClientMockRecorder) RebaseAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseAll", reflect.TypeOf((*MockPackClient)(nil).RebaseAll), arg0, arg1)
+}
+
 // RegisterBuildpack mocks base method.
 func (m *MockPackClient) RegisterBuildpack(arg0 context.Context, arg1 client.RegisterBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -311,6 +458,20 @@ func (mr *MockPackClientMockRecorder) RegisterBuildpack(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBuildpack", reflect.TypeOf((*MockPackClient)(nil).RegisterBuildpack), arg0, arg1)
 }
 
+// RemoveCaches mocks base method.
+func (m *MockPackClient) RemoveCaches(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCaches", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCaches indicates an expected call of RemoveCaches.
+func (mr *MockPackClientMockRecorder) RemoveCaches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCaches", reflect.TypeOf((*MockPackClient)(nil).RemoveCaches), arg0, arg1)
+}
+
 // RemoveManifest mocks base method.
 func (m *MockPackClient) RemoveManifest(arg0 string, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -325,6 +486,64 @@ func (mr *MockPackClientMockRecorder) RemoveManifest(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveManifest", reflect.TypeOf((*MockPackClient)(nil).RemoveManifest), arg0, arg1)
 }
 
+// Run mocks base method.
+func (m *MockPackClient) Run(arg0 context.Context, arg1 client.RunOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockPackClientMockRecorder) Run(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockPackClient)(nil).Run), arg0, arg1)
+}
+
+// SearchBuildpack mocks base method.
+func (m *MockPackClient) SearchBuildpack(arg0 client.SearchBuildpackOptions) ([]client.BuildpackSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBuildpack", arg0)
+	ret0, _ := ret[0].([]client.BuildpackSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBuildpack indicates an expected call of SearchBuildpack.
+func (mr *MockPackClientMockRecorder) SearchBuildpack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBuildpack", reflect.TypeOf((*MockPackClient)(nil).SearchBuildpack), arg0)
+}
+
+// UpdateBuilderBase mocks base method.
+func (m *MockPackClient) UpdateBuilderBase(arg0 context.Context, arg1 client.UpdateBuilderBaseOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBuilderBase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBuilderBase indicates an expected call of UpdateBuilderBase.
+func (mr *MockPackClientMockRecorder) UpdateBuilderBase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBuilderBase", reflect.TypeOf((*MockPackClient)(nil).UpdateBuilderBase), arg0, arg1)
+}
+
+// ValidateBuilder mocks base method.
+func (m *MockPackClient) ValidateBuilder(arg0 context.Context, arg1 client.ValidateBuilderOptions) ([]client.BuilderDiagnostic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBuilder", arg0, arg1)
+	ret0, _ := ret[0].([]client.BuilderDiagnostic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateBuilder indicates an expected call of ValidateBuilder.
+func (mr *MockPackClientMockRecorder) ValidateBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBuilder", reflect.TypeOf((*MockPackClient)(nil).ValidateBuilder), arg0, arg1)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
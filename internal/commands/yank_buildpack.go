@@ -35,8 +35,9 @@ func YankBuildpack(logger logging.Logger, cfg config.Config, pack PackClient) *c
 			opts := client.YankBuildpackOptions{
 				ID:      id,
 				Version: version,
-				Type:    "github",
+				Type:    registry.Type,
 				URL:     registry.URL,
+				Name:    registry.Name,
 				Yank:    !flags.Undo,
 			}
 
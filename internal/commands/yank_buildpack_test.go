@@ -68,6 +68,7 @@ func testYankBuildpackCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    true,
 				}
 
@@ -103,6 +104,7 @@ func testYankBuildpackCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    true,
 				}
 
@@ -120,6 +122,7 @@ func testYankBuildpackCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    false,
 				}
 				mockClient.EXPECT().
@@ -154,6 +157,7 @@ func testYankBuildpackCommand(t *testing.T, when spec.G, it spec.S) {
 						Version: "0.0.1",
 						Type:    "github",
 						URL:     "https://github.com/override/buildpack-registry",
+						Name:    "override",
 						Yank:    true,
 					}
 					mockClient.EXPECT().
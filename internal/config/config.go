@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/pkg/errors"
@@ -23,10 +24,35 @@ type Config struct {
 	LifecycleImage      string            `toml:"lifecycle-image,omitempty"`
 	RegistryMirrors     map[string]string `toml:"registry-mirrors,omitempty"`
 	LayoutRepositoryDir string            `toml:"layout-repo-dir,omitempty"`
+	CachePolicy         CachePolicy       `toml:"cache-policy,omitempty"`
+	Verification        Verification      `toml:"verification,omitempty"`
+}
+
+// Verification requires the builder, run image, lifecycle and remote buildpacks of builds to be signed by a trusted
+// key or to have an allowed digest. It applies when it lists keys or digests.
+type Verification struct {
+	// Mode is enforce (fail builds) or warn
+	Mode string `toml:"mode,omitempty"`
+	// Keys are paths to PEM encoded public keys, such as cosign.pub files
+	Keys []string `toml:"keys,omitempty"`
+	// AllowedDigests are digests trusted without a signature
+	AllowedDigests []string `toml:"allowed-digests,omitempty"`
+}
+
+// CachePolicy limits the disk space used by the cache volumes pack creates. Least-recently-used volumes are evicted
+// before each build to stay within the limits.
+type CachePolicy struct {
+	// MaxTotalSize is the maximum size of all cache volumes, e.g. "10GB"
+	MaxTotalSize string `toml:"max-total-size,omitempty"`
+	// MaxAge is the maximum time a cache volume can stay unused, e.g. "168h" or "7d"
+	MaxAge string `toml:"max-age,omitempty"`
+	// MaxImages is the maximum number of images to keep caches for
+	MaxImages int `toml:"max-images,omitempty"`
 }
 
 type VolumeConfig struct {
-	VolumeKeys map[string]string `toml:"volume-keys,omitempty"`
+	VolumeKeys map[string]string    `toml:"volume-keys,omitempty"`
+	LastUsed   map[string]time.Time `toml:"last-used,omitempty"`
 }
 
 type Registry struct {
@@ -72,6 +72,10 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 					RegistryMirrors: map[string]string{
 						"index.docker.io": "10.0.0.1",
 					},
+					CachePolicy: config.CachePolicy{
+						MaxTotalSize: "10GB",
+						MaxAge:       "7d",
+					},
 				}, configPath))
 
 				b, err := os.ReadFile(configPath)
@@ -90,6 +94,10 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 
 				h.AssertContains(t, string(b), `[registry-mirrors]
   "index.docker.io" = "10.0.0.1"`)
+
+				h.AssertContains(t, string(b), `[cache-policy]
+  max-total-size = "10GB"
+  max-age = "7d"`)
 			})
 		})
 
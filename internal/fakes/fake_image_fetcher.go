@@ -2,6 +2,7 @@ package fakes
 
 import (
 	"context"
+	"sync"
 
 	"github.com/buildpacks/imgutil"
 	"github.com/pkg/errors"
@@ -18,6 +19,7 @@ type FetchArgs struct {
 }
 
 type FakeImageFetcher struct {
+	mutex        sync.Mutex
 	LocalImages  map[string]imgutil.Image
 	RemoteImages map[string]imgutil.Image
 	FetchCalls   map[string]*FetchArgs
@@ -32,6 +34,9 @@ func NewFakeImageFetcher() *FakeImageFetcher {
 }
 
 func (f *FakeImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
 	f.FetchCalls[name] = &FetchArgs{Daemon: options.Daemon, PullPolicy: options.PullPolicy, Target: options.Target, LayoutOption: options.LayoutOption}
 
 	ri, remoteFound := f.RemoteImages[name]
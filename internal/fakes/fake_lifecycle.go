@@ -2,15 +2,43 @@ package fakes
 
 import (
 	"context"
+	"sync"
 
 	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/logging"
 )
 
 type FakeLifecycle struct {
 	Opts build.LifecycleOptions
+	// Events are reported to the event sink of the build, if any
+	Events []logging.Event
+	// ExecuteFunc is called with the options of each execution when set, e.g. to write the image it exports
+	ExecuteFunc func(opts build.LifecycleOptions) error
+
+	mutex      sync.Mutex
+	executions int
 }
 
 func (f *FakeLifecycle) Execute(ctx context.Context, opts build.LifecycleOptions) error {
+	f.mutex.Lock()
 	f.Opts = opts
+	f.executions++
+	f.mutex.Unlock()
+
+	if opts.EventSink != nil {
+		for _, event := range f.Events {
+			opts.EventSink(event)
+		}
+	}
+	if f.ExecuteFunc != nil {
+		return f.ExecuteFunc(opts)
+	}
 	return nil
 }
+
+// Executions returns the number of times the lifecycle was executed
+func (f *FakeLifecycle) Executions() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.executions
+}
@@ -0,0 +1,123 @@
+package inspectimage
+
+import (
+	"github.com/buildpacks/pack/pkg/client"
+)
+
+type RunImageDiffDisplay struct {
+	FromImage     string `json:"from_image" yaml:"from_image"`
+	ToImage       string `json:"to_image" yaml:"to_image"`
+	FromReference string `json:"from_reference" yaml:"from_reference"`
+	ToReference   string `json:"to_reference" yaml:"to_reference"`
+	FromTopLayer  string `json:"from_top_layer" yaml:"from_top_layer"`
+	ToTopLayer    string `json:"to_top_layer" yaml:"to_top_layer"`
+}
+
+type BuildpackDiffDisplay struct {
+	ID          string `json:"id" yaml:"id"`
+	Change      string `json:"change" yaml:"change"`
+	FromVersion string `json:"from_version,omitempty" yaml:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty" yaml:"to_version,omitempty"`
+}
+
+type LayerDiffDisplay struct {
+	Buildpack  string `json:"buildpack,omitempty" yaml:"buildpack,omitempty"`
+	Name       string `json:"name" yaml:"name"`
+	Change     string `json:"change" yaml:"change"`
+	FromDigest string `json:"from_digest,omitempty" yaml:"from_digest,omitempty"`
+	ToDigest   string `json:"to_digest,omitempty" yaml:"to_digest,omitempty"`
+}
+
+type ProcessDiffDisplay struct {
+	Type        string `json:"type" yaml:"type"`
+	Change      string `json:"change" yaml:"change"`
+	FromCommand string `json:"from_command,omitempty" yaml:"from_command,omitempty"`
+	ToCommand   string `json:"to_command,omitempty" yaml:"to_command,omitempty"`
+}
+
+type PackageDiffDisplay struct {
+	Buildpack   string `json:"buildpack" yaml:"buildpack"`
+	Scope       string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Name        string `json:"name" yaml:"name"`
+	Change      string `json:"change" yaml:"change"`
+	FromVersion string `json:"from_version,omitempty" yaml:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty" yaml:"to_version,omitempty"`
+}
+
+type DiffOutput struct {
+	From       string                 `json:"from" yaml:"from"`
+	To         string                 `json:"to" yaml:"to"`
+	RunImage   *RunImageDiffDisplay   `json:"run_image" yaml:"run_image"`
+	Buildpacks []BuildpackDiffDisplay `json:"buildpacks" yaml:"buildpacks"`
+	Layers     []LayerDiffDisplay     `json:"layers" yaml:"layers"`
+	Processes  []ProcessDiffDisplay   `json:"processes" yaml:"processes"`
+	Packages   []PackageDiffDisplay   `json:"packages" yaml:"packages"`
+	SBOM       *LayerDiffDisplay      `json:"sbom" yaml:"sbom"`
+}
+
+func NewDiffOutput(diff *client.ImageDiff) DiffOutput {
+	output := DiffOutput{
+		From:       diff.From,
+		To:         diff.To,
+		Buildpacks: []BuildpackDiffDisplay{},
+		Layers:     []LayerDiffDisplay{},
+		Processes:  []ProcessDiffDisplay{},
+		Packages:   []PackageDiffDisplay{},
+	}
+
+	if diff.RunImage != nil {
+		output.RunImage = &RunImageDiffDisplay{
+			FromImage:     diff.RunImage.FromImage,
+			ToImage:       diff.RunImage.ToImage,
+			FromReference: diff.RunImage.FromReference,
+			ToReference:   diff.RunImage.ToReference,
+			FromTopLayer:  diff.RunImage.FromTopLayer,
+			ToTopLayer:    diff.RunImage.ToTopLayer,
+		}
+	}
+	for _, bp := range diff.Buildpacks {
+		output.Buildpacks = append(output.Buildpacks, BuildpackDiffDisplay{
+			ID:          bp.ID,
+			Change:      string(bp.Change),
+			FromVersion: bp.FromVersion,
+			ToVersion:   bp.ToVersion,
+		})
+	}
+	for _, layer := range diff.Layers {
+		output.Layers = append(output.Layers, displayLayerDiff(layer))
+	}
+	for _, process := range diff.Processes {
+		output.Processes = append(output.Processes, ProcessDiffDisplay{
+			Type:        process.Type,
+			Change:      string(process.Change),
+			FromCommand: process.FromCommand,
+			ToCommand:   process.ToCommand,
+		})
+	}
+	for _, pkg := range diff.Packages {
+		output.Packages = append(output.Packages, PackageDiffDisplay{
+			Buildpack:   pkg.Buildpack,
+			Scope:       pkg.Scope,
+			Name:        pkg.Name,
+			Change:      string(pkg.Change),
+			FromVersion: pkg.FromVersion,
+			ToVersion:   pkg.ToVersion,
+		})
+	}
+	if diff.SBOM != nil {
+		sbom := displayLayerDiff(*diff.SBOM)
+		output.SBOM = &sbom
+	}
+
+	return output
+}
+
+func displayLayerDiff(layer client.LayerDiff) LayerDiffDisplay {
+	return LayerDiffDisplay{
+		Buildpack:  layer.Buildpack,
+		Name:       layer.Name,
+		Change:     string(layer.Change),
+		FromDigest: layer.FromDigest,
+		ToDigest:   layer.ToDigest,
+	}
+}
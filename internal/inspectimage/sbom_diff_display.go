@@ -0,0 +1,53 @@
+package inspectimage
+
+import (
+	"github.com/buildpacks/pack/pkg/client"
+)
+
+type SBOMPackageDiffDisplay struct {
+	Name        string `json:"name" yaml:"name"`
+	PURL        string `json:"purl,omitempty" yaml:"purl,omitempty"`
+	Change      string `json:"change" yaml:"change"`
+	FromVersion string `json:"from_version,omitempty" yaml:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty" yaml:"to_version,omitempty"`
+}
+
+type SBOMDiffGroupDisplay struct {
+	Buildpack string                   `json:"buildpack" yaml:"buildpack"`
+	Scope     string                   `json:"scope" yaml:"scope"`
+	Packages  []SBOMPackageDiffDisplay `json:"packages" yaml:"packages"`
+}
+
+type SBOMDiffOutput struct {
+	From   string                 `json:"from" yaml:"from"`
+	To     string                 `json:"to" yaml:"to"`
+	Groups []SBOMDiffGroupDisplay `json:"groups" yaml:"groups"`
+}
+
+func NewSBOMDiffOutput(diff *client.SBOMDiff) SBOMDiffOutput {
+	output := SBOMDiffOutput{
+		From:   diff.From,
+		To:     diff.To,
+		Groups: []SBOMDiffGroupDisplay{},
+	}
+
+	for _, group := range diff.Groups {
+		groupOutput := SBOMDiffGroupDisplay{
+			Buildpack: group.Buildpack,
+			Scope:     group.Scope,
+			Packages:  []SBOMPackageDiffDisplay{},
+		}
+		for _, pkg := range group.Packages {
+			groupOutput.Packages = append(groupOutput.Packages, SBOMPackageDiffDisplay{
+				Name:        pkg.Name,
+				PURL:        pkg.PURL,
+				Change:      string(pkg.Change),
+				FromVersion: pkg.FromVersion,
+				ToVersion:   pkg.ToVersion,
+			})
+		}
+		output.Groups = append(output.Groups, groupOutput)
+	}
+
+	return output
+}
@@ -0,0 +1,98 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/buildpacks/pack/internal/inspectimage"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type DiffHumanReadable struct{}
+
+func NewDiffHumanReadable() *DiffHumanReadable {
+	return &DiffHumanReadable{}
+}
+
+func (h *DiffHumanReadable) Print(logger logging.Logger, diff *client.ImageDiff) error {
+	logger.Infof("Comparing image %s to %s\n", style.Symbol(diff.From), style.Symbol(diff.To))
+
+	if diff.IsEmpty() {
+		logger.Info("\n(no differences)\n")
+		return nil
+	}
+
+	tpl := template.Must(template.New("diff").
+		Funcs(template.FuncMap{"transition": transition}).
+		Parse(diffTemplate))
+
+	buf := bytes.NewBuffer(nil)
+	tw := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	if err := tpl.Execute(tw, inspectimage.NewDiffOutput(diff)); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Info(buf.String())
+	return nil
+}
+
+// transition describes how a value changed, e.g. "1.0.0 -> 1.1.0"
+func transition(change, from, to string) string {
+	switch client.Change(change) {
+	case client.ChangeAdded:
+		return to
+	case client.ChangeRemoved:
+		return from
+	default:
+		return fmt.Sprintf("%s -> %s", from, to)
+	}
+}
+
+var diffTemplate = `
+{{- if .RunImage }}
+Run Image:
+  Image:	{{ transition "changed" .RunImage.FromImage .RunImage.ToImage }}
+{{- if or .RunImage.FromReference .RunImage.ToReference }}
+  Reference:	{{ transition "changed" .RunImage.FromReference .RunImage.ToReference }}
+{{- end }}
+  Top Layer:	{{ transition "changed" .RunImage.FromTopLayer .RunImage.ToTopLayer }}
+{{ end }}
+{{- if .Buildpacks }}
+Buildpacks:
+  CHANGE	ID	VERSION
+{{- range $_, $b := .Buildpacks }}
+  {{ $b.Change }}	{{ $b.ID }}	{{ transition $b.Change $b.FromVersion $b.ToVersion }}
+{{- end }}
+{{ end }}
+{{- if .Layers }}
+Layers:
+  CHANGE	BUILDPACK	LAYER	DIGEST
+{{- range $_, $l := .Layers }}
+  {{ $l.Change }}	{{ $l.Buildpack }}	{{ $l.Name }}	{{ transition $l.Change $l.FromDigest $l.ToDigest }}
+{{- end }}
+{{ end }}
+{{- if .Processes }}
+Processes:
+  CHANGE	TYPE	COMMAND
+{{- range $_, $p := .Processes }}
+  {{ $p.Change }}	{{ $p.Type }}	{{ transition $p.Change $p.FromCommand $p.ToCommand }}
+{{- end }}
+{{ end }}
+{{- if .Packages }}
+Packages:
+  CHANGE	BUILDPACK	SCOPE	NAME	VERSION
+{{- range $_, $p := .Packages }}
+  {{ $p.Change }}	{{ $p.Buildpack }}	{{ or $p.Scope "-" }}	{{ $p.Name }}	{{ transition $p.Change $p.FromVersion $p.ToVersion }}
+{{- end }}
+{{ end }}
+{{- if .SBOM }}
+SBOM:
+  {{ .SBOM.Change }}	{{ transition .SBOM.Change .SBOM.FromDigest .SBOM.ToDigest }}
+{{ end }}`
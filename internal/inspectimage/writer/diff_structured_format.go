@@ -0,0 +1,83 @@
+package writer
+
+import (
+	"github.com/buildpacks/pack/internal/inspectimage"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type StructuredDiffFormat struct {
+	MarshalFunc func(interface{}) ([]byte, error)
+}
+
+func (w *StructuredDiffFormat) Print(logger logging.Logger, diff *client.ImageDiff) error {
+	out, err := w.MarshalFunc(inspectimage.NewDiffOutput(diff))
+	if err != nil {
+		return err
+	}
+
+	_, err = logger.Writer().Write(out)
+	return err
+}
+
+type JSONDiff struct {
+	StructuredDiffFormat
+}
+
+func NewJSONDiff() *JSONDiff {
+	return &JSONDiff{
+		StructuredDiffFormat: StructuredDiffFormat{
+			MarshalFunc: NewJSON().MarshalFunc,
+		},
+	}
+}
+
+type YAMLDiff struct {
+	StructuredDiffFormat
+}
+
+func NewYAMLDiff() *YAMLDiff {
+	return &YAMLDiff{
+		StructuredDiffFormat: StructuredDiffFormat{
+			MarshalFunc: NewYAML().MarshalFunc,
+		},
+	}
+}
+
+type StructuredSBOMDiffFormat struct {
+	MarshalFunc func(interface{}) ([]byte, error)
+}
+
+func (w *StructuredSBOMDiffFormat) Print(logger logging.Logger, diff *client.SBOMDiff) error {
+	out, err := w.MarshalFunc(inspectimage.NewSBOMDiffOutput(diff))
+	if err != nil {
+		return err
+	}
+
+	_, err = logger.Writer().Write(out)
+	return err
+}
+
+type JSONSBOMDiff struct {
+	StructuredSBOMDiffFormat
+}
+
+func NewJSONSBOMDiff() *JSONSBOMDiff {
+	return &JSONSBOMDiff{
+		StructuredSBOMDiffFormat: StructuredSBOMDiffFormat{
+			MarshalFunc: NewJSON().MarshalFunc,
+		},
+	}
+}
+
+type YAMLSBOMDiff struct {
+	StructuredSBOMDiffFormat
+}
+
+func NewYAMLSBOMDiff() *YAMLSBOMDiff {
+	return &YAMLSBOMDiff{
+		StructuredSBOMDiffFormat: StructuredSBOMDiffFormat{
+			MarshalFunc: NewYAML().MarshalFunc,
+		},
+	}
+}
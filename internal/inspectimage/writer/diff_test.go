@@ -0,0 +1,128 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffWriters(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Diff Writers", testDiffWriters, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffWriters(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+		logger logging.Logger
+		diff   *client.ImageDiff
+	)
+
+	it.Before(func() {
+		outBuf = bytes.Buffer{}
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		diff = &client.ImageDiff{
+			From: "some/app:v1",
+			To:   "some/app:v2",
+			RunImage: &client.RunImageDiff{
+				FromImage:     "some/run",
+				ToImage:       "some/run",
+				FromReference: "some/run@sha256:old",
+				ToReference:   "some/run@sha256:new",
+				FromTopLayer:  "old-top-layer",
+				ToTopLayer:    "new-top-layer",
+			},
+			Buildpacks: []client.BuildpackDiff{
+				{ID: "some/go", ToVersion: "0.1.0", Change: client.ChangeAdded},
+				{ID: "some/node", FromVersion: "1.0.0", ToVersion: "1.1.0", Change: client.ChangeUpgraded},
+			},
+			Layers: []client.LayerDiff{
+				{Buildpack: "some/node", Name: "node", FromDigest: "sha256:node-1", ToDigest: "sha256:node-2", Change: client.ChangeChanged},
+			},
+			Processes: []client.ProcessDiff{
+				{Type: "web", FromCommand: "npm start", ToCommand: "node server.js", Change: client.ChangeChanged},
+			},
+			Packages: []client.PackageDiff{
+				{Buildpack: "some/node", Name: "node", FromVersion: "18.0.0", ToVersion: "20.0.0", Change: client.ChangeUpgraded},
+				{Buildpack: "some/npm", Scope: "launch", Name: "express", ToVersion: "4.17.0", Change: client.ChangeAdded},
+			},
+			SBOM: &client.LayerDiff{Name: "sbom", FromDigest: "sha256:old-sbom", ToDigest: "sha256:new-sbom", Change: client.ChangeChanged},
+		}
+	})
+
+	when("human-readable", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewDiffHumanReadable().Print(logger, diff))
+
+			assert.Contains(outBuf.String(), "Comparing image 'some/app:v1' to 'some/app:v2'")
+			assert.Contains(outBuf.String(), `Run Image:
+  Image:       some/run -> some/run
+  Reference:   some/run@sha256:old -> some/run@sha256:new
+  Top Layer:   old-top-layer -> new-top-layer`)
+			assert.Contains(outBuf.String(), `Buildpacks:
+  CHANGE     ID          VERSION
+  added      some/go     0.1.0
+  upgraded   some/node   1.0.0 -> 1.1.0`)
+			assert.Contains(outBuf.String(), `Layers:
+  CHANGE    BUILDPACK   LAYER   DIGEST
+  changed   some/node   node    sha256:node-1 -> sha256:node-2`)
+			assert.Contains(outBuf.String(), `Processes:
+  CHANGE    TYPE   COMMAND
+  changed   web    npm start -> node server.js`)
+			assert.Contains(outBuf.String(), `Packages:
+  CHANGE     BUILDPACK   SCOPE    NAME      VERSION
+  upgraded   some/node   -        node      18.0.0 -> 20.0.0
+  added      some/npm    launch   express   4.17.0`)
+			assert.Contains(outBuf.String(), `SBOM:
+  changed   sha256:old-sbom -> sha256:new-sbom`)
+		})
+
+		it("prints that the images don't differ", func() {
+			assert.Nil(writer.NewDiffHumanReadable().Print(logger, &client.ImageDiff{From: "some/app:v1", To: "some/app:v1"}))
+
+			assert.Contains(outBuf.String(), "(no differences)")
+		})
+	})
+
+	when("json", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewJSONDiff().Print(logger, diff))
+
+			assert.ContainsJSON(outBuf.String(), `{
+  "from": "some/app:v1",
+  "to": "some/app:v2",
+  "buildpacks": [
+    {"id": "some/go", "change": "added", "to_version": "0.1.0"},
+    {"id": "some/node", "change": "upgraded", "from_version": "1.0.0", "to_version": "1.1.0"}
+  ],
+  "sbom": {"name": "sbom", "change": "changed", "from_digest": "sha256:old-sbom", "to_digest": "sha256:new-sbom"}
+}`)
+		})
+	})
+
+	when("yaml", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewYAMLDiff().Print(logger, diff))
+
+			assert.ContainsYAML(outBuf.String(), `---
+from: some/app:v1
+to: some/app:v2
+processes:
+- type: web
+  change: changed
+  from_command: npm start
+  to_command: node server.js
+`)
+		})
+	})
+}
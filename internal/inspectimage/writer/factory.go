@@ -22,6 +22,14 @@ type InspectImageWriter interface {
 	) error
 }
 
+type ImageDiffWriter interface {
+	Print(logger logging.Logger, diff *client.ImageDiff) error
+}
+
+type SBOMDiffWriter interface {
+	Print(logger logging.Logger, diff *client.SBOMDiff) error
+}
+
 func NewFactory() *Factory {
 	return &Factory{}
 }
@@ -49,3 +57,29 @@ func (f *Factory) Writer(kind string, bom bool) (InspectImageWriter, error) {
 
 	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
 }
+
+func (f *Factory) DiffWriter(kind string) (ImageDiffWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewDiffHumanReadable(), nil
+	case "json":
+		return NewJSONDiff(), nil
+	case "yaml":
+		return NewYAMLDiff(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
+}
+
+func (f *Factory) SBOMDiffWriter(kind string) (SBOMDiffWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewSBOMDiffHumanReadable(), nil
+	case "json":
+		return NewJSONSBOMDiff(), nil
+	case "yaml":
+		return NewYAMLSBOMDiff(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
+}
@@ -145,4 +145,52 @@ func testFactory(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("DiffWriter", func() {
+		when("output format is human-readable", func() {
+			it("returns a DiffHumanReadable writer", func() {
+				returnedWriter, err := writer.NewFactory().DiffWriter("human-readable")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.DiffHumanReadable)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.DiffHumanReadable`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is json", func() {
+			it("returns a JSONDiff writer", func() {
+				returnedWriter, err := writer.NewFactory().DiffWriter("json")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.JSONDiff)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.JSONDiff`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is yaml", func() {
+			it("returns a YAMLDiff writer", func() {
+				returnedWriter, err := writer.NewFactory().DiffWriter("yaml")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.YAMLDiff)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.YAMLDiff`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is not supported", func() {
+			it("returns an error", func() {
+				_, err := writer.NewFactory().DiffWriter("toml")
+				assert.ErrorWithMessage(err, "output format 'toml' is not supported")
+			})
+		})
+	})
 }
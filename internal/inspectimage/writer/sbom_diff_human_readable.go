@@ -0,0 +1,52 @@
+package writer
+
+import (
+	"bytes"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/buildpacks/pack/internal/inspectimage"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type SBOMDiffHumanReadable struct{}
+
+func NewSBOMDiffHumanReadable() *SBOMDiffHumanReadable {
+	return &SBOMDiffHumanReadable{}
+}
+
+func (h *SBOMDiffHumanReadable) Print(logger logging.Logger, diff *client.SBOMDiff) error {
+	logger.Infof("Comparing SBoM of image %s to %s\n", style.Symbol(diff.From), style.Symbol(diff.To))
+
+	if diff.IsEmpty() {
+		logger.Info("\n(no differences)\n")
+		return nil
+	}
+
+	tpl := template.Must(template.New("sbom-diff").
+		Funcs(template.FuncMap{"transition": transition}).
+		Parse(sbomDiffTemplate))
+
+	buf := bytes.NewBuffer(nil)
+	tw := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	if err := tpl.Execute(tw, inspectimage.NewSBOMDiffOutput(diff)); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Info(buf.String())
+	return nil
+}
+
+var sbomDiffTemplate = `
+{{- range $_, $g := .Groups }}
+{{ $g.Buildpack }} ({{ $g.Scope }}):
+  CHANGE	NAME	VERSION
+{{- range $_, $p := $g.Packages }}
+  {{ $p.Change }}	{{ $p.Name }}	{{ transition $p.Change $p.FromVersion $p.ToVersion }}
+{{- end }}
+{{ end }}`
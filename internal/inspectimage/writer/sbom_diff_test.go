@@ -0,0 +1,134 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSBOMDiffWriters(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SBOM Diff Writers", testSBOMDiffWriters, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSBOMDiffWriters(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+		logger logging.Logger
+		diff   *client.SBOMDiff
+	)
+
+	it.Before(func() {
+		outBuf = bytes.Buffer{}
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		diff = &client.SBOMDiff{
+			From: "some/app:v1",
+			To:   "some/app:v2",
+			Groups: []client.SBOMDiffGroup{
+				{
+					Buildpack: "some/npm",
+					Scope:     "build",
+					Packages: []client.SBOMPackageDiff{
+						{Name: "typescript", FromVersion: "5.0.0", ToVersion: "5.1.0", Change: client.ChangeUpgraded},
+					},
+				},
+				{
+					Buildpack: "some/npm",
+					Scope:     "launch",
+					Packages: []client.SBOMPackageDiff{
+						{Name: "express", PURL: "pkg:npm/express", FromVersion: "4.17.0", ToVersion: "4.16.0", Change: client.ChangeDowngraded},
+						{Name: "lodash", PURL: "pkg:npm/lodash", FromVersion: "4.17.21", Change: client.ChangeRemoved},
+					},
+				},
+			},
+		}
+	})
+
+	when("human-readable", func() {
+		it("prints the differences by buildpack", func() {
+			assert.Nil(writer.NewSBOMDiffHumanReadable().Print(logger, diff))
+
+			assert.Contains(outBuf.String(), "Comparing SBoM of image 'some/app:v1' to 'some/app:v2'")
+			assert.Contains(outBuf.String(), `some/npm (build):
+  CHANGE     NAME         VERSION
+  upgraded   typescript   5.0.0 -> 5.1.0`)
+			assert.Contains(outBuf.String(), `some/npm (launch):
+  CHANGE       NAME      VERSION
+  downgraded   express   4.17.0 -> 4.16.0
+  removed      lodash    4.17.21`)
+		})
+
+		it("prints that the SBoMs don't differ", func() {
+			assert.Nil(writer.NewSBOMDiffHumanReadable().Print(logger, &client.SBOMDiff{From: "some/app:v1", To: "some/app:v1"}))
+
+			assert.Contains(outBuf.String(), "(no differences)")
+		})
+	})
+
+	when("json", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewJSONSBOMDiff().Print(logger, diff))
+
+			assert.ContainsJSON(outBuf.String(), `{
+  "from": "some/app:v1",
+  "to": "some/app:v2",
+  "groups": [
+    {
+      "buildpack": "some/npm",
+      "scope": "build",
+      "packages": [{"name": "typescript", "change": "upgraded", "from_version": "5.0.0", "to_version": "5.1.0"}]
+    },
+    {
+      "buildpack": "some/npm",
+      "scope": "launch",
+      "packages": [
+        {"name": "express", "purl": "pkg:npm/express", "change": "downgraded", "from_version": "4.17.0", "to_version": "4.16.0"},
+        {"name": "lodash", "purl": "pkg:npm/lodash", "change": "removed", "from_version": "4.17.21"}
+      ]
+    }
+  ]
+}`)
+		})
+	})
+
+	when("yaml", func() {
+		it("prints the differences", func() {
+			assert.Nil(writer.NewYAMLSBOMDiff().Print(logger, diff))
+
+			assert.ContainsYAML(outBuf.String(), `---
+from: some/app:v1
+to: some/app:v2
+groups:
+- buildpack: some/npm
+  scope: build
+  packages:
+  - name: typescript
+    change: upgraded
+    from_version: 5.0.0
+    to_version: 5.1.0
+- buildpack: some/npm
+  scope: launch
+  packages:
+  - name: express
+    purl: pkg:npm/express
+    change: downgraded
+    from_version: 4.17.0
+    to_version: 4.16.0
+  - name: lodash
+    purl: pkg:npm/lodash
+    change: removed
+    from_version: 4.17.21
+`)
+		})
+	})
+}
@@ -0,0 +1,39 @@
+// Package ocilayout reads the images pack writes to OCI layouts.
+package ocilayout
+
+import (
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/pkg/errors"
+)
+
+// ReadImage returns the only image in the OCI layout at path, along with its descriptor. A layout holding several
+// images is an error, as which of them is meant can't be told.
+func ReadImage(path string) (v1.Descriptor, v1.Image, error) {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		return v1.Descriptor{}, nil, err
+	}
+	idx, err := p.ImageIndex()
+	if err != nil {
+		return v1.Descriptor{}, nil, err
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return v1.Descriptor{}, nil, err
+	}
+	switch len(manifest.Manifests) {
+	case 0:
+		return v1.Descriptor{}, nil, errors.New("no image found")
+	case 1:
+	default:
+		return v1.Descriptor{}, nil, errors.Errorf("found %d images, expected one", len(manifest.Manifests))
+	}
+
+	desc := manifest.Manifests[0]
+	img, err := idx.Image(desc.Digest)
+	if err != nil {
+		return v1.Descriptor{}, nil, err
+	}
+	return desc, img, nil
+}
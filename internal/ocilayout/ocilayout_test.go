@@ -0,0 +1,61 @@
+package ocilayout_test
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/ocilayout"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOCILayout(t *testing.T) {
+	spec.Run(t, "OCILayout", testOCILayout, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testOCILayout(t *testing.T, when spec.G, it spec.S) {
+	when("#ReadImage", func() {
+		var p layout.Path
+
+		it.Before(func() {
+			var err error
+			p, err = layout.Write(t.TempDir(), empty.Index)
+			h.AssertNil(t, err)
+		})
+
+		it("returns the only image of the layout", func() {
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			h.AssertNil(t, p.AppendImage(img))
+
+			desc, read, err := ocilayout.ReadImage(string(p))
+			h.AssertNil(t, err)
+			digest, err := img.Digest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, desc.Digest, digest)
+			readDigest, err := read.Digest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, readDigest, digest)
+		})
+
+		it("errors when the layout is empty", func() {
+			_, _, err := ocilayout.ReadImage(string(p))
+			h.AssertError(t, err, "no image found")
+		})
+
+		it("errors when the layout holds several images", func() {
+			for i := 0; i < 2; i++ {
+				img, err := random.Image(1024, 1)
+				h.AssertNil(t, err)
+				h.AssertNil(t, p.AppendImage(img))
+			}
+
+			_, _, err := ocilayout.ReadImage(string(p))
+			h.AssertError(t, err, "found 2 images, expected one")
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// NewDirRegistryCache creates a registry cache for an index in a local directory, laid out as in a git registry. The
+// directory is read and updated in place, without any git operations.
+func NewDirRegistryCache(logger logging.Logger, path string) (Cache, error) {
+	path = strings.TrimPrefix(path, "file://")
+	if path == "" {
+		return Cache{}, errors.New("registry directory must not be empty")
+	}
+
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return Cache{}, errors.Wrapf(err, "resolving registry directory %s", style.Symbol(path))
+	}
+
+	return Cache{
+		logger: logger,
+		dir:    true,
+		Root:   root,
+	}, nil
+}
+
+// validateDir checks that the directory of a registry of type 'dir' exists
+func (r *Cache) validateDir() error {
+	r.logger.Debugf("Reading registry directory %s", r.Root)
+
+	info, err := os.Stat(r.Root)
+	if err != nil {
+		return errors.Wrapf(err, "finding registry directory %s", style.Symbol(r.Root))
+	}
+	if !info.IsDir() {
+		return errors.Errorf("registry directory %s is not a directory", style.Symbol(r.Root))
+	}
+	return nil
+}
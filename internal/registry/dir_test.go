@@ -0,0 +1,99 @@
+package registry_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDirRegistryCache(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DirRegistryCache", testDirRegistryCache, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDirRegistryCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		registryDir   string
+		registryCache registry.Cache
+		outBuf        bytes.Buffer
+	)
+
+	it.Before(func() {
+		registryDir = filepath.Join(t.TempDir(), "registry")
+		h.RecursiveCopyNow(t, filepath.Join("..", "..", "testdata", "registry"), registryDir)
+
+		var err error
+		registryCache, err = registry.NewDirRegistryCache(logging.NewLogWithWriters(&outBuf, &outBuf), registryDir)
+		h.AssertNil(t, err)
+	})
+
+	when("#LocateBuildpack", func() {
+		it("reads the buildpack from the directory", func() {
+			bp, err := registryCache.LocateBuildpack("example/foo@1.1.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Address, "example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7")
+
+			_, err = os.Stat(filepath.Join(registryDir, ".git"))
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+
+		it("errors when the directory doesn't exist", func() {
+			missingCache, err := registry.NewDirRegistryCache(logging.NewLogWithWriters(&outBuf, &outBuf), filepath.Join(registryDir, "missing"))
+			h.AssertNil(t, err)
+
+			_, err = missingCache.LocateBuildpack("example/foo")
+			h.AssertError(t, err, "finding registry directory")
+		})
+	})
+
+	when("#Register", func() {
+		it("writes the buildpack to the directory", func() {
+			bp := registry.Buildpack{
+				Namespace: "example",
+				Name:      "python",
+				Version:   "0.1.0",
+				Address:   "example.com/some/python@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+			}
+			h.AssertNil(t, registryCache.Register(bp))
+
+			index, err := registry.IndexPath(registryDir, "example", "python")
+			h.AssertNil(t, err)
+			_, err = os.Stat(index)
+			h.AssertNil(t, err)
+
+			located, err := registryCache.LocateBuildpack("example/python")
+			h.AssertNil(t, err)
+			h.AssertEq(t, located, bp)
+		})
+
+		it("creates the directory", func() {
+			newCache, err := registry.NewDirRegistryCache(logging.NewLogWithWriters(&outBuf, &outBuf), filepath.Join(registryDir, "new"))
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, newCache.Register(registry.Buildpack{Namespace: "example", Name: "go", Version: "1.0.0"}))
+			entries, err := newCache.Search("go")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 1)
+		})
+	})
+
+	when("#Yank", func() {
+		it("marks the version as yanked in the directory", func() {
+			h.AssertNil(t, registryCache.Yank(registry.Buildpack{Namespace: "example", Name: "foo", Version: "1.2.0", Yanked: true}))
+
+			bp, err := registryCache.LocateBuildpack("example/foo@^1.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.1.0")
+		})
+	})
+}
@@ -37,7 +37,7 @@ func testGit(t *testing.T, when spec.G, it spec.S) {
 		tmpDir, err = os.MkdirTemp("", "registry")
 		h.AssertNil(t, err)
 
-		registryFixture = h.CreateRegistryFixture(t, tmpDir, filepath.Join("..", "..", "testdata", "registry"))
+		registryFixture = h.CreateBareRegistryFixture(t, tmpDir, filepath.Join("..", "..", "testdata", "registry"))
 		registryCache, err = registry.NewRegistryCache(logger, tmpDir, registryFixture)
 		h.AssertNil(t, err)
 	})
@@ -74,6 +74,7 @@ func testGit(t *testing.T, when spec.G, it spec.S) {
 				h.AssertNil(t, err)
 
 				h.AssertEq(t, commit.Message, "ADD example/python@1.0.0")
+				h.AssertGitHeadEq(t, registryFixture, registryCache.Root)
 			})
 		})
 
@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Register adds a buildpack to a registry of type 'oci' or 'dir', whose index is updated directly rather than through
+// a pull request or a git commit
+func (r *Cache) Register(b Buildpack) error {
+	return r.updateIndex(b, func(entry Entry) (Entry, error) {
+		for _, bp := range entry.Buildpacks {
+			if bp.Version == b.Version {
+				return Entry{}, errors.Errorf("buildpack %s is already registered", style.Symbol(b.Namespace+"/"+b.Name+"@"+b.Version))
+			}
+		}
+		entry.Buildpacks = append(entry.Buildpacks, b)
+		return entry, nil
+	})
+}
+
+// Yank marks the version of a buildpack in a registry of type 'oci' or 'dir' as yanked, or not yanked
+func (r *Cache) Yank(b Buildpack) error {
+	return r.updateIndex(b, func(entry Entry) (Entry, error) {
+		for i, bp := range entry.Buildpacks {
+			if bp.Version == b.Version {
+				entry.Buildpacks[i].Yanked = b.Yanked
+				return entry, nil
+			}
+		}
+		return Entry{}, errors.Errorf("could not find version for buildpack: %s", b.Namespace+"/"+b.Name+"@"+b.Version)
+	})
+}
+
+func (r *Cache) updateIndex(b Buildpack, update func(Entry) (Entry, error)) error {
+	switch {
+	case r.index != nil:
+		if err := r.pullIndex(); err != nil {
+			return err
+		}
+	case r.dir:
+		if err := os.MkdirAll(r.Root, 0750); err != nil {
+			return errors.Wrapf(err, "creating registry directory %s", style.Symbol(r.Root))
+		}
+	default:
+		return errors.New("only registries of type 'oci' or 'dir' can be updated directly")
+	}
+
+	entry, err := r.readEntry(b.Namespace, b.Name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if entry, err = update(entry); err != nil {
+		return err
+	}
+	if err := r.replaceEntry(b.Namespace, b.Name, entry); err != nil {
+		return err
+	}
+
+	if r.index != nil {
+		return r.pushIndex()
+	}
+	return nil
+}
+
+// replaceEntry writes every version of a buildpack to its index file
+func (r *Cache) replaceEntry(ns, name string, entry Entry) error {
+	index, err := IndexPath(r.Root, ns, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(index), 0750); err != nil {
+		return errors.Wrapf(err, "creating directory structure for: %s/%s", ns, name)
+	}
+
+	var buf bytes.Buffer
+	for _, bp := range entry.Buildpacks {
+		line, err := json.Marshal(bp)
+		if err != nil {
+			return errors.Wrapf(err, "converting buildpack file to json: %s/%s", ns, name)
+		}
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+	return errors.Wrapf(os.WriteFile(index, buf.Bytes(), 0644), "writing buildpack to file: %s/%s", ns, name)
+}
@@ -0,0 +1,210 @@
+package registry
+
+import (
+	"archive/tar"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	// IndexConfigMediaType is the media type of the config of the OCI artifact holding a registry index
+	IndexConfigMediaType types.MediaType = "application/vnd.buildpacks.registry.index.config.v1+json"
+	// IndexLayerMediaType is the media type of the single layer of the OCI artifact holding a registry index, which is
+	// a tar of the index files laid out as in a git registry
+	IndexLayerMediaType types.MediaType = "application/vnd.buildpacks.registry.index.v1.tar"
+
+	// indexDigestFile records the digest of the index artifact a cache was extracted from
+	indexDigestFile = ".digest"
+)
+
+// NewOCIRegistryCache creates a registry cache for an index stored as an OCI artifact in a container registry
+func NewOCIRegistryCache(logger logging.Logger, home, indexRef string, keychain authn.Keychain) (Cache, error) {
+	if _, err := os.Stat(home); err != nil {
+		return Cache{}, errors.Wrapf(err, "finding home %s", home)
+	}
+
+	ref, err := name.ParseReference(indexRef, name.WeakValidation)
+	if err != nil {
+		return Cache{}, errors.Wrapf(err, "parsing registry index reference %s", indexRef)
+	}
+
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+
+	key := sha256.New()
+	key.Write([]byte(ref.Name()))
+	cacheDir := fmt.Sprintf("%s-%s", defaultRegistryDir, hex.EncodeToString(key.Sum(nil)))
+
+	return Cache{
+		logger:   logger,
+		index:    ref,
+		keychain: keychain,
+		Root:     filepath.Join(home, cacheDir),
+	}, nil
+}
+
+// pullIndex refreshes the cache from the index artifact, unless it was already extracted from the same artifact. A
+// registry without an index artifact is empty.
+func (r *Cache) pullIndex() error {
+	r.logger.Debugf("Refreshing registry cache for %s", r.index.Name())
+
+	desc, err := remote.Head(r.index, remote.WithAuthFromKeychain(r.keychain))
+	if err != nil {
+		if !isNotFound(err) {
+			return errors.Wrapf(err, "fetching registry index %s", style.Symbol(r.index.Name()))
+		}
+		r.indexDigest = ""
+		return r.resetRoot(nil)
+	}
+
+	r.indexDigest = desc.Digest.String()
+	if current, err := os.ReadFile(filepath.Join(r.Root, indexDigestFile)); err == nil && string(current) == r.indexDigest {
+		return nil
+	}
+
+	img, err := remote.Image(r.index.Context().Digest(r.indexDigest), remote.WithAuthFromKeychain(r.keychain))
+	if err != nil {
+		return errors.Wrapf(err, "fetching registry index %s", style.Symbol(r.index.Name()))
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return errors.Wrapf(err, "reading registry index %s", style.Symbol(r.index.Name()))
+	}
+	if len(layers) != 1 {
+		return errors.Errorf("registry index %s must have exactly one layer, found %d", style.Symbol(r.index.Name()), len(layers))
+	}
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return errors.Wrapf(err, "reading registry index %s", style.Symbol(r.index.Name()))
+	}
+	defer rc.Close()
+
+	return r.resetRoot(rc)
+}
+
+// resetRoot replaces the content of the cache with the index files in the given tar
+func (r *Cache) resetRoot(indexTar io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(r.Root), 0750); err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp(filepath.Dir(r.Root), "registry")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if indexTar != nil {
+		if err := extractIndex(indexTar, dir); err != nil {
+			return errors.Wrapf(err, "extracting registry index %s", style.Symbol(r.index.Name()))
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, indexDigestFile), []byte(r.indexDigest), 0644); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(r.Root); err != nil {
+		return errors.Wrap(err, "resetting registry cache")
+	}
+	return os.Rename(dir, r.Root)
+}
+
+// pushIndex pushes the cache as the index artifact. It fails rather than overwriting changes pushed by someone else
+// since the cache was refreshed.
+func (r *Cache) pushIndex() error {
+	desc, err := remote.Head(r.index, remote.WithAuthFromKeychain(r.keychain))
+	switch {
+	case err != nil && !isNotFound(err):
+		return errors.Wrapf(err, "fetching registry index %s", style.Symbol(r.index.Name()))
+	case (err == nil && desc.Digest.String() != r.indexDigest) || (err != nil && r.indexDigest != ""):
+		return errors.Errorf("registry index %s changed while it was being updated, please try again", style.Symbol(r.index.Name()))
+	}
+
+	indexTar, err := io.ReadAll(archive.ReadDirAsTar(r.Root, "", 0, 0, -1, true, false, func(path string) bool {
+		return !strings.HasPrefix(path, ".")
+	}))
+	if err != nil {
+		return errors.Wrap(err, "archiving registry cache")
+	}
+
+	img := mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), IndexConfigMediaType)
+	img, err = mutate.AppendLayers(img, static.NewLayer(indexTar, IndexLayerMediaType))
+	if err != nil {
+		return errors.Wrap(err, "creating registry index")
+	}
+	if err := remote.Write(r.index, img, remote.WithAuthFromKeychain(r.keychain)); err != nil {
+		return errors.Wrapf(err, "pushing registry index %s", style.Symbol(r.index.Name()))
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return err
+	}
+	r.indexDigest = digest.String()
+	return os.WriteFile(filepath.Join(r.Root, indexDigestFile), []byte(r.indexDigest), 0644)
+}
+
+// extractIndex extracts the files of an index from a tar
+func extractIndex(indexTar io.Reader, dir string) error {
+	tr := tar.NewReader(bufio.NewReader(indexTar))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			return errors.Errorf("invalid path %s in registry index", style.Symbol(header.Name))
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func isNotFound(err error) bool {
+	var transportErr *transport.Error
+	return errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound
+}
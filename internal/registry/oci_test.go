@@ -0,0 +1,154 @@
+package registry_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOCIRegistryCache(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "OCIRegistryCache", testOCIRegistryCache, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testOCIRegistryCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server   *httptest.Server
+		indexRef string
+		outBuf   bytes.Buffer
+		logger   logging.Logger
+
+		nodeBuildpack = registry.Buildpack{
+			Namespace: "example",
+			Name:      "node",
+			Version:   "1.0.0",
+			Address:   "example.com/some/node@sha256:d1f4e39a05a3bfaafd8c68fbaf19f39584cae11d8de30bd3ac40c006fb15ae09",
+		}
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+		indexRef = strings.TrimPrefix(server.URL, "http://") + "/buildpacks/index:latest"
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	// newCache creates a cache for the index with its own home, as if on another machine
+	newCache := func() registry.Cache {
+		registryCache, err := registry.NewOCIRegistryCache(logger, t.TempDir(), indexRef, nil)
+		h.AssertNil(t, err)
+		return registryCache
+	}
+
+	when("the index doesn't exist yet", func() {
+		it("is empty", func() {
+			registryCache := newCache()
+			entries, err := registryCache.Search("")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+	})
+
+	when("#Register", func() {
+		it("pushes the buildpack to the index", func() {
+			registryCache := newCache()
+			h.AssertNil(t, registryCache.Register(nodeBuildpack))
+
+			other := newCache()
+			bp, err := other.LocateBuildpack("example/node")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, nodeBuildpack)
+		})
+
+		it("adds versions to the buildpack", func() {
+			registryCache := newCache()
+			h.AssertNil(t, registryCache.Register(nodeBuildpack))
+
+			newer := nodeBuildpack
+			newer.Version = "1.1.0"
+			other := newCache()
+			h.AssertNil(t, other.Register(newer))
+
+			entries, err := registryCache.Search("node")
+			h.AssertNil(t, err)
+			h.AssertEq(t, entries, []registry.Entry{{Buildpacks: []registry.Buildpack{nodeBuildpack, newer}}})
+		})
+
+		it("errors when the version is already registered", func() {
+			registryCache := newCache()
+			h.AssertNil(t, registryCache.Register(nodeBuildpack))
+
+			h.AssertError(t, registryCache.Register(nodeBuildpack), "buildpack 'example/node@1.0.0' is already registered")
+		})
+
+		it("refreshes the index before updating it", func() {
+			registryCache := newCache()
+			h.AssertNil(t, registryCache.Refresh())
+
+			other := newCache()
+			h.AssertNil(t, other.Register(nodeBuildpack))
+
+			newer := nodeBuildpack
+			newer.Version = "1.1.0"
+			h.AssertNil(t, registryCache.Register(newer))
+
+			entries, err := other.Search("node")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries[0].Buildpacks), 2)
+		})
+	})
+
+	when("#Yank", func() {
+		it.Before(func() {
+			registryCache := newCache()
+			h.AssertNil(t, registryCache.Register(nodeBuildpack))
+		})
+
+		it("marks the version as yanked", func() {
+			yanked := nodeBuildpack
+			yanked.Yanked = true
+			other := newCache()
+			h.AssertNil(t, other.Yank(yanked))
+
+			registryCache := newCache()
+			_, err := registryCache.LocateBuildpack("example/node@^1.0")
+			h.AssertError(t, err, "could not find a version in range '^1.0' for buildpack: example/node@^1.0")
+
+			h.AssertNil(t, registryCache.Yank(nodeBuildpack))
+			bp, err := other.LocateBuildpack("example/node@^1.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.0.0")
+		})
+
+		it("errors when the version isn't registered", func() {
+			missing := nodeBuildpack
+			missing.Version = "2.0.0"
+			registryCache := newCache()
+			h.AssertError(t, registryCache.Yank(missing), "could not find version for buildpack: example/node@2.0.0")
+		})
+	})
+
+	when("the cache isn't for an OCI index", func() {
+		it("can't be updated directly", func() {
+			registryCache, err := registry.NewRegistryCache(logger, t.TempDir(), "https://github.com/buildpacks/registry-index")
+			h.AssertNil(t, err)
+			h.AssertError(t, registryCache.Register(nodeBuildpack), "only registries of type 'oci' or 'dir' can be updated directly")
+		})
+	})
+}
@@ -6,15 +6,20 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
+	mastermindsSemver "github.com/Masterminds/semver"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 
@@ -33,6 +38,15 @@ type Cache struct {
 	url         *url.URL
 	Root        string
 	RegistryDir string
+
+	// index is the reference of the OCI artifact holding the index of registries of type 'oci', which aren't git
+	// repositories
+	index       name.Reference
+	keychain    authn.Keychain
+	indexDigest string
+
+	// dir is true for registries of type 'dir', whose index is a local directory used in place
+	dir bool
 }
 
 const GithubIssueTitleTemplate = "{{ if .Yanked }}YANK{{ else }}ADD{{ end }} {{.Namespace}}/{{.Name}}@{{.Version}}"
@@ -82,6 +96,11 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 		return Buildpack{}, errors.Wrap(err, "refreshing cache")
 	}
 
+	return r.LocateCachedBuildpack(bp)
+}
+
+// LocateCachedBuildpack locates a buildpack in the registry cache as it was last refreshed, without reaching the registry
+func (r *Cache) LocateCachedBuildpack(bp string) (Buildpack, error) {
 	ns, name, version, err := buildpack.ParseRegistryID(bp)
 	if err != nil {
 		return Buildpack{}, errors.Wrap(err, "parsing buildpacks registry id")
@@ -105,6 +124,10 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 			return highestVersion, Validate(highestVersion)
 		}
 
+		if buildpack.IsVersionRange(version) {
+			return r.locateVersionInRange(bp, entry, version)
+		}
+
 		for _, bpIndex := range entry.Buildpacks {
 			if bpIndex.Version == version {
 				return bpIndex, Validate(bpIndex)
@@ -116,14 +139,105 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 	return Buildpack{}, fmt.Errorf("no entries for buildpack: %s", bp)
 }
 
+// locateVersionInRange returns the highest version of the buildpack that is in the given range and wasn't yanked
+func (r *Cache) locateVersionInRange(bp string, entry Entry, versionRange string) (Buildpack, error) {
+	constraints, err := buildpack.ParseVersionRange(versionRange)
+	if err != nil {
+		return Buildpack{}, err
+	}
+
+	var (
+		highest        Buildpack
+		highestVersion *mastermindsSemver.Version
+	)
+	for _, bpIndex := range entry.Buildpacks {
+		if bpIndex.Yanked {
+			continue
+		}
+		v, err := mastermindsSemver.NewVersion(bpIndex.Version)
+		if err != nil || !constraints.Check(v) {
+			continue
+		}
+		if highestVersion == nil || v.GreaterThan(highestVersion) {
+			highest, highestVersion = bpIndex, v
+		}
+	}
+	if highestVersion == nil {
+		return Buildpack{}, fmt.Errorf("could not find a version in range %s for buildpack: %s", style.Symbol(versionRange), bp)
+	}
+
+	r.logger.Infof("Resolved buildpack %s to version %s", style.Symbol(bp), style.Symbol(highest.Version))
+	return highest, Validate(highest)
+}
+
+// Search the registry for buildpacks whose namespace or name contains the given term, ignoring case. An empty term
+// matches every buildpack. Entries are returned sorted by namespace and name.
+func (r *Cache) Search(term string) ([]Entry, error) {
+	if err := r.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+
+	term = strings.ToLower(term)
+	var entries []Entry
+	err := filepath.WalkDir(r.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ns, name, found := strings.Cut(d.Name(), "_")
+		if !found || !strings.Contains(strings.ToLower(ns+"/"+name), term) {
+			return nil
+		}
+		// skip files that aren't where the index of the buildpack would be, such as a README
+		if index, err := IndexPath(r.Root, ns, name); err != nil || index != path {
+			return nil
+		}
+
+		entry, err := r.readEntry(ns, name)
+		if err != nil {
+			return err
+		}
+		if len(entry.Buildpacks) > 0 {
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "searching registry cache")
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].Buildpacks[0], entries[j].Buildpacks[0]
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+	return entries, nil
+}
+
 // Refresh local Registry Cache
 func (r *Cache) Refresh() error {
+	if r.index != nil {
+		return r.pullIndex()
+	}
+	if r.dir {
+		return r.validateDir()
+	}
+
 	r.logger.Debugf("Refreshing registry cache for %s/%s", r.url.Host, r.url.Path)
 
 	if err := r.Initialize(); err != nil {
 		return errors.Wrapf(err, "initializing (%s)", r.Root)
 	}
 
+	if isAzureDevOps(r.url) {
+		return r.pullWithGit()
+	}
+
 	repository, err := git.PlainOpen(r.Root)
 	if err != nil {
 		return errors.Wrapf(err, "opening (%s)", r.Root)
@@ -134,15 +248,30 @@ func (r *Cache) Refresh() error {
 		return errors.Wrapf(err, "reading (%s)", r.Root)
 	}
 
-	err = w.Pull(&git.PullOptions{RemoteName: "origin"})
+	auth, err := gitAuth(r.url.String())
+	if err != nil {
+		return err
+	}
+
+	err = w.Pull(&git.PullOptions{RemoteName: "origin", Auth: auth})
 	if err == git.NoErrAlreadyUpToDate {
 		return nil
 	}
-	return err
+	if err != nil {
+		return errors.Wrap(&GitError{Op: "pull", URL: r.url.String(), Err: err}, "pulling remote registry")
+	}
+	return nil
 }
 
 // Initialize a local Registry Cache
 func (r *Cache) Initialize() error {
+	if r.index != nil {
+		return r.pullIndex()
+	}
+	if r.dir {
+		return r.validateDir()
+	}
+
 	_, err := os.Stat(r.Root)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -154,19 +283,22 @@ func (r *Cache) Initialize() error {
 	}
 
 	if err := r.validateCache(); err != nil {
-		err = os.RemoveAll(r.Root)
-		if err != nil {
-			return errors.Wrap(err, "resetting registry cache")
-		}
-		err = r.CreateCache()
-		if err != nil {
-			return errors.Wrap(err, "rebuilding registry cache")
-		}
+		return r.recreateCache()
 	}
 
 	return nil
 }
 
+func (r *Cache) recreateCache() error {
+	if err := os.RemoveAll(r.Root); err != nil {
+		return errors.Wrap(err, "resetting registry cache")
+	}
+	if err := r.CreateCache(); err != nil {
+		return errors.Wrap(err, "rebuilding registry cache")
+	}
+	return nil
+}
+
 // CreateCache creates the cache on the filesystem
 func (r *Cache) CreateCache() error {
 	var repository *git.Repository
@@ -179,23 +311,25 @@ func (r *Cache) CreateCache() error {
 
 	r.RegistryDir = registryDir
 
-	if r.url.Host == "dev.azure.com" {
-		err = exec.Command("git", "clone", r.url.String(), r.RegistryDir).Run()
-		if err != nil {
-			return errors.Wrap(err, "cloning remote registry with native git")
-		}
+	auth, err := gitAuth(r.url.String())
+	if err != nil {
+		return err
+	}
 
-		repository, err = git.PlainOpen(r.RegistryDir)
-		if err != nil {
-			return errors.Wrap(err, "opening remote registry clone")
-		}
-	} else {
+	clone := func() error {
 		repository, err = git.PlainClone(r.RegistryDir, false, &git.CloneOptions{
-			URL: r.url.String(),
+			URL:  r.url.String(),
+			Auth: auth,
 		})
-		if err != nil {
-			return errors.Wrap(err, "cloning remote registry")
-		}
+		return err
+	}
+	if isAzureDevOps(r.url) {
+		err = withMultiAck(clone)
+	} else {
+		err = clone()
+	}
+	if err != nil {
+		return errors.Wrap(&GitError{Op: "clone", URL: r.url.String(), Err: err}, "cloning remote registry")
 	}
 
 	w, err := repository.Worktree()
@@ -274,9 +408,25 @@ func (r *Cache) Commit(b Buildpack, username, msg string) error {
 			When:  time.Now(),
 		},
 	}); err != nil {
-		return errors.Wrapf(err, "committing")
+		return errors.Wrap(&GitError{Op: "commit", URL: r.url.String(), Err: err}, "committing")
+	}
+
+	return r.push(repository)
+}
+
+// push the commits of the registry cache to the remote registry
+func (r *Cache) push(repository *git.Repository) error {
+	r.logger.Debugf("Pushing registry cache to %s/%s", r.url.Host, r.url.Path)
+
+	auth, err := gitAuth(r.url.String())
+	if err != nil {
+		return err
 	}
 
+	err = repository.Push(&git.PushOptions{RemoteName: "origin", Auth: auth})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return errors.Wrap(&GitError{Op: "push", URL: r.url.String(), Err: err}, "pushing")
+	}
 	return nil
 }
 
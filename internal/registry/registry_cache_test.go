@@ -2,6 +2,7 @@ package registry
 
 import (
 	"bytes"
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -89,6 +90,48 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#Search", func() {
+		var registryCache Cache
+
+		it.Before(func() {
+			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+		})
+
+		ids := func(entries []Entry) []string {
+			var ids []string
+			for _, entry := range entries {
+				ids = append(ids, entry.Buildpacks[0].Namespace+"/"+entry.Buildpacks[0].Name)
+			}
+			return ids
+		}
+
+		it("returns every buildpack for an empty term", func() {
+			entries, err := registryCache.Search("")
+			h.AssertNil(t, err)
+			h.AssertEq(t, ids(entries), []string{"example/foo", "example/java", "example/node"})
+		})
+
+		it("matches the term on the name, ignoring case", func() {
+			entries, err := registryCache.Search("NOD")
+			h.AssertNil(t, err)
+			h.AssertEq(t, ids(entries), []string{"example/node"})
+			h.AssertEq(t, len(entries[0].Buildpacks), 6)
+		})
+
+		it("matches the term on the namespace", func() {
+			entries, err := registryCache.Search("example/j")
+			h.AssertNil(t, err)
+			h.AssertEq(t, ids(entries), []string{"example/java"})
+		})
+
+		it("returns nothing when no buildpack matches", func() {
+			entries, err := registryCache.Search("python")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+	})
+
 	when("#LocateBuildpack", func() {
 		var (
 			registryCache Cache
@@ -148,6 +191,62 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			_, err := registryCache.LocateBuildpack("example/foo@3.5.6")
 			h.AssertError(t, err, "could not find version")
 		})
+
+		when("the version is a range", func() {
+			for _, tc := range []struct {
+				versionRange string
+				expected     string
+			}{
+				{versionRange: "^1.4", expected: "1.4.2"},
+				{versionRange: "~2.1.0", expected: "2.1.3"},
+				{versionRange: ">=1.2 <2", expected: "1.4.2"},
+				{versionRange: ">= 1.3 < 1.4.2", expected: "1.4.0"},
+				{versionRange: "^1.3 || ^2", expected: "2.1.3"},
+			} {
+				it(fmt.Sprintf("locates the highest version in %s", tc.versionRange), func() {
+					bp, err := registryCache.LocateBuildpack("urn:cnb:registry:example/node@" + tc.versionRange)
+					h.AssertNil(t, err)
+
+					h.AssertEq(t, bp.Version, tc.expected)
+					h.AssertContains(t, outBuf.String(), fmt.Sprintf("to version '%s'", tc.expected))
+				})
+			}
+
+			it("skips yanked versions", func() {
+				bp, err := registryCache.LocateBuildpack("example/node@~1.5")
+				h.AssertError(t, err, "could not find a version in range '~1.5' for buildpack: example/node@~1.5")
+				h.AssertEq(t, bp, Buildpack{})
+			})
+
+			it("returns error if the range is invalid", func() {
+				_, err := registryCache.LocateBuildpack("example/node@>=")
+				h.AssertError(t, err, "could not find version")
+			})
+		})
+	})
+
+	when("#LocateCachedBuildpack", func() {
+		var (
+			registryCache Cache
+		)
+
+		it.Before(func() {
+			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+		})
+
+		it("locates a buildpack in the cache as last refreshed", func() {
+			h.AssertNil(t, registryCache.Refresh())
+
+			bp, err := registryCache.LocateCachedBuildpack("example/foo@1.1.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.1.0")
+		})
+
+		it("returns error if the cache was never refreshed", func() {
+			_, err := registryCache.LocateCachedBuildpack("example/foo")
+			h.AssertError(t, err, "reading entry")
+		})
 	})
 
 	when("#Refresh", func() {
@@ -252,6 +351,7 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 		)
 
 		it.Before(func() {
+			registryFixture = h.CreateBareRegistryFixture(t, t.TempDir(), filepath.Join("..", "..", "testdata", "registry"))
 			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
 			h.AssertNil(t, err)
 
@@ -263,6 +363,11 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			it("creates a file and a commit", func() {
 				h.AssertNil(t, registryCache.Commit(bp, username, msg))
 			})
+
+			it("pushes the commit to the remote registry", func() {
+				h.AssertNil(t, registryCache.Commit(bp, username, msg))
+				h.AssertGitHeadEq(t, registryFixture, registryCache.Root)
+			})
 		})
 
 		when("empty commit message is passed", func() {
@@ -0,0 +1,146 @@
+// Package attestation creates in-toto attestations of app images, such as their SBOMs and SLSA provenance, signed in
+// DSSE envelopes and attached to the images as OCI referrers.
+package attestation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/signature"
+)
+
+const (
+	// StatementType is the type of in-toto statements
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PayloadType is the type of the payload of DSSE envelopes holding in-toto statements
+	PayloadType = "application/vnd.in-toto+json"
+	// ArtifactType is the artifact type of the referrers holding the attestations of an image
+	ArtifactType = "application/vnd.in-toto+json"
+	// EnvelopeMediaType is the media type of the layers of an attestation referrer, each holding a DSSE envelope
+	EnvelopeMediaType = "application/vnd.dsse.envelope.v1+json"
+	// PredicateTypeAnnotation is the annotation of an attestation layer holding the predicate type of its statement
+	PredicateTypeAnnotation = "in-toto.io/predicate-type"
+	// TitleAnnotation is the annotation of an attestation layer naming what it attests, such as the path of an SBOM
+	TitleAnnotation = "org.opencontainers.image.title"
+)
+
+// Statement is an in-toto statement about the subjects
+type Statement struct {
+	Type          string      `json:"_type"`
+	Subject       []Subject   `json:"subject"`
+	PredicateType string      `json:"predicateType"`
+	Predicate     interface{} `json:"predicate"`
+}
+
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// NewStatement returns a statement about an image, identified by its name and the digest of its manifest
+func NewStatement(imageName string, digest v1.Hash, predicateType string, predicate interface{}) Statement {
+	return Statement{
+		Type:          StatementType,
+		Subject:       []Subject{{Name: imageName, Digest: map[string]string{digest.Algorithm: digest.Hex}}},
+		PredicateType: predicateType,
+		Predicate:     predicate,
+	}
+}
+
+// Envelope is a DSSE envelope
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Sign returns a DSSE envelope holding the statement, signed with key
+func Sign(statement Statement, key signature.PrivateKey) (Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Envelope{}, err
+	}
+	sig, err := key.Sign(PAE(PayloadType, payload))
+	if err != nil {
+		return Envelope{}, errors.Wrapf(err, "signing with %s", style.Symbol(key.Path))
+	}
+	return Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}, nil
+}
+
+// Verify checks that the envelope is signed by key, and returns its statement
+func (e Envelope) Verify(key signature.PublicKey) (Statement, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return Statement{}, errors.Wrap(err, "decoding payload")
+	}
+
+	for _, s := range e.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if key.Verify(PAE(e.PayloadType, payload), sig) {
+			var statement Statement
+			if err := json.Unmarshal(payload, &statement); err != nil {
+				return Statement{}, errors.Wrap(err, "parsing statement")
+			}
+			return statement, nil
+		}
+	}
+	return Statement{}, errors.Errorf("envelope is not signed by %s", style.Symbol(key.Path))
+}
+
+// PAE is the pre-authentication encoding of a DSSE payload, which is what is signed
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Attestation is a signed statement, along with what it attests
+type Attestation struct {
+	// Title names what the statement attests, such as the path of an SBOM in the SBOM layer
+	Title    string
+	Envelope Envelope
+	// PredicateType of the statement in the envelope
+	PredicateType string
+}
+
+// NewImage returns a referrer of subject holding the attestations, one in each layer
+func NewImage(subject v1.Descriptor, attestations ...Attestation) (v1.Image, error) {
+	img := empty.Image
+	for _, a := range attestations {
+		contents, err := json.Marshal(a.Envelope)
+		if err != nil {
+			return nil, err
+		}
+		annotations := map[string]string{PredicateTypeAnnotation: a.PredicateType}
+		if a.Title != "" {
+			annotations[TitleAnnotation] = a.Title
+		}
+
+		img, err = mutate.Append(img, mutate.Addendum{
+			Layer:       static.NewLayer(contents, EnvelopeMediaType),
+			Annotations: annotations,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return signature.NewReferrer(img, ArtifactType, subject), nil
+}
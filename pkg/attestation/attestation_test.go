@@ -0,0 +1,129 @@
+package attestation_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/attestation"
+	"github.com/buildpacks/pack/pkg/signature"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAttestation(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Attestation", testAttestation, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAttestation(t *testing.T, when spec.G, it spec.S) {
+	var key signature.PrivateKey
+
+	it.Before(func() {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		key = signature.PrivateKey{Path: "cosign.key", Key: ecKey}
+	})
+
+	when("#Sign", func() {
+		it("signs the statement in a DSSE envelope", func() {
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			digest, err := img.Digest()
+			h.AssertNil(t, err)
+
+			statement := attestation.NewStatement("registry.example.com/app", digest, attestation.ProvenancePredicateType, map[string]string{"some": "predicate"})
+			envelope, err := attestation.Sign(statement, key)
+			h.AssertNil(t, err)
+			h.AssertEq(t, envelope.PayloadType, attestation.PayloadType)
+
+			verified, err := envelope.Verify(key.PublicKey())
+			h.AssertNil(t, err)
+			h.AssertEq(t, verified.Type, attestation.StatementType)
+			h.AssertEq(t, verified.PredicateType, attestation.ProvenancePredicateType)
+			h.AssertEq(t, verified.Subject[0].Name, "registry.example.com/app")
+			h.AssertEq(t, verified.Subject[0].Digest["sha256"], digest.Hex)
+		})
+
+		it("fails verification with another key", func() {
+			envelope, err := attestation.Sign(attestation.Statement{Type: attestation.StatementType}, key)
+			h.AssertNil(t, err)
+
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+			_, err = envelope.Verify(signature.PublicKey{Path: "other.pub", Key: &otherKey.PublicKey})
+			h.AssertError(t, err, "envelope is not signed by 'other.pub'")
+		})
+	})
+
+	when("#PAE", func() {
+		it("encodes the payload type and payload with their lengths", func() {
+			h.AssertEq(t, string(attestation.PAE("http://example.com/HelloWorld", []byte("hello world"))), "DSSEv1 29 http://example.com/HelloWorld 11 hello world")
+		})
+	})
+
+	when("#NewImage", func() {
+		it("holds each attestation in a layer of a referrer of the subject", func() {
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			subject, err := partial.Descriptor(img)
+			h.AssertNil(t, err)
+
+			envelope, err := attestation.Sign(attestation.NewStatement("app", subject.Digest, attestation.CycloneDXPredicateType, map[string]string{}), key)
+			h.AssertNil(t, err)
+			referrer, err := attestation.NewImage(*subject, attestation.Attestation{
+				Title:         "/layers/sbom/launch/some-buildpack/sbom.cdx.json",
+				Envelope:      envelope,
+				PredicateType: attestation.CycloneDXPredicateType,
+			})
+			h.AssertNil(t, err)
+
+			manifest, err := referrer.Manifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, manifest.Subject.Digest, subject.Digest)
+			h.AssertEq(t, string(manifest.Config.MediaType), attestation.ArtifactType)
+			h.AssertEq(t, len(manifest.Layers), 1)
+			h.AssertEq(t, string(manifest.Layers[0].MediaType), attestation.EnvelopeMediaType)
+			h.AssertEq(t, manifest.Layers[0].Annotations[attestation.PredicateTypeAnnotation], attestation.CycloneDXPredicateType)
+			h.AssertEq(t, manifest.Layers[0].Annotations[attestation.TitleAnnotation], "/layers/sbom/launch/some-buildpack/sbom.cdx.json")
+
+			layers, err := referrer.Layers()
+			h.AssertNil(t, err)
+			rc, err := layers[0].Compressed()
+			h.AssertNil(t, err)
+			defer rc.Close()
+			contents, err := io.ReadAll(rc)
+			h.AssertNil(t, err)
+			var layerEnvelope attestation.Envelope
+			h.AssertNil(t, json.Unmarshal(contents, &layerEnvelope))
+			_, err = layerEnvelope.Verify(key.PublicKey())
+			h.AssertNil(t, err)
+		})
+	})
+
+	when("#SBOMPredicateType", func() {
+		it("maps the SBOM formats of the lifecycle to predicate types", func() {
+			for path, expected := range map[string]string{
+				"layers/sbom/launch/bp/sbom.cdx.json":  attestation.CycloneDXPredicateType,
+				"layers/sbom/launch/bp/sbom.spdx.json": attestation.SPDXPredicateType,
+				"layers/sbom/launch/bp/sbom.syft.json": attestation.SyftPredicateType,
+			} {
+				predicateType, ok := attestation.SBOMPredicateType(path)
+				h.AssertTrue(t, ok)
+				h.AssertEq(t, predicateType, expected)
+			}
+
+			_, ok := attestation.SBOMPredicateType("layers/sbom/launch/bp/sbom.toml")
+			h.AssertFalse(t, ok)
+		})
+	})
+}
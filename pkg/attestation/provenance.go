@@ -0,0 +1,76 @@
+package attestation
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// ProvenancePredicateType is the predicate type of SLSA provenance statements
+	ProvenancePredicateType = "https://slsa.dev/provenance/v1"
+	// BuildType identifies builds run by pack in provenance statements
+	BuildType = "https://buildpacks.io/pack/build/v1"
+
+	CycloneDXPredicateType = "https://cyclonedx.org/bom"
+	SPDXPredicateType      = "https://spdx.dev/Document"
+	SyftPredicateType      = "https://syft.dev/bom"
+)
+
+// Provenance is a SLSA provenance predicate
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string                 `json:"buildType"`
+	ExternalParameters   map[string]interface{} `json:"externalParameters"`
+	InternalParameters   map[string]interface{} `json:"internalParameters,omitempty"`
+	ResolvedDependencies []ResourceDescriptor   `json:"resolvedDependencies,omitempty"`
+}
+
+type RunDetails struct {
+	Builder  Builder       `json:"builder"`
+	Metadata BuildMetadata `json:"metadata"`
+}
+
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type BuildMetadata struct {
+	StartedOn  *time.Time `json:"startedOn,omitempty"`
+	FinishedOn *time.Time `json:"finishedOn,omitempty"`
+}
+
+// ResourceDescriptor describes an artifact a build depended on
+type ResourceDescriptor struct {
+	Name        string                 `json:"name,omitempty"`
+	URI         string                 `json:"uri,omitempty"`
+	Digest      map[string]string      `json:"digest,omitempty"`
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+}
+
+// DigestSet returns the digest set of a digest in the form '<algorithm>:<hex>'
+func DigestSet(digest string) map[string]string {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return nil
+	}
+	return map[string]string{algorithm: hex}
+}
+
+// SBOMPredicateType returns the predicate type of an SBOM file, from the extension the lifecycle gives it
+func SBOMPredicateType(path string) (string, bool) {
+	switch {
+	case strings.HasSuffix(path, ".cdx.json"):
+		return CycloneDXPredicateType, true
+	case strings.HasSuffix(path, ".spdx.json"):
+		return SPDXPredicateType, true
+	case strings.HasSuffix(path, ".syft.json"):
+		return SyftPredicateType, true
+	default:
+		return "", false
+	}
+}
@@ -3,6 +3,7 @@ package buildpack
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -36,6 +37,12 @@ type Downloader interface {
 	Download(ctx context.Context, pathOrURI string) (blob.Blob, error)
 }
 
+// Verifier checks the provenance of the package images and remote blobs modules are downloaded from
+type Verifier interface {
+	VerifyImage(ctx context.Context, kind, ref string, img imgutil.Image) error
+	VerifyBlob(ctx context.Context, kind, uri string, b blob.Blob) error
+}
+
 //go:generate mockgen -package testmocks -destination ../testmocks/mock_registry_resolver.go github.com/buildpacks/pack/pkg/buildpack RegistryResolver
 
 type RegistryResolver interface {
@@ -80,6 +87,10 @@ type DownloadOptions struct {
 
 	// The OS/Architecture/Variant to download.
 	Target *dist.Target
+
+	// Verifier of the package images and remote blobs, before modules are extracted from them. Nothing is verified
+	// when nil.
+	Verifier Verifier
 }
 
 func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, opts DownloadOptions) (BuildModule, []BuildModule, error) {
@@ -110,7 +121,7 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 			Daemon:     opts.Daemon,
 			PullPolicy: opts.PullPolicy,
 			Target:     opts.Target,
-		})
+		}, opts.Verifier)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from registry %s", style.Symbol(moduleURI))
 		}
@@ -125,7 +136,7 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 			Daemon:     opts.Daemon,
 			PullPolicy: opts.PullPolicy,
 			Target:     opts.Target,
-		})
+		}, opts.Verifier)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from registry %s", style.Symbol(moduleURI))
 		}
@@ -141,6 +152,12 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "downloading %s from %s", kind, style.Symbol(moduleURI))
 		}
+		// modules on the local filesystem are part of the project, and aren't verified
+		if opts.Verifier != nil && !strings.HasPrefix(moduleURI, "file:") {
+			if err := opts.Verifier.VerifyBlob(ctx, kind, moduleURI, blob); err != nil {
+				return nil, nil, err
+			}
+		}
 
 		imageOS := opts.ImageOS
 		if opts.Target != nil {
@@ -203,11 +220,16 @@ func fromOCILayoutBlob(blob blob.Blob, kind string) (mainModule BuildModule, dep
 	return mainModule, depModules, nil
 }
 
-func extractPackaged(ctx context.Context, kind string, pkgImageRef string, fetcher ImageFetcher, fetchOptions image.FetchOptions) (mainModule BuildModule, depModules []BuildModule, err error) {
+func extractPackaged(ctx context.Context, kind string, pkgImageRef string, fetcher ImageFetcher, fetchOptions image.FetchOptions, verifier Verifier) (mainModule BuildModule, depModules []BuildModule, err error) {
 	pkgImage, err := fetcher.Fetch(ctx, pkgImageRef, fetchOptions)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "fetching image")
 	}
+	if verifier != nil {
+		if err := verifier.VerifyImage(ctx, kind, pkgImageRef, pkgImage); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	switch kind {
 	case KindBuildpack:
@@ -155,6 +155,14 @@ func testGetLocatorType(t *testing.T, when spec.G, it spec.S) {
 			locator:      "example/registry-cnb",
 			expectedType: buildpack.RegistryLocator,
 		},
+		{
+			locator:      "example/foo@^1.4",
+			expectedType: buildpack.RegistryLocator,
+		},
+		{
+			locator:      "example/foo@>=1.2 <2",
+			expectedType: buildpack.RegistryLocator,
+		},
 		{
 			locator:      "cnbs/sample-package@hello-universe",
 			expectedType: buildpack.InvalidLocator,
@@ -0,0 +1,75 @@
+package buildpack
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const comparisonOperators = "<>=!~^"
+
+var exactVersionPattern = regexp.MustCompile(`^` + semverPattern + `$`)
+
+// IsVersionRange returns true if the version of a buildpack locator is a range of versions, such as `^1.4`, `~2.1.0`
+// or `>=1.2 <2`, rather than an exact version.
+func IsVersionRange(version string) bool {
+	if version == "" || exactVersionPattern.MatchString(version) {
+		return false
+	}
+	_, err := ParseVersionRange(version)
+	return err == nil
+}
+
+// ParseVersionRange parses a range of versions. Comparisons that must all be satisfied are separated by spaces, and
+// alternatives by `||` (e.g. `>=1.2 <2 || ^3.1`). Commas aren't supported, as they separate the values of the
+// `--buildpack` flag.
+func ParseVersionRange(versionRange string) (*semver.Constraints, error) {
+	if strings.Contains(versionRange, ",") {
+		return nil, errors.Errorf("invalid version range %s, comparisons must be separated by spaces", style.Symbol(versionRange))
+	}
+
+	var alternatives []string
+	for _, alternative := range strings.Split(versionRange, "||") {
+		var comparisons []string
+		operator := ""
+		for _, field := range strings.Fields(alternative) {
+			// an operator may be separated from its version, as in `>= 1.2`
+			if strings.Trim(field, comparisonOperators) == "" {
+				operator += field
+				continue
+			}
+			comparisons = append(comparisons, completeUpperBound(operator+field))
+			operator = ""
+		}
+		if operator != "" || len(comparisons) == 0 {
+			return nil, errors.Errorf("invalid version range %s", style.Symbol(versionRange))
+		}
+		alternatives = append(alternatives, strings.Join(comparisons, ", "))
+	}
+
+	constraints, err := semver.NewConstraint(strings.Join(alternatives, " || "))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid version range %s", style.Symbol(versionRange))
+	}
+	return constraints, nil
+}
+
+// completeUpperBound fills in the missing parts of the version of a `<` comparison, so that `<2` excludes `2.0.0`
+func completeUpperBound(comparison string) string {
+	if !strings.HasPrefix(comparison, "<") || strings.HasPrefix(comparison, "<=") {
+		return comparison
+	}
+
+	version := strings.TrimPrefix(comparison, "<")
+	if strings.ContainsAny(version, "xX*-+") {
+		return comparison
+	}
+	for strings.Count(version, ".") < 2 {
+		version += ".0"
+	}
+	return "<" + version
+}
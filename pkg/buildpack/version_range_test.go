@@ -0,0 +1,71 @@
+package buildpack_test
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/buildpack"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVersionRange(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "VersionRange", testVersionRange, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVersionRange(t *testing.T, when spec.G, it spec.S) {
+	when("#IsVersionRange", func() {
+		it("is true for ranges", func() {
+			for _, version := range []string{"^1.4", "~2.1.0", ">=1.2 <2", "1.x", "^1 || ^2"} {
+				h.AssertTrue(t, buildpack.IsVersionRange(version))
+			}
+		})
+
+		it("is false for exact versions and invalid ranges", func() {
+			for _, version := range []string{"", "1.2.3", "1.2.3-rc.1", "hello-universe", ">="} {
+				h.AssertFalse(t, buildpack.IsVersionRange(version))
+			}
+		})
+	})
+
+	when("#ParseVersionRange", func() {
+		matches := func(versionRange string, version string) bool {
+			constraints, err := buildpack.ParseVersionRange(versionRange)
+			h.AssertNil(t, err)
+			return constraints.Check(semver.MustParse(version))
+		}
+
+		it("requires all comparisons separated by spaces to be satisfied", func() {
+			h.AssertTrue(t, matches(">=1.2 <2", "1.9.9"))
+			h.AssertTrue(t, matches(">= 1.2 < 2", "1.2.0"))
+			h.AssertFalse(t, matches(">=1.2 <2", "1.1.0"))
+		})
+
+		it("excludes the bound of a partial upper bound", func() {
+			h.AssertFalse(t, matches("<2", "2.0.0"))
+			h.AssertFalse(t, matches("<1.4", "1.4.0"))
+			h.AssertTrue(t, matches("<1.4", "1.3.9"))
+		})
+
+		it("supports alternatives", func() {
+			h.AssertTrue(t, matches("^1.4 || ~3.0", "3.0.7"))
+			h.AssertFalse(t, matches("^1.4 || ~3.0", "2.0.0"))
+		})
+
+		it("errors when the range is invalid", func() {
+			_, err := buildpack.ParseVersionRange(">= ")
+			h.AssertError(t, err, "invalid version range '>= '")
+
+			_, err = buildpack.ParseVersionRange("not-a-range")
+			h.AssertError(t, err, "invalid version range 'not-a-range'")
+
+			_, err = buildpack.ParseVersionRange(">=1.2, <2")
+			h.AssertError(t, err, "invalid version range '>=1.2, <2', comparisons must be separated by spaces")
+		})
+	})
+}
@@ -25,6 +25,7 @@ const (
 	CacheVolume Format = iota
 	CacheImage
 	CacheBind
+	CacheLayout
 )
 
 func (f Format) String() string {
@@ -35,6 +36,8 @@ func (f Format) String() string {
 		return "volume"
 	case CacheBind:
 		return "bind"
+	case CacheLayout:
+		return "layout"
 	}
 	return ""
 }
@@ -45,7 +48,7 @@ func (c *CacheInfo) SourceName() string {
 		fallthrough
 	case CacheVolume:
 		return "name"
-	case CacheBind:
+	case CacheBind, CacheLayout:
 		return "source"
 	}
 	return ""
@@ -96,6 +99,8 @@ func (c *CacheOpts) Set(value string) error {
 				cache.Format = CacheVolume
 			case "bind":
 				cache.Format = CacheBind
+			case "layout":
+				cache.Format = CacheLayout
 			default:
 				return errors.Errorf("invalid cache format '%s'", value)
 			}
@@ -140,6 +145,10 @@ func sanitize(c *CacheOpts) error {
 		}
 	}
 
+	if c.Launch.Format == CacheLayout {
+		return errors.New("cache format 'layout' is only supported for the build cache")
+	}
+
 	var (
 		resolvedPath string
 		err          error
@@ -150,6 +159,11 @@ func sanitize(c *CacheOpts) error {
 		}
 		c.Build.Source = filepath.Join(resolvedPath, "build-cache")
 	}
+	if c.Build.Format == CacheLayout {
+		if c.Build.Source, err = filepath.Abs(c.Build.Source); err != nil {
+			return errors.Wrap(err, "resolve absolute path")
+		}
+	}
 	if c.Launch.Format == CacheBind {
 		if resolvedPath, err = filepath.Abs(c.Launch.Source); err != nil {
 			return errors.Wrap(err, "resolve absolute path")
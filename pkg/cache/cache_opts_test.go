@@ -3,6 +3,7 @@ package cache
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -317,4 +318,26 @@ func testCacheOpts(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 	})
+
+	when("layout cache format options are passed", func() {
+		it("with complete options", func() {
+			cwd, err := os.Getwd()
+			h.AssertNil(t, err)
+
+			var cacheFlags CacheOpts
+			h.AssertNil(t, cacheFlags.Set("type=build;format=layout;source=test-layout-cache"))
+			h.AssertEq(t, cacheFlags.Build.Format, CacheLayout)
+			h.AssertEq(t, cacheFlags.String(), fmt.Sprintf("type=build;format=layout;source=%s;type=launch;format=volume;", filepath.Join(cwd, "test-layout-cache")))
+		})
+
+		it("with missing options", func() {
+			var cacheFlags CacheOpts
+			h.AssertError(t, cacheFlags.Set("type=build;format=layout"), "cache 'source' is required")
+		})
+
+		it("for the launch cache", func() {
+			var cacheFlags CacheOpts
+			h.AssertError(t, cacheFlags.Set("type=launch;format=layout;source=test-layout-cache"), "cache format 'layout' is only supported for the build cache")
+		})
+	})
 }
@@ -4,6 +4,7 @@ const (
 	Image Type = iota
 	Volume
 	Bind
+	Layout
 )
 
 type Type int
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/ocilayout"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// LayoutCacheMetadataLabel is the label holding the lifecycle cache metadata, as in cache images
+const LayoutCacheMetadataLabel = "io.buildpacks.lifecycle.cache.metadata"
+
+// LayoutCache stores the build cache as an OCI image layout on the host filesystem, using the same image structure as
+// an image cache. The lifecycle reads and writes the cache through a working directory, which is restored from the
+// layout before the build and saved back to it once the build succeeds.
+type LayoutCache struct {
+	path string
+	dir  string
+	os   string
+}
+
+func NewLayoutCache(cacheType CacheInfo, workDir string, targetOS string) *LayoutCache {
+	return &LayoutCache{
+		path: cacheType.Source,
+		dir:  workDir,
+		os:   targetOS,
+	}
+}
+
+// Name returns the working directory to mount as the lifecycle cache directory
+func (c *LayoutCache) Name() string {
+	return c.dir
+}
+
+// Path returns the location of the OCI layout
+func (c *LayoutCache) Path() string {
+	return c.path
+}
+
+func (c *LayoutCache) Clear(ctx context.Context) error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return err
+	}
+	return os.RemoveAll(c.path)
+}
+
+func (c *LayoutCache) Type() Type {
+	return Layout
+}
+
+// Restore populates the working directory from the OCI layout, if present
+func (c *LayoutCache) Restore() error {
+	committedDir := filepath.Join(c.dir, "committed")
+	if err := os.MkdirAll(committedDir, 0777); err != nil {
+		return err
+	}
+	// the lifecycle may run as a different user than pack
+	if err := os.Chmod(c.dir, 0777); err != nil {
+		return err
+	}
+	if err := os.Chmod(committedDir, 0777); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(filepath.Join(c.path, "index.json")); os.IsNotExist(err) {
+		return nil
+	}
+
+	_, img, err := ocilayout.ReadImage(c.path)
+	if err != nil {
+		return errors.Wrapf(err, "reading OCI layout %s", style.Symbol(c.path))
+	}
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return err
+	}
+	if metadata, ok := configFile.Config.Labels[LayoutCacheMetadataLabel]; ok {
+		if err := os.WriteFile(filepath.Join(committedDir, LayoutCacheMetadataLabel), []byte(metadata), 0644); err != nil {
+			return err
+		}
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		diffID, err := layer.DiffID()
+		if err != nil {
+			return err
+		}
+		if err := c.writeLayer(committedDir, diffID.String(), layer.Uncompressed); err != nil {
+			return errors.Wrapf(err, "restoring layer %s", style.Symbol(diffID.String()))
+		}
+	}
+	return nil
+}
+
+// Save replaces the OCI layout with the contents of the working directory
+func (c *LayoutCache) Save() error {
+	committedDir := filepath.Join(c.dir, "committed")
+	entries, err := os.ReadDir(committedDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.OCIConfigJSON)
+
+	var layerFiles []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".tar") {
+			layerFiles = append(layerFiles, entry.Name())
+		}
+	}
+	sort.Strings(layerFiles)
+	for _, file := range layerFiles {
+		layer, err := tarball.LayerFromFile(filepath.Join(committedDir, file), tarball.WithMediaType(types.OCILayer))
+		if err != nil {
+			return err
+		}
+		if img, err = mutate.AppendLayers(img, layer); err != nil {
+			return errors.Wrapf(err, "adding layer %s", style.Symbol(file))
+		}
+	}
+
+	metadata, err := os.ReadFile(filepath.Join(committedDir, LayoutCacheMetadataLabel))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return err
+	}
+	configFile = configFile.DeepCopy()
+	configFile.OS = c.os
+	configFile.Config.Labels = map[string]string{LayoutCacheMetadataLabel: string(metadata)}
+	if img, err = mutate.ConfigFile(img, configFile); err != nil {
+		return err
+	}
+
+	// write to a sibling directory first so that a failure doesn't destroy the previous cache
+	tmpPath := c.path + ".tmp"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	p, err := layout.Write(tmpPath, mutate.IndexMediaType(empty.Index, types.OCIImageIndex))
+	if err != nil {
+		return errors.Wrapf(err, "writing OCI layout %s", style.Symbol(c.path))
+	}
+	if err = p.AppendImage(img); err != nil {
+		return errors.Wrapf(err, "writing OCI layout %s", style.Symbol(c.path))
+	}
+	if err := os.RemoveAll(c.path); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, c.path)
+}
+
+func (c *LayoutCache) writeLayer(dir, diffID string, open func() (io.ReadCloser, error)) error {
+	if c.os == "windows" {
+		// the lifecycle avoids colons in Windows file paths
+		diffID = strings.TrimPrefix(diffID, "sha256:")
+	}
+	rc, err := open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.OpenFile(filepath.Join(dir, diffID+".tar"), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, rc)
+	return err
+}
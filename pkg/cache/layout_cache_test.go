@@ -0,0 +1,124 @@
+package cache_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLayoutCache(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "LayoutCache", testLayoutCache, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLayoutCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir     string
+		layoutPath string
+		subject    *cache.LayoutCache
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "layout-cache")
+		h.AssertNil(t, err)
+		layoutPath = filepath.Join(tmpDir, "layout")
+		subject = cache.NewLayoutCache(cache.CacheInfo{Format: cache.CacheLayout, Source: layoutPath}, filepath.Join(tmpDir, "work"), "linux")
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	it("uses the working directory as name", func() {
+		h.AssertEq(t, subject.Name(), filepath.Join(tmpDir, "work"))
+		h.AssertEq(t, subject.Path(), layoutPath)
+		h.AssertEq(t, subject.Type(), cache.Layout)
+	})
+
+	when("#Restore", func() {
+		it("creates an empty cache directory when the layout doesn't exist", func() {
+			h.AssertNil(t, subject.Restore())
+			h.AssertPathExists(t, filepath.Join(tmpDir, "work", "committed"))
+			h.AssertPathDoesNotExists(t, layoutPath)
+		})
+	})
+
+	when("#Save", func() {
+		var (
+			layerTar []byte
+			diffID   string
+		)
+
+		it.Before(func() {
+			layerTar = layerTarWithFile(t, "layers/some-file", "some-content")
+			diffID = fmt.Sprintf("sha256:%x", sha256.Sum256(layerTar))
+
+			h.AssertNil(t, subject.Restore())
+			committedDir := filepath.Join(tmpDir, "work", "committed")
+			h.AssertNil(t, os.WriteFile(filepath.Join(committedDir, diffID+".tar"), layerTar, 0644))
+			h.AssertNil(t, os.WriteFile(filepath.Join(committedDir, cache.LayoutCacheMetadataLabel), []byte(`{"buildpacks":[]}`), 0644))
+		})
+
+		it("writes the cache directory as an OCI layout that can be restored", func() {
+			h.AssertNil(t, subject.Save())
+			index := h.ReadIndexManifest(t, layoutPath)
+			h.AssertEq(t, len(index.Manifests), 1)
+
+			restored := cache.NewLayoutCache(cache.CacheInfo{Format: cache.CacheLayout, Source: layoutPath}, filepath.Join(tmpDir, "restored"), "linux")
+			h.AssertNil(t, restored.Restore())
+
+			contents, err := os.ReadFile(filepath.Join(tmpDir, "restored", "committed", diffID+".tar"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, contents, layerTar)
+			metadata, err := os.ReadFile(filepath.Join(tmpDir, "restored", "committed", cache.LayoutCacheMetadataLabel))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(metadata), `{"buildpacks":[]}`)
+		})
+
+		it("replaces a previously saved layout", func() {
+			h.AssertNil(t, subject.Save())
+			h.AssertNil(t, subject.Save())
+			index := h.ReadIndexManifest(t, layoutPath)
+			h.AssertEq(t, len(index.Manifests), 1)
+			h.AssertPathDoesNotExists(t, layoutPath+".tmp")
+		})
+	})
+
+	when("#Clear", func() {
+		it("removes the layout and the working directory", func() {
+			h.AssertNil(t, subject.Restore())
+			h.AssertNil(t, subject.Save())
+
+			h.AssertNil(t, subject.Clear(context.TODO()))
+			h.AssertPathDoesNotExists(t, layoutPath)
+			h.AssertPathDoesNotExists(t, filepath.Join(tmpDir, "work"))
+		})
+	})
+}
+
+func layerTarWithFile(t *testing.T, name, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	h.AssertNil(t, err)
+	h.AssertNil(t, tw.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// ParseSize parses a human readable size such as `10GB` or `512MiB` into a number of bytes
+func ParseSize(size string) (int64, error) {
+	bytes, err := humanize.ParseBytes(size)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parsing size %s", style.Symbol(size))
+	}
+	return int64(bytes), nil
+}
+
+// ParseAge parses a duration such as `72h`; a number of days such as `7d` is also accepted
+func ParseAge(age string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(age, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, errors.Errorf("parsing age %s: invalid number of days", style.Symbol(age))
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(age)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parsing age %s", style.Symbol(age))
+	}
+	return d, nil
+}
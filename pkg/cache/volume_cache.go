@@ -31,9 +31,10 @@ func NewVolumeCache(imageRef name.Reference, cacheType CacheInfo, suffix string,
 		if err != nil {
 			return nil, err
 		}
-		sum := sha256.Sum256([]byte(imageRef.Name() + volumeKey))
-		vol := paths.FilterReservedNames(fmt.Sprintf("%s-%x", sanitizedRef(imageRef), sum[:6]))
-		volumeName = fmt.Sprintf("pack-cache-%s.%s", vol, suffix)
+		volumeName = fmt.Sprintf("%s.%s", cacheVolumeName(imageRef, volumeKey), suffix)
+		if err := recordVolumeUse(volumeName); err != nil {
+			logger.Debugf("Unable to record usage of volume %s: %s", volumeName, err)
+		}
 	} else {
 		volumeName = paths.FilterReservedNames(cacheType.Source)
 	}
@@ -44,6 +45,13 @@ func NewVolumeCache(imageRef name.Reference, cacheType CacheInfo, suffix string,
 	}, nil
 }
 
+// cacheVolumeName returns the name, without suffix, of the volumes holding the caches of the given image
+func cacheVolumeName(imageRef name.Reference, volumeKey string) string {
+	sum := sha256.Sum256([]byte(imageRef.Name() + volumeKey))
+	vol := paths.FilterReservedNames(fmt.Sprintf("%s-%x", sanitizedRef(imageRef), sum[:6]))
+	return volumeNamePrefix + vol
+}
+
 func getVolumeKey(imageRef name.Reference, logger logging.Logger) (string, error) {
 	var foundKey string
 
@@ -54,40 +62,32 @@ func getVolumeKey(imageRef name.Reference, logger logging.Logger) (string, error
 		return foundKey, nil
 	}
 
-	// then, look for key in existing config
-
-	volumeKeysPath, err := config.DefaultVolumeKeysPath()
-	if err != nil {
-		return "", err
-	}
-	cfg, err := config.ReadVolumeKeys(volumeKeysPath)
-	if err != nil {
-		return "", err
-	}
-
-	foundKey = cfg.VolumeKeys[imageRef.Name()]
-	if foundKey != "" {
-		return foundKey, nil
-	}
+	// then, look for key in existing config, or else create new key and store it in config
 
-	// finally, create new key and store it in config
+	err := updateVolumeConfig(func(cfg *config.VolumeConfig) bool {
+		foundKey = cfg.VolumeKeys[imageRef.Name()]
+		if foundKey != "" {
+			return false
+		}
 
-	// if we're running in a container, we should log a warning
-	// so that we don't always re-create the cache
-	if RunningInContainer() {
-		logger.Warnf("%s is unset; set this environment variable to a secret value to avoid creating a new volume cache on every build", EnvVolumeKey)
-	}
+		// if we're running in a container, we should log a warning
+		// so that we don't always re-create the cache
+		if RunningInContainer() {
+			logger.Warnf("%s is unset; set this environment variable to a secret value to avoid creating a new volume cache on every build", EnvVolumeKey)
+		}
 
-	newKey := randString(20)
-	if cfg.VolumeKeys == nil {
-		cfg.VolumeKeys = make(map[string]string)
-	}
-	cfg.VolumeKeys[imageRef.Name()] = newKey
-	if err = config.Write(cfg, volumeKeysPath); err != nil {
+		foundKey = randString(20)
+		if cfg.VolumeKeys == nil {
+			cfg.VolumeKeys = make(map[string]string)
+		}
+		cfg.VolumeKeys[imageRef.Name()] = foundKey
+		return true
+	})
+	if err != nil {
 		return "", err
 	}
 
-	return newKey, nil
+	return foundKey, nil
 }
 
 // Returns a string iwith lowercase a-z, of length n
@@ -0,0 +1,457 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	ggcrtypes "github.com/google/go-containerregistry/pkg/v1/types"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/ocilayout"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	volumeNamePrefix = "pack-cache-"
+	// volumeMountPath is where cache volumes are mounted in the helper container used for export and import
+	volumeMountPath = "/cache"
+)
+
+// ArchiveFormat is the on-disk format of an exported cache volume
+type ArchiveFormat string
+
+const (
+	// FormatTarball exports the volume contents as a tar archive rooted at `cache/`
+	FormatTarball ArchiveFormat = "tar"
+	// FormatLayout exports the volume contents as a single-layer image in OCI layout format
+	FormatLayout ArchiveFormat = "oci-layout"
+)
+
+// VolumeDockerClient is the subset of the Docker API required to manage cache volumes
+type VolumeDockerClient interface {
+	DockerClient
+	DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error)
+	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error)
+	ContainerRemove(ctx context.Context, container string, options container.RemoveOptions) error
+	CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error)
+	CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error
+}
+
+// VolumeInfo describes a cache volume created by pack
+type VolumeInfo struct {
+	Name string
+	// Image is the application image the cache belongs to; it is empty when the volume can't be matched to any
+	// of the keys in volume-keys.toml
+	Image string
+	// Kind is the volume name suffix, e.g. `build` or `launch`
+	Kind string
+	// Size is the disk space used by the volume in bytes, or -1 when not reported by the daemon
+	Size      int64
+	InUse     bool
+	CreatedAt time.Time
+	// LastUsed is the time of the last build that used the volume, or its creation time when unknown
+	LastUsed time.Time
+}
+
+// PruneOptions configures which cache volumes are removed by PruneVolumes. When no limit is set,
+// every cache volume that isn't in use is removed.
+type PruneOptions struct {
+	// MaxAge removes volumes that haven't been used for longer than the given duration
+	MaxAge time.Duration
+	// MaxTotalSize removes least-recently-used volumes until the total size of all cache volumes is below the given number of bytes
+	MaxTotalSize int64
+	// MaxImages removes the volumes of the least-recently-used images until caches are kept for at most the given number of images
+	MaxImages int
+	// ExcludeImages are images whose volumes are never removed
+	ExcludeImages []string
+	// DryRun reports the volumes that would be removed without removing them
+	DryRun bool
+}
+
+// ListVolumes returns all the cache volumes created by pack, sorted by name
+func ListVolumes(ctx context.Context, docker VolumeDockerClient) ([]VolumeInfo, error) {
+	usage, err := docker.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing volumes")
+	}
+
+	cfg, err := readVolumeConfig()
+	if err != nil {
+		return nil, err
+	}
+	owners := volumeOwners(cfg)
+
+	var volumes []VolumeInfo
+	for _, v := range usage.Volumes {
+		if v == nil || !strings.HasPrefix(v.Name, volumeNamePrefix) {
+			continue
+		}
+
+		info := VolumeInfo{Name: v.Name, Image: owners[volumeStem(v.Name)], Size: -1}
+		if idx := strings.LastIndex(v.Name, "."); idx > 0 {
+			info.Kind = v.Name[idx+1:]
+		}
+		if v.UsageData != nil {
+			info.Size = v.UsageData.Size
+			info.InUse = v.UsageData.RefCount > 0
+		}
+		if createdAt, err := time.Parse(time.RFC3339, v.CreatedAt); err == nil {
+			info.CreatedAt = createdAt
+		}
+		info.LastUsed = info.CreatedAt
+		if lastUsed, ok := cfg.LastUsed[v.Name]; ok {
+			info.LastUsed = lastUsed
+		}
+		volumes = append(volumes, info)
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	return volumes, nil
+}
+
+// RemoveVolume removes the given cache volume and forgets its usage history
+func RemoveVolume(ctx context.Context, docker VolumeDockerClient, volumeName string) error {
+	if err := docker.VolumeRemove(ctx, volumeName, false); err != nil && !client.IsErrNotFound(err) {
+		return errors.Wrapf(err, "removing volume %s", style.Symbol(volumeName))
+	}
+	return forgetVolume(volumeName)
+}
+
+// PruneVolumes removes the cache volumes selected by the given options and returns them. Volumes that are in use by a
+// container are never removed.
+func PruneVolumes(ctx context.Context, docker VolumeDockerClient, opts PruneOptions) ([]VolumeInfo, error) {
+	volumes, err := ListVolumes(ctx, docker)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := SelectForPruning(volumes, opts, time.Now())
+	if opts.DryRun {
+		return selected, nil
+	}
+	for _, v := range selected {
+		if err := RemoveVolume(ctx, docker, v.Name); err != nil {
+			return nil, err
+		}
+	}
+	return selected, nil
+}
+
+// SelectForPruning returns the volumes that should be removed to satisfy the given options, least-recently-used first
+func SelectForPruning(volumes []VolumeInfo, opts PruneOptions, now time.Time) []VolumeInfo {
+	excluded := map[string]bool{}
+	for _, imageName := range opts.ExcludeImages {
+		if ref, err := name.ParseReference(imageName, name.WeakValidation); err == nil {
+			excluded[ref.Name()] = true
+		}
+	}
+	isKept := func(v VolumeInfo) bool {
+		return v.InUse || (v.Image != "" && excluded[v.Image])
+	}
+
+	var candidates []VolumeInfo
+	for _, v := range volumes {
+		if !isKept(v) {
+			candidates = append(candidates, v)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastUsed.Before(candidates[j].LastUsed)
+	})
+
+	if opts.MaxAge <= 0 && opts.MaxTotalSize <= 0 && opts.MaxImages <= 0 {
+		return candidates
+	}
+
+	selected := map[string]bool{}
+	if opts.MaxAge > 0 {
+		for _, v := range candidates {
+			if now.Sub(v.LastUsed) > opts.MaxAge {
+				selected[v.Name] = true
+			}
+		}
+	}
+
+	if opts.MaxImages > 0 {
+		// the build and launch volumes of an image share the same name stem
+		lastUsed := map[string]time.Time{}
+		for _, v := range volumes {
+			used := v.LastUsed
+			if isKept(v) {
+				used = now
+			}
+			stem := volumeStem(v.Name)
+			if current, ok := lastUsed[stem]; !ok || used.After(current) {
+				lastUsed[stem] = used
+			}
+		}
+		var stems []string
+		for stem := range lastUsed {
+			stems = append(stems, stem)
+		}
+		sort.Slice(stems, func(i, j int) bool {
+			return lastUsed[stems[i]].After(lastUsed[stems[j]])
+		})
+		evicted := map[string]bool{}
+		if len(stems) > opts.MaxImages {
+			for _, stem := range stems[opts.MaxImages:] {
+				evicted[stem] = true
+			}
+		}
+		for _, v := range candidates {
+			if evicted[volumeStem(v.Name)] {
+				selected[v.Name] = true
+			}
+		}
+	}
+
+	if opts.MaxTotalSize > 0 {
+		var totalSize int64
+		for _, v := range volumes {
+			if v.Size > 0 && !selected[v.Name] {
+				totalSize += v.Size
+			}
+		}
+		for _, v := range candidates {
+			if totalSize <= opts.MaxTotalSize {
+				break
+			}
+			if selected[v.Name] {
+				continue
+			}
+			selected[v.Name] = true
+			if v.Size > 0 {
+				totalSize -= v.Size
+			}
+		}
+	}
+
+	var result []VolumeInfo
+	for _, v := range candidates {
+		if selected[v.Name] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// ExportVolume writes the contents of the given cache volume to path in the given format. The volume is read through a
+// container created (but never started) from helperImage, which must be available in the daemon.
+func ExportVolume(ctx context.Context, docker VolumeDockerClient, volumeName, helperImage, path string, format ArchiveFormat) error {
+	switch format {
+	case FormatTarball:
+		f, err := os.Create(path)
+		if err != nil {
+			return errors.Wrapf(err, "creating %s", style.Symbol(path))
+		}
+		defer f.Close()
+		return copyFromVolume(ctx, docker, volumeName, helperImage, f)
+	case FormatLayout:
+		tmp, err := os.CreateTemp("", "pack-cache-export")
+		if err != nil {
+			return errors.Wrap(err, "creating temp file")
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		if err = copyFromVolume(ctx, docker, volumeName, helperImage, tmp); err != nil {
+			return err
+		}
+		return writeLayout(tmp.Name(), volumeName, path)
+	default:
+		return errors.Errorf("unsupported export format %s", style.Symbol(string(format)))
+	}
+}
+
+// ImportVolume restores the contents of an archive created by ExportVolume into the given cache volume, creating it
+// if needed. The archive format is detected from path: directories are read as OCI layouts, files as tar archives.
+func ImportVolume(ctx context.Context, docker VolumeDockerClient, volumeName, helperImage, path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", style.Symbol(path))
+	}
+
+	var content io.ReadCloser
+	if fi.IsDir() {
+		if content, err = readLayout(path); err != nil {
+			return errors.Wrapf(err, "reading OCI layout %s", style.Symbol(path))
+		}
+	} else if content, err = os.Open(path); err != nil {
+		return errors.Wrapf(err, "opening %s", style.Symbol(path))
+	}
+	defer content.Close()
+
+	return withVolumeContainer(ctx, docker, volumeName, helperImage, func(containerID string) error {
+		return docker.CopyToContainer(ctx, containerID, "/", content, types.CopyToContainerOptions{})
+	})
+}
+
+func copyFromVolume(ctx context.Context, docker VolumeDockerClient, volumeName, helperImage string, w io.Writer) error {
+	return withVolumeContainer(ctx, docker, volumeName, helperImage, func(containerID string) error {
+		rc, _, err := docker.CopyFromContainer(ctx, containerID, volumeMountPath)
+		if err != nil {
+			return errors.Wrapf(err, "reading volume %s", style.Symbol(volumeName))
+		}
+		defer rc.Close()
+		_, err = io.Copy(w, rc)
+		return err
+	})
+}
+
+func withVolumeContainer(ctx context.Context, docker VolumeDockerClient, volumeName, helperImage string, f func(containerID string) error) error {
+	ctr, err := docker.ContainerCreate(ctx,
+		&container.Config{Image: helperImage, Cmd: []string{"cache"}},
+		&container.HostConfig{Binds: []string{volumeName + ":" + volumeMountPath}},
+		nil, nil, "",
+	)
+	if err != nil {
+		return errors.Wrapf(err, "creating container to access volume %s", style.Symbol(volumeName))
+	}
+	defer docker.ContainerRemove(context.Background(), ctr.ID, container.RemoveOptions{Force: true})
+
+	return f(ctr.ID)
+}
+
+func writeLayout(tarPath, volumeName, path string) error {
+	layer, err := tarball.LayerFromFile(tarPath, tarball.WithMediaType(ggcrtypes.OCIUncompressedLayer))
+	if err != nil {
+		return err
+	}
+	img, err := mutate.AppendLayers(mutate.MediaType(empty.Image, ggcrtypes.OCIManifestSchema1), layer)
+	if err != nil {
+		return err
+	}
+	p, err := layout.Write(path, mutate.IndexMediaType(empty.Index, ggcrtypes.OCIImageIndex))
+	if err != nil {
+		return errors.Wrapf(err, "writing OCI layout %s", style.Symbol(path))
+	}
+	return p.AppendImage(img, layout.WithAnnotations(map[string]string{"org.opencontainers.image.ref.name": volumeName}))
+}
+
+func readLayout(path string) (io.ReadCloser, error) {
+	_, img, err := ocilayout.ReadImage(path)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) != 1 {
+		return nil, errors.Errorf("expected exactly one layer, found %d", len(layers))
+	}
+	return layers[0].Uncompressed()
+}
+
+// volumeStem returns the volume name without its kind suffix
+func volumeStem(volumeName string) string {
+	if idx := strings.LastIndex(volumeName, "."); idx > 0 {
+		return volumeName[:idx]
+	}
+	return volumeName
+}
+
+// volumeOwners maps the name (without suffix) of every volume that can be derived from volume-keys.toml to its image
+func volumeOwners(cfg config.VolumeConfig) map[string]string {
+	owners := map[string]string{}
+	for imageName, key := range cfg.VolumeKeys {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		if err != nil {
+			continue
+		}
+		owners[cacheVolumeName(ref, key)] = imageName
+		if envKey := os.Getenv(EnvVolumeKey); envKey != "" {
+			owners[cacheVolumeName(ref, envKey)] = imageName
+		}
+	}
+	return owners
+}
+
+// recordVolumeUse stores the current time as the last time the volume was used by a build
+func recordVolumeUse(volumeName string) error {
+	return updateVolumeConfig(func(cfg *config.VolumeConfig) bool {
+		if cfg.LastUsed == nil {
+			cfg.LastUsed = map[string]time.Time{}
+		}
+		cfg.LastUsed[volumeName] = time.Now().UTC().Truncate(time.Second)
+		return true
+	})
+}
+
+func forgetVolume(volumeName string) error {
+	return updateVolumeConfig(func(cfg *config.VolumeConfig) bool {
+		delete(cfg.LastUsed, volumeName)
+		return true
+	})
+}
+
+// readVolumeConfig reads volume-keys.toml while holding the lock on it
+func readVolumeConfig() (config.VolumeConfig, error) {
+	var cfg config.VolumeConfig
+	err := updateVolumeConfig(func(read *config.VolumeConfig) bool {
+		cfg = *read
+		return false
+	})
+	return cfg, err
+}
+
+// updateVolumeConfig reads volume-keys.toml, and writes it back when update changes it, while holding a lock on it, so
+// that concurrent builds neither read it half-written nor overwrite the keys and usage recorded by each other
+func updateVolumeConfig(update func(cfg *config.VolumeConfig) bool) error {
+	volumeKeysPath, err := config.DefaultVolumeKeysPath()
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockVolumeConfig(volumeKeysPath)
+	if err != nil {
+		return errors.Wrapf(err, "locking %s", style.Symbol(volumeKeysPath))
+	}
+	defer unlock()
+
+	cfg, err := config.ReadVolumeKeys(volumeKeysPath)
+	if err != nil {
+		return err
+	}
+	if !update(&cfg) {
+		return nil
+	}
+	return config.Write(cfg, volumeKeysPath)
+}
+
+// lockVolumeConfig takes an exclusive lock on a file next to the volume config, which is truncated when written, until
+// the returned function is called
+func lockVolumeConfig(volumeKeysPath string) (func(), error) {
+	if err := config.MkdirAll(filepath.Dir(volumeKeysPath)); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(volumeKeysPath+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+	}, nil
+}
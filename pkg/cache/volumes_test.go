@@ -0,0 +1,333 @@
+package cache_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVolumes(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Volumes", testVolumes, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testVolumes(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		tmpPackHome    string
+		volumeKeysPath string
+		buildVolume    string
+		launchVolume   string
+	)
+
+	it.Before(func() {
+		var err error
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+
+		tmpPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		t.Setenv("PACK_HOME", tmpPackHome)
+		volumeKeysPath = filepath.Join(tmpPackHome, "volume-keys.toml")
+
+		ref, err := name.ParseReference("my/app", name.WeakValidation)
+		h.AssertNil(t, err)
+		var outBuf bytes.Buffer
+		buildCache, err := cache.NewVolumeCache(ref, cache.CacheInfo{}, "build", mockDocker, logging.NewSimpleLogger(&outBuf))
+		h.AssertNil(t, err)
+		buildVolume = buildCache.Name()
+		launchCache, err := cache.NewVolumeCache(ref, cache.CacheInfo{}, "launch", mockDocker, logging.NewSimpleLogger(&outBuf))
+		h.AssertNil(t, err)
+		launchVolume = launchCache.Name()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpPackHome))
+	})
+
+	expectVolumes := func(volumes ...*volume.Volume) {
+		mockDocker.EXPECT().
+			DiskUsage(gomock.Any(), types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}}).
+			Return(types.DiskUsage{Volumes: volumes}, nil)
+	}
+
+	when("#NewVolumeCache", func() {
+		it("records when the volume was last used", func() {
+			cfg, err := config.ReadVolumeKeys(volumeKeysPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(cfg.LastUsed), 2)
+			h.AssertTrue(t, time.Since(cfg.LastUsed[buildVolume]) < time.Minute)
+		})
+
+		it("records the volumes of concurrent builds", func() {
+			ref, err := name.ParseReference("my/app", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 10)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(suffix string) {
+					defer wg.Done()
+					var outBuf bytes.Buffer
+					_, err := cache.NewVolumeCache(ref, cache.CacheInfo{}, suffix, mockDocker, logging.NewSimpleLogger(&outBuf))
+					errs <- err
+				}(fmt.Sprintf("build%d", i))
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				h.AssertNil(t, err)
+			}
+
+			cfg, err := config.ReadVolumeKeys(volumeKeysPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(cfg.LastUsed), 12)
+		})
+	})
+
+	when("#ListVolumes", func() {
+		it("returns pack cache volumes with their image, size and usage", func() {
+			expectVolumes(
+				&volume.Volume{Name: launchVolume, CreatedAt: "2024-01-01T00:00:00Z", UsageData: &volume.UsageData{Size: 100, RefCount: 1}},
+				&volume.Volume{Name: "unrelated-volume", UsageData: &volume.UsageData{Size: 10}},
+				&volume.Volume{Name: buildVolume, CreatedAt: "2024-01-01T00:00:00Z", UsageData: &volume.UsageData{Size: 200}},
+				&volume.Volume{Name: "pack-cache-orphan_latest-000000000000.build", CreatedAt: "2024-01-01T00:00:00Z"},
+			)
+
+			volumes, err := cache.ListVolumes(context.TODO(), mockDocker)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(volumes), 3)
+
+			h.AssertEq(t, volumes[0].Name, buildVolume)
+			h.AssertEq(t, volumes[0].Image, "index.docker.io/my/app:latest")
+			h.AssertEq(t, volumes[0].Kind, "build")
+			h.AssertEq(t, volumes[0].Size, int64(200))
+			h.AssertEq(t, volumes[0].InUse, false)
+			h.AssertTrue(t, volumes[0].LastUsed.After(volumes[0].CreatedAt))
+
+			h.AssertEq(t, volumes[1].Name, launchVolume)
+			h.AssertEq(t, volumes[1].Kind, "launch")
+			h.AssertEq(t, volumes[1].InUse, true)
+
+			h.AssertEq(t, volumes[2].Image, "")
+			h.AssertEq(t, volumes[2].Size, int64(-1))
+			h.AssertEq(t, volumes[2].LastUsed, volumes[2].CreatedAt)
+		})
+	})
+
+	when("#RemoveVolume", func() {
+		it("removes the volume and its usage record", func() {
+			mockDocker.EXPECT().VolumeRemove(gomock.Any(), buildVolume, false).Return(nil)
+
+			h.AssertNil(t, cache.RemoveVolume(context.TODO(), mockDocker, buildVolume))
+
+			cfg, err := config.ReadVolumeKeys(volumeKeysPath)
+			h.AssertNil(t, err)
+			_, found := cfg.LastUsed[buildVolume]
+			h.AssertEq(t, found, false)
+			h.AssertEq(t, len(cfg.VolumeKeys), 1)
+		})
+	})
+
+	when("#SelectForPruning", func() {
+		var (
+			now     = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+			volumes []cache.VolumeInfo
+		)
+
+		it.Before(func() {
+			volumes = []cache.VolumeInfo{
+				{Name: "recent", Size: 100, LastUsed: now.Add(-time.Hour)},
+				{Name: "old", Size: 300, LastUsed: now.Add(-30 * 24 * time.Hour)},
+				{Name: "older-in-use", Size: 500, InUse: true, LastUsed: now.Add(-60 * 24 * time.Hour)},
+				{Name: "week-old", Size: 200, LastUsed: now.Add(-7 * 24 * time.Hour)},
+			}
+		})
+
+		it("selects every unused volume when no limit is set", func() {
+			selected := cache.SelectForPruning(volumes, cache.PruneOptions{}, now)
+			h.AssertEq(t, volumeNames(selected), []string{"old", "week-old", "recent"})
+		})
+
+		it("selects volumes older than max age", func() {
+			selected := cache.SelectForPruning(volumes, cache.PruneOptions{MaxAge: 72 * time.Hour}, now)
+			h.AssertEq(t, volumeNames(selected), []string{"old", "week-old"})
+		})
+
+		it("selects least recently used volumes until the total size fits", func() {
+			selected := cache.SelectForPruning(volumes, cache.PruneOptions{MaxTotalSize: 850}, now)
+			h.AssertEq(t, volumeNames(selected), []string{"old"})
+		})
+
+		it("never selects volumes of excluded images", func() {
+			volumes[1].Image = "index.docker.io/my/app:latest"
+			selected := cache.SelectForPruning(volumes, cache.PruneOptions{MaxAge: 72 * time.Hour, ExcludeImages: []string{"my/app"}}, now)
+			h.AssertEq(t, volumeNames(selected), []string{"week-old"})
+		})
+
+		it("selects the volumes of least recently used images beyond max images", func() {
+			volumes = []cache.VolumeInfo{
+				{Name: "pack-cache-a.build", LastUsed: now.Add(-time.Hour)},
+				{Name: "pack-cache-a.launch", LastUsed: now.Add(-10 * time.Hour)},
+				{Name: "pack-cache-b.build", LastUsed: now.Add(-2 * time.Hour)},
+				{Name: "pack-cache-c.build", LastUsed: now.Add(-30 * time.Hour), InUse: true},
+				{Name: "pack-cache-c.launch", LastUsed: now.Add(-30 * time.Hour)},
+			}
+			selected := cache.SelectForPruning(volumes, cache.PruneOptions{MaxImages: 2}, now)
+			h.AssertEq(t, volumeNames(selected), []string{"pack-cache-b.build"})
+		})
+	})
+
+	when("#ParseAge", func() {
+		it("accepts durations and days", func() {
+			age, err := cache.ParseAge("36h")
+			h.AssertNil(t, err)
+			h.AssertEq(t, age, 36*time.Hour)
+
+			age, err = cache.ParseAge("7d")
+			h.AssertNil(t, err)
+			h.AssertEq(t, age, 7*24*time.Hour)
+
+			_, err = cache.ParseAge("xd")
+			h.AssertError(t, err, "parsing age 'xd'")
+		})
+	})
+
+	when("#ParseSize", func() {
+		it("accepts human readable sizes", func() {
+			size, err := cache.ParseSize("10GB")
+			h.AssertNil(t, err)
+			h.AssertEq(t, size, int64(10000000000))
+
+			size, err = cache.ParseSize("1GiB")
+			h.AssertNil(t, err)
+			h.AssertEq(t, size, int64(1<<30))
+
+			_, err = cache.ParseSize("lots")
+			h.AssertError(t, err, "parsing size 'lots'")
+		})
+	})
+
+	when("#PruneVolumes", func() {
+		it("doesn't remove volumes on dry run", func() {
+			expectVolumes(&volume.Volume{Name: buildVolume, UsageData: &volume.UsageData{Size: 200}})
+
+			pruned, err := cache.PruneVolumes(context.TODO(), mockDocker, cache.PruneOptions{DryRun: true})
+			h.AssertNil(t, err)
+			h.AssertEq(t, volumeNames(pruned), []string{buildVolume})
+		})
+
+		it("removes the selected volumes", func() {
+			expectVolumes(
+				&volume.Volume{Name: buildVolume, UsageData: &volume.UsageData{Size: 200}},
+				&volume.Volume{Name: launchVolume, UsageData: &volume.UsageData{Size: 100, RefCount: 1}},
+			)
+			mockDocker.EXPECT().VolumeRemove(gomock.Any(), buildVolume, false).Return(nil)
+
+			pruned, err := cache.PruneVolumes(context.TODO(), mockDocker, cache.PruneOptions{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, volumeNames(pruned), []string{buildVolume})
+		})
+	})
+
+	when("#ExportVolume and #ImportVolume", func() {
+		var (
+			tmpDir  string
+			content []byte
+		)
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "pack-cache-export")
+			h.AssertNil(t, err)
+			content = cacheTar(t)
+
+			mockDocker.EXPECT().
+				ContainerCreate(gomock.Any(), &container.Config{Image: "helper-image", Cmd: []string{"cache"}}, &container.HostConfig{Binds: []string{buildVolume + ":/cache"}}, nil, nil, "").
+				Return(container.CreateResponse{ID: "export-container"}, nil)
+			mockDocker.EXPECT().
+				CopyFromContainer(gomock.Any(), "export-container", "/cache").
+				Return(io.NopCloser(bytes.NewReader(content)), types.ContainerPathStat{}, nil)
+			mockDocker.EXPECT().
+				ContainerRemove(gomock.Any(), "export-container", container.RemoveOptions{Force: true}).
+				Return(nil)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(tmpDir))
+		})
+
+		for _, format := range []cache.ArchiveFormat{cache.FormatTarball, cache.FormatLayout} {
+			format := format
+			it("round-trips the volume contents as "+string(format), func() {
+				path := filepath.Join(tmpDir, "export")
+				h.AssertNil(t, cache.ExportVolume(context.TODO(), mockDocker, buildVolume, "helper-image", path, format))
+
+				var imported bytes.Buffer
+				mockDocker.EXPECT().
+					ContainerCreate(gomock.Any(), &container.Config{Image: "helper-image", Cmd: []string{"cache"}}, &container.HostConfig{Binds: []string{"restored:/cache"}}, nil, nil, "").
+					Return(container.CreateResponse{ID: "import-container"}, nil)
+				mockDocker.EXPECT().
+					CopyToContainer(gomock.Any(), "import-container", "/", gomock.Any(), types.CopyToContainerOptions{}).
+					DoAndReturn(func(_ context.Context, _, _ string, r io.Reader, _ types.CopyToContainerOptions) error {
+						_, err := io.Copy(&imported, r)
+						return err
+					})
+				mockDocker.EXPECT().
+					ContainerRemove(gomock.Any(), "import-container", container.RemoveOptions{Force: true}).
+					Return(nil)
+
+				h.AssertNil(t, cache.ImportVolume(context.TODO(), mockDocker, "restored", "helper-image", path))
+				h.AssertEq(t, imported.Bytes(), content)
+			})
+		}
+	})
+}
+
+func volumeNames(volumes []cache.VolumeInfo) []string {
+	var result []string
+	for _, v := range volumes {
+		result = append(result, v.Name)
+	}
+	return result
+}
+
+func cacheTar(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "cache/", Typeflag: tar.TypeDir, Mode: 0755}))
+	h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "cache/some-file", Typeflag: tar.TypeReg, Mode: 0644, Size: 7}))
+	_, err := tw.Write([]byte("content"))
+	h.AssertNil(t, err)
+	h.AssertNil(t, tw.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,346 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/ocilayout"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/attestation"
+	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	"github.com/buildpacks/pack/pkg/signature"
+)
+
+// imageSigning is what an app image is signed with, and what its provenance is made of, once it is built
+type imageSigning struct {
+	key signature.PrivateKey
+
+	// layoutDir is the OCI layout the image is exported to, or empty when it is published to a registry
+	layoutDir string
+
+	// reportDir is the directory the lifecycle copies its report to when the image is published to a registry, whose
+	// report records the digest of the image it exported
+	reportDir string
+
+	packVersion string
+	builder     string
+
+	// builderDigest is the manifest digest of the builder in its registry, or empty when it has none
+	builderDigest string
+
+	lifecycleVersion string
+	source           *files.ProjectSource
+	parameters       map[string]interface{}
+}
+
+// prepareSigning records what the app image of a build is signed with, and the inputs of the build its provenance
+// lists. The source is the project source of the build, or the git metadata of the app when there is none.
+func (c *Client) prepareSigning(ctx context.Context, opts BuildOptions, builderImage imgutil.Image, builderName string, lifecycleVersion *builder.Version, source *files.ProjectSource, layoutDir string) (*imageSigning, error) {
+	builderDigest, err := c.registryDigest(ctx, builderName, builderImage)
+	if err != nil {
+		c.logger.Debugf("Omitting the digest of builder %s from the provenance: %s", style.Symbol(builderName), err)
+	}
+	if source == nil {
+		source = v02.GitMetadata(opts.AppPath)
+	}
+
+	parameters := map[string]interface{}{
+		"image":   opts.Image,
+		"builder": opts.Builder,
+		"publish": opts.Publish,
+	}
+	if opts.RunImage != "" {
+		parameters["runImage"] = opts.RunImage
+	}
+	if len(opts.Buildpacks) > 0 {
+		parameters["buildpacks"] = opts.Buildpacks
+	}
+	if len(opts.Extensions) > 0 {
+		parameters["extensions"] = opts.Extensions
+	}
+
+	signing := &imageSigning{
+		key:           *opts.SignKey,
+		packVersion:   c.version,
+		builder:       builderName,
+		builderDigest: builderDigest,
+		source:        source,
+		parameters:    parameters,
+	}
+	if opts.Layout() {
+		signing.layoutDir = layoutDir
+	}
+	if lifecycleVersion != nil {
+		signing.lifecycleVersion = lifecycleVersion.String()
+	}
+	return signing, nil
+}
+
+// signImage signs the app image built by b, and attaches its SBOM and provenance as referrers, if signing
+func (c *Client) signImage(ctx context.Context, b *preparedBuild, startedOn time.Time) error {
+	if b.signing == nil {
+		return nil
+	}
+	finishedOn := time.Now()
+
+	var (
+		subject v1.Descriptor
+		img     v1.Image
+		write   func(referrer v1.Image) error
+	)
+	if b.signing.layoutDir != "" {
+		layoutPath, err := layout.FromPath(b.signing.layoutDir)
+		if err != nil {
+			return errors.Wrapf(err, "reading OCI layout %s", style.Symbol(b.signing.layoutDir))
+		}
+		if subject, img, err = ocilayout.ReadImage(b.signing.layoutDir); err != nil {
+			return errors.Wrapf(err, "reading image in OCI layout %s", style.Symbol(b.signing.layoutDir))
+		}
+		write = func(referrer v1.Image) error {
+			return layoutPath.AppendImage(referrer)
+		}
+	} else {
+		// the image is fetched by the digest the lifecycle exported, as the tag may have been pushed to since
+		digest, err := exportedDigest(b.signing.reportDir)
+		if err != nil {
+			return errors.Wrapf(err, "reading digest of built image %s", style.Symbol(b.imageRef.Name()))
+		}
+		digestRef := b.imageRef.Context().Digest(digest)
+		options := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(c.keychain)}
+		desc, err := remote.Get(digestRef, options...)
+		if err != nil {
+			return errors.Wrapf(err, "fetching built image %s", style.Symbol(digestRef.Name()))
+		}
+		if img, err = desc.Image(); err != nil {
+			return errors.Wrapf(err, "reading built image %s", style.Symbol(digestRef.Name()))
+		}
+		subject = desc.Descriptor
+		write = func(referrer v1.Image) error {
+			digest, err := referrer.Digest()
+			if err != nil {
+				return err
+			}
+			return remote.Write(b.imageRef.Context().Digest(digest.String()), referrer, options...)
+		}
+	}
+
+	imageName := b.imageRef.Context().Name()
+	referrers, sboms, err := c.imageReferrers(b.signing, imageName, subject, img, startedOn, finishedOn)
+	if err != nil {
+		return err
+	}
+	for _, referrer := range referrers {
+		if err := write(referrer); err != nil {
+			return errors.Wrapf(err, "attaching signature and attestations to %s", style.Symbol(imageName+"@"+subject.Digest.String()))
+		}
+	}
+
+	c.logger.Infof("Signed %s with %s and attached its provenance and %d SBOM(s)", style.Symbol(imageName+"@"+subject.Digest.String()), style.Symbol(b.signing.key.Path), sboms)
+	return nil
+}
+
+// exportedDigest returns the digest of the image the lifecycle exported, as recorded in the report it copied to dir
+func exportedDigest(dir string) (string, error) {
+	var report files.Report
+	if _, err := toml.DecodeFile(filepath.Join(dir, "report.toml"), &report); err != nil {
+		return "", err
+	}
+	if report.Image.Digest == "" {
+		return "", errors.New("the lifecycle didn't report the digest of the image")
+	}
+	return report.Image.Digest, nil
+}
+
+// imageReferrers returns the signature of the image, followed by its signed SBOM and provenance attestations, and the
+// number of SBOMs attested
+func (c *Client) imageReferrers(signing *imageSigning, imageName string, subject v1.Descriptor, img v1.Image, startedOn, finishedOn time.Time) ([]v1.Image, int, error) {
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "reading config of built image")
+	}
+	labels := configFile.Config.Labels
+
+	var buildMD files.BuildMetadata
+	if err := unmarshalLabel(labels, platform.BuildMetadataLabel, &buildMD); err != nil {
+		return nil, 0, err
+	}
+	var layersMD files.LayersMetadata
+	if err := unmarshalLabel(labels, platform.LifecycleMetadataLabel, &layersMD); err != nil {
+		return nil, 0, err
+	}
+
+	sigImage, err := signature.NewSignatureImage(signing.key, imageName, subject)
+	if err != nil {
+		return nil, 0, err
+	}
+	referrers := []v1.Image{sigImage}
+
+	var sboms []attestation.Attestation
+	if layersMD.BOM != nil && layersMD.BOM.SHA != "" {
+		if sboms, err = sbomAttestations(signing.key, imageName, subject.Digest, img, layersMD.BOM.SHA); err != nil {
+			return nil, 0, errors.Wrap(err, "attesting SBOM of built image")
+		}
+	}
+	if len(sboms) > 0 {
+		sbomImage, err := attestation.NewImage(subject, sboms...)
+		if err != nil {
+			return nil, 0, err
+		}
+		referrers = append(referrers, sbomImage)
+	}
+
+	provenance := attestation.NewStatement(imageName, subject.Digest, attestation.ProvenancePredicateType, signing.provenance(buildMD, layersMD, startedOn, finishedOn))
+	envelope, err := attestation.Sign(provenance, signing.key)
+	if err != nil {
+		return nil, 0, err
+	}
+	provenanceImage, err := attestation.NewImage(subject, attestation.Attestation{
+		Envelope:      envelope,
+		PredicateType: attestation.ProvenancePredicateType,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return append(referrers, provenanceImage), len(sboms), nil
+}
+
+// provenance returns the SLSA provenance of the image, whose buildpacks and run image are read from the metadata the
+// lifecycle exported
+func (s *imageSigning) provenance(buildMD files.BuildMetadata, layersMD files.LayersMetadata, startedOn, finishedOn time.Time) attestation.Provenance {
+	dependencies := []attestation.ResourceDescriptor{{
+		Name:   "builder",
+		URI:    s.builder,
+		Digest: attestation.DigestSet(s.builderDigest),
+	}}
+	if runImage := layersMD.RunImage.Reference; runImage != "" {
+		descriptor := attestation.ResourceDescriptor{Name: "run-image", URI: runImage}
+		if _, digest, ok := strings.Cut(runImage, "@"); ok {
+			descriptor.Digest = attestation.DigestSet(digest)
+		}
+		dependencies = append(dependencies, descriptor)
+	}
+	for _, bp := range buildMD.Buildpacks {
+		dependencies = append(dependencies, attestation.ResourceDescriptor{
+			Name:        "buildpack:" + bp.ID,
+			Annotations: map[string]interface{}{"id": bp.ID, "version": bp.Version},
+		})
+	}
+	for _, ext := range buildMD.Extensions {
+		dependencies = append(dependencies, attestation.ResourceDescriptor{
+			Name:        "extension:" + ext.ID,
+			Annotations: map[string]interface{}{"id": ext.ID, "version": ext.Version},
+		})
+	}
+	if s.source != nil {
+		descriptor := attestation.ResourceDescriptor{
+			Name:        "source",
+			Annotations: map[string]interface{}{"type": s.source.Type, "version": s.source.Version, "metadata": s.source.Metadata},
+		}
+		if url, ok := s.source.Metadata["url"].(string); ok && url != "" {
+			descriptor.URI = url
+		}
+		if commit, ok := s.source.Version["commit"].(string); ok && commit != "" {
+			descriptor.Digest = map[string]string{"gitCommit": commit}
+		}
+		dependencies = append(dependencies, descriptor)
+	}
+
+	internal := map[string]interface{}{}
+	if s.lifecycleVersion != "" {
+		internal["lifecycleVersion"] = s.lifecycleVersion
+	}
+	return attestation.Provenance{
+		BuildDefinition: attestation.BuildDefinition{
+			BuildType:            attestation.BuildType,
+			ExternalParameters:   s.parameters,
+			InternalParameters:   internal,
+			ResolvedDependencies: dependencies,
+		},
+		RunDetails: attestation.RunDetails{
+			Builder: attestation.Builder{
+				ID:      "https://buildpacks.io/pack",
+				Version: map[string]string{"pack": s.packVersion},
+			},
+			Metadata: attestation.BuildMetadata{StartedOn: &startedOn, FinishedOn: &finishedOn},
+		},
+	}
+}
+
+// sbomAttestations returns a signed statement for each SBOM file in the SBOM layer of the image
+func sbomAttestations(key signature.PrivateKey, imageName string, digest v1.Hash, img v1.Image, diffID string) ([]attestation.Attestation, error) {
+	hash, err := v1.NewHash(diffID)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := img.LayerByDiffID(hash)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var attestations []attestation.Attestation
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return attestations, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		predicateType, ok := attestation.SBOMPredicateType(header.Name)
+		if !ok {
+			continue
+		}
+
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		var predicate json.RawMessage
+		if err := json.Unmarshal(contents, &predicate); err != nil {
+			return nil, errors.Wrapf(err, "parsing SBOM %s", style.Symbol(header.Name))
+		}
+
+		envelope, err := attestation.Sign(attestation.NewStatement(imageName, digest, predicateType, predicate), key)
+		if err != nil {
+			return nil, err
+		}
+		attestations = append(attestations, attestation.Attestation{
+			Title:         path.Clean("/" + header.Name),
+			Envelope:      envelope,
+			PredicateType: predicateType,
+		})
+	}
+}
+
+func unmarshalLabel(labels map[string]string, label string, v interface{}) error {
+	value, ok := labels[label]
+	if !ok {
+		return nil
+	}
+	return errors.Wrapf(json.Unmarshal([]byte(value), v), "parsing label %s", style.Symbol(label))
+}
@@ -0,0 +1,344 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/attestation"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signature"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAttest(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Attest", testAttest, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAttest(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject   *Client
+		out       bytes.Buffer
+		key       signature.PrivateKey
+		layoutDir string
+		appImage  v1.Image
+	)
+
+	it.Before(func() {
+		subject = &Client{logger: logging.NewLogWithWriters(&out, &out), version: "1.2.3"}
+
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		key = signature.PrivateKey{Path: "cosign.key", Key: ecKey}
+
+		sbomLayer := tarLayer(t, map[string]string{
+			"layers/sbom/launch/some_node/sbom.cdx.json": `{"bomFormat": "CycloneDX"}`,
+			"layers/sbom/launch/some_node/sbom.toml":     `ignored = true`,
+		})
+		sbomDiffID, err := sbomLayer.DiffID()
+		h.AssertNil(t, err)
+
+		appImage, err = mutate.AppendLayers(empty.Image, sbomLayer)
+		h.AssertNil(t, err)
+		appImage, err = mutate.Config(appImage, v1.Config{Labels: map[string]string{
+			"io.buildpacks.build.metadata":     `{"buildpacks": [{"id": "some/node", "version": "1.0.0"}]}`,
+			"io.buildpacks.lifecycle.metadata": fmt.Sprintf(`{"runImage": {"reference": "some/run@sha256:%064d"}, "sbom": {"sha": "%s"}}`, 1, sbomDiffID),
+		}})
+		h.AssertNil(t, err)
+
+		layoutDir = t.TempDir()
+		layoutPath, err := layout.Write(layoutDir, empty.Index)
+		h.AssertNil(t, err)
+		h.AssertNil(t, layoutPath.AppendImage(appImage))
+	})
+
+	when("#prepareSigning", func() {
+		var (
+			mockController *gomock.Controller
+			mockDocker     *testmocks.MockCommonAPIClient
+			server         *httptest.Server
+			builderRepo    string
+			builderDigest  string
+			builderImage   *fakes.Image
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+			subject.docker = mockDocker
+
+			server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+			builderRepo = strings.TrimPrefix(server.URL, "http://") + "/some/builder"
+			pushed, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			pushedRef, err := name.ParseReference(builderRepo + ":latest")
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(pushedRef, pushed))
+			digest, err := pushed.Digest()
+			h.AssertNil(t, err)
+			builderDigest = digest.String()
+			configName, err := pushed.ConfigName()
+			h.AssertNil(t, err)
+
+			builderImage = fakes.NewImage(builderRepo+":latest", "", local.IDIdentifier{ImageID: configName.String()})
+		})
+
+		it.After(func() {
+			mockController.Finish()
+			server.Close()
+		})
+
+		prepareSigning := func() *imageSigning {
+			signing, err := subject.prepareSigning(context.TODO(), BuildOptions{Image: "some/app", SignKey: &key}, builderImage, builderRepo+":latest", nil, nil, "")
+			h.AssertNil(t, err)
+			return signing
+		}
+
+		it("records the digest the builder in the daemon has in its registry", func() {
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), gomock.Any()).
+				Return(types.ImageInspect{RepoDigests: []string{builderRepo + "@" + builderDigest}}, nil, nil)
+
+			h.AssertEq(t, prepareSigning().builderDigest, builderDigest)
+		})
+
+		it("omits the digest of a builder that was never pulled or pushed", func() {
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), gomock.Any()).Return(types.ImageInspect{}, nil, nil)
+
+			signing := prepareSigning()
+			h.AssertEq(t, signing.builderDigest, "")
+			dependencies := signing.provenance(files.BuildMetadata{}, files.LayersMetadata{}, time.Now(), time.Now()).BuildDefinition.ResolvedDependencies
+			h.AssertEq(t, dependencies[0].URI, builderRepo+":latest")
+			h.AssertEq(t, len(dependencies[0].Digest), 0)
+		})
+
+		it("omits the digest of a builder that can't be resolved in its registry", func() {
+			server.Close()
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), gomock.Any()).
+				Return(types.ImageInspect{RepoDigests: []string{builderRepo + "@" + builderDigest}}, nil, nil)
+
+			h.AssertEq(t, prepareSigning().builderDigest, "")
+		})
+	})
+
+	when("#signImage", func() {
+		it("attaches the signature, SBOM and provenance of an image in an OCI layout", func() {
+			imageRef, err := name.ParseReference("registry.example.com/some/app")
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, subject.signImage(context.TODO(), &preparedBuild{
+				imageRef: imageRef,
+				signing: &imageSigning{
+					key:           key,
+					layoutDir:     layoutDir,
+					packVersion:   subject.version,
+					builder:       "some/builder",
+					builderDigest: fmt.Sprintf("sha256:%064d", 2),
+					source: &files.ProjectSource{
+						Type:     "git",
+						Version:  map[string]interface{}{"commit": "abc123"},
+						Metadata: map[string]interface{}{"url": "https://github.com/some/app"},
+					},
+					parameters: map[string]interface{}{"image": "some/app"},
+				},
+			}, time.Now()))
+
+			layoutPath, err := layout.FromPath(layoutDir)
+			h.AssertNil(t, err)
+			index, err := layoutPath.ImageIndex()
+			h.AssertNil(t, err)
+			indexManifest, err := index.IndexManifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(indexManifest.Manifests), 4)
+
+			appDigest, err := appImage.Digest()
+			h.AssertNil(t, err)
+			var artifactTypes []string
+			envelopes := map[string]attestation.Envelope{}
+			for _, desc := range indexManifest.Manifests[1:] {
+				referrer, err := index.Image(desc.Digest)
+				h.AssertNil(t, err)
+				manifest, err := referrer.Manifest()
+				h.AssertNil(t, err)
+				h.AssertEq(t, manifest.Subject.Digest, appDigest)
+				artifactTypes = append(artifactTypes, string(manifest.Config.MediaType))
+
+				if manifest.Config.MediaType != attestation.ArtifactType {
+					continue
+				}
+				layers, err := referrer.Layers()
+				h.AssertNil(t, err)
+				for i, layer := range layers {
+					var envelope attestation.Envelope
+					h.AssertNil(t, json.Unmarshal(layerContents(t, layer), &envelope))
+					envelopes[manifest.Layers[i].Annotations[attestation.PredicateTypeAnnotation]] = envelope
+				}
+			}
+			h.AssertEq(t, artifactTypes, []string{signature.SignatureArtifactType, attestation.ArtifactType, attestation.ArtifactType})
+			h.AssertEq(t, len(envelopes), 2)
+
+			sbom, err := envelopes[attestation.CycloneDXPredicateType].Verify(key.PublicKey())
+			h.AssertNil(t, err)
+			h.AssertEq(t, sbom.Subject[0].Digest["sha256"], appDigest.Hex)
+			h.AssertEq(t, sbom.Predicate, map[string]interface{}{"bomFormat": "CycloneDX"})
+
+			statement, err := envelopes[attestation.ProvenancePredicateType].Verify(key.PublicKey())
+			h.AssertNil(t, err)
+			predicate, err := json.Marshal(statement.Predicate)
+			h.AssertNil(t, err)
+			var provenance attestation.Provenance
+			h.AssertNil(t, json.Unmarshal(predicate, &provenance))
+			h.AssertEq(t, provenance.BuildDefinition.BuildType, attestation.BuildType)
+			h.AssertEq(t, provenance.RunDetails.Builder.Version["pack"], "1.2.3")
+
+			dependencies := provenance.BuildDefinition.ResolvedDependencies
+			h.AssertEq(t, len(dependencies), 4)
+			h.AssertEq(t, dependencies[0].URI, "some/builder")
+			h.AssertEq(t, dependencies[0].Digest, map[string]string{"sha256": fmt.Sprintf("%064d", 2)})
+			h.AssertEq(t, dependencies[1].Name, "run-image")
+			h.AssertEq(t, dependencies[1].Digest, map[string]string{"sha256": fmt.Sprintf("%064d", 1)})
+			h.AssertEq(t, dependencies[2].Name, "buildpack:some/node")
+			h.AssertEq(t, dependencies[2].Annotations["version"], "1.0.0")
+			h.AssertEq(t, dependencies[3].URI, "https://github.com/some/app")
+			h.AssertEq(t, dependencies[3].Digest, map[string]string{"gitCommit": "abc123"})
+
+			h.AssertContains(t, out.String(), "and attached its provenance and 1 SBOM(s)")
+		})
+
+		it("errors when the OCI layout has more than one image", func() {
+			layoutPath, err := layout.FromPath(layoutDir)
+			h.AssertNil(t, err)
+			other, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			h.AssertNil(t, layoutPath.AppendImage(other))
+			imageRef, err := name.ParseReference("registry.example.com/some/app")
+			h.AssertNil(t, err)
+
+			err = subject.signImage(context.TODO(), &preparedBuild{
+				imageRef: imageRef,
+				signing:  &imageSigning{key: key, layoutDir: layoutDir},
+			}, time.Now())
+			h.AssertError(t, err, "found 2 images, expected one")
+		})
+
+		when("the image is published", func() {
+			var (
+				server    *httptest.Server
+				imageRef  name.Reference
+				reportDir string
+			)
+
+			it.Before(func() {
+				server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+				var err error
+				imageRef, err = name.ParseReference(strings.TrimPrefix(server.URL, "http://") + "/some/app:latest")
+				h.AssertNil(t, err)
+				reportDir = t.TempDir()
+			})
+
+			it.After(func() {
+				server.Close()
+			})
+
+			it("signs the image by the digest the lifecycle reported, rather than the image its tag points to", func() {
+				appDigest, err := appImage.Digest()
+				h.AssertNil(t, err)
+				h.AssertNil(t, remote.Write(imageRef.Context().Digest(appDigest.String()), appImage))
+				// the tag was pushed to after the build exported the image
+				other, err := random.Image(1024, 1)
+				h.AssertNil(t, err)
+				h.AssertNil(t, remote.Write(imageRef, other))
+				h.AssertNil(t, os.WriteFile(filepath.Join(reportDir, "report.toml"), []byte(fmt.Sprintf("[image]\ndigest = %q\n", appDigest.String())), 0600))
+
+				h.AssertNil(t, subject.signImage(context.TODO(), &preparedBuild{
+					imageRef: imageRef,
+					publish:  true,
+					signing:  &imageSigning{key: key, reportDir: reportDir, builder: "some/builder"},
+				}, time.Now()))
+
+				referrers, err := remote.Referrers(imageRef.Context().Digest(appDigest.String()))
+				h.AssertNil(t, err)
+				referrersManifest, err := referrers.IndexManifest()
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(referrersManifest.Manifests), 3)
+				otherDigest, err := other.Digest()
+				h.AssertNil(t, err)
+				referrers, err = remote.Referrers(imageRef.Context().Digest(otherDigest.String()))
+				h.AssertNil(t, err)
+				referrersManifest, err = referrers.IndexManifest()
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(referrersManifest.Manifests), 0)
+			})
+
+			it("errors when the lifecycle didn't report the digest of the image", func() {
+				err := subject.signImage(context.TODO(), &preparedBuild{
+					imageRef: imageRef,
+					publish:  true,
+					signing:  &imageSigning{key: key, reportDir: reportDir},
+				}, time.Now())
+				h.AssertError(t, err, "reading digest of built image")
+			})
+		})
+	})
+}
+
+func tarLayer(t *testing.T, contents map[string]string) v1.Layer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for path, content := range contents {
+		h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: path, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		h.AssertNil(t, err)
+	}
+	h.AssertNil(t, tw.Close())
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	h.AssertNil(t, err)
+	return layer
+}
+
+func layerContents(t *testing.T, layer v1.Layer) []byte {
+	t.Helper()
+	rc, err := layer.Compressed()
+	h.AssertNil(t, err)
+	defer rc.Close()
+	contents, err := io.ReadAll(rc)
+	h.AssertNil(t, err)
+	return contents
+}
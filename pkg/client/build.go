@@ -44,9 +44,11 @@ import (
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/lockfile"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	"github.com/buildpacks/pack/pkg/signature"
 )
 
 const (
@@ -140,6 +142,10 @@ type BuildOptions struct {
 	// Launch a terminal UI to depict the build process
 	Interactive bool
 
+	// Receives structured events as the build progresses, such as lifecycle phases starting and finishing,
+	// buildpacks passing detection, layers reused from cache and the digest of the exported image.
+	EventSink logging.EventSink
+
 	// List of buildpack images or archives to add to a builder.
 	// These buildpacks may overwrite those on the builder if they
 	// share both an ID and Version with a buildpack on the builder.
@@ -167,6 +173,13 @@ type BuildOptions struct {
 	// Platform is the desired platform to build on (e.g., linux/amd64)
 	Platform string
 
+	// Targets are the platforms to build the application image for. When more than one target
+	// is provided, one lifecycle execution runs per target and the resulting images are assembled
+	// into an image index, which requires either Publish or an OCI layout output. When publishing, the image of
+	// each platform is pushed by digest before the index, without being tagged.
+	// When set, Targets takes precedence over Platform.
+	Targets []dist.Target
+
 	// Strategy for updating local images before a build.
 	PullPolicy image.PullPolicy
 
@@ -217,7 +230,8 @@ type BuildOptions struct {
 	// Directory to output any SBOM artifacts
 	SBOMDestinationDir string
 
-	// Directory to output the report.toml metadata artifact
+	// Directory to output the report.toml metadata artifact, along with a pack-report.json
+	// recording the time spent in each phase, pulling images and uploading the app, and the size of the cache volumes.
 	ReportDestinationDir string
 
 	// Desired create time in the output image config
@@ -225,6 +239,30 @@ type BuildOptions struct {
 
 	// Configuration to export to OCI layout format
 	LayoutConfig *LayoutConfig
+
+	// Keep running after the build, rebuilding the app every time a file sent to the build changes. The ephemeral
+	// builder and the cache volumes are reused by every rebuild. Watching stops when the context is cancelled.
+	// Watching can't be combined with a ReportDestinationDir.
+	Watch bool
+
+	// How long the app must stay unchanged before it is rebuilt when watching. Defaults to DefaultWatchDebounce.
+	WatchDebounce time.Duration
+
+	// Path to a lockfile recording the digests that the builder, run image, lifecycle image and remote buildpacks
+	// and extensions resolve to. When the lockfile exists, the build is verified against it. New references are added
+	// to the lockfile once the build succeeds.
+	Lockfile string
+
+	// What to do when a reference resolves to a different digest than the one in the lockfile.
+	LockfileMode lockfile.Mode
+
+	// Policy the builder, run image, lifecycle image and remote buildpacks and extensions are verified against before
+	// the build runs. Nothing is verified when nil.
+	Verification *signature.Policy
+
+	// Key the app image is signed with once it is built. Its SBOM and SLSA provenance are signed with it too, and
+	// attached to the image as OCI referrers. Requires publishing the image or exporting it to OCI layout format.
+	SignKey *signature.PrivateKey
 }
 
 func (b *BuildOptions) Layout() bool {
@@ -295,8 +333,73 @@ type layoutPathConfig struct {
 // If any configuration is deemed invalid, or if any lifecycle phases fail,
 // an error will be returned and no image produced.
 func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+	if opts.Lockfile != "" && c.lock == nil {
+		return c.withLockfile(opts.Lockfile, opts.LockfileMode, func(locking *Client) error {
+			return locking.Build(ctx, opts)
+		})
+	}
+	if opts.Verification != nil && c.verifier == nil {
+		return c.withVerification(*opts.Verification, func(verifying *Client) error {
+			return verifying.Build(ctx, opts)
+		})
+	}
+	if opts.SignKey != nil && !opts.Publish && !opts.Layout() {
+		return errors.New("signing the app image requires publishing it to a registry or exporting it to OCI layout format")
+	}
+	if len(opts.Targets) > 1 {
+		return c.buildMultiPlatform(ctx, opts)
+	}
+	if opts.Watch && opts.ReportDestinationDir != "" {
+		return errors.New("watching the app can't be combined with exporting a build report")
+	}
+	if opts.Watch {
+		return c.buildAndWatch(ctx, opts)
+	}
+	if opts.ReportDestinationDir != "" {
+		return c.buildWithReport(ctx, opts)
+	}
+	return c.build(ctx, opts)
+}
+
+func (c *Client) build(ctx context.Context, opts BuildOptions) error {
+	return c.withPreparedBuild(ctx, opts, func(b *preparedBuild) error {
+		return c.executeBuild(ctx, b)
+	})
+}
+
+// preparedBuild is a build whose builder, run image and buildpacks are ready, which the lifecycle can execute as many
+// times as needed
+type preparedBuild struct {
+	lifecycleOpts build.LifecycleOptions
+	imageRef      name.Reference
+	publish       bool
+	// signing is what the app image is signed with once it is built, or nil when it isn't signed
+	signing *imageSigning
+}
+
+// executeBuild runs the lifecycle to build the app image
+func (c *Client) executeBuild(ctx context.Context, b *preparedBuild) error {
+	c.enforceCachePolicy(ctx, b.imageRef)
+
+	startedOn := time.Now()
+	if err := c.lifecycleExecutor.Execute(ctx, b.lifecycleOpts); err != nil {
+		return fmt.Errorf("executing lifecycle: %w", err)
+	}
+	if err := c.logImageNameAndSha(ctx, b.publish, b.imageRef); err != nil {
+		return err
+	}
+	return c.signImage(ctx, b, startedOn)
+}
+
+// withPreparedBuild fetches and creates everything the build needs, such as the ephemeral builder, and calls execute
+// with the prepared build. Anything created for the build is cleaned up once execute returns.
+func (c *Client) withPreparedBuild(ctx context.Context, opts BuildOptions, execute func(b *preparedBuild) error) error {
 	var pathsConfig layoutPathConfig
 
+	if len(opts.Targets) == 1 {
+		opts.Platform = platformString(opts.Targets[0])
+	}
+
 	if RunningInContainer() && !(opts.PullPolicy == image.PullAlways) {
 		c.logger.Warnf("Detected pack is running in a container; if using a shared docker host, failing to pull build inputs from a remote registry is insecure - " +
 			"other tenants may have compromised build inputs stored in the daemon." +
@@ -329,6 +432,19 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 
 	proxyConfig := c.processProxyConfig(opts.ProxyConfig)
 
+	builderPullPolicy := opts.PullPolicy
+	builderInput := ParseInputImageReference(opts.Builder)
+	if builderInput.Layout() {
+		builderPath, err := builderInput.FullName()
+		if err != nil {
+			return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
+		}
+		if opts.Builder, err = c.loadLayoutBuilder(ctx, opts.Builder, builderPath); err != nil {
+			return err
+		}
+		builderPullPolicy = image.PullNever
+	}
+
 	builderRef, err := c.processBuilderName(opts.Builder)
 	if err != nil {
 		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
@@ -357,12 +473,22 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		image.FetchOptions{
 			Daemon:     true,
 			Target:     requestedTarget,
-			PullPolicy: opts.PullPolicy},
+			PullPolicy: builderPullPolicy},
 	)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
 	}
 
+	// a builder in an OCI layout is locked and verified when it is loaded, rather than by the name it is loaded as
+	if !builderInput.Layout() {
+		if err = c.lockImage(ctx, lockfile.KindBuilder, builderRef.Name(), rawBuilderImage); err != nil {
+			return err
+		}
+		if err = c.verifyImage(ctx, lockfile.KindBuilder, builderRef.Name(), rawBuilderImage); err != nil {
+			return err
+		}
+	}
+
 	var targetToUse *dist.Target
 	if requestedTarget != nil {
 		targetToUse = requestedTarget
@@ -404,6 +530,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	if err != nil {
 		return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
 	}
+	if err = c.lockImage(ctx, lockfile.KindRunImage, runImageName, runImage); err != nil {
+		return err
+	}
+	if err = c.verifyImage(ctx, lockfile.KindRunImage, runImageName, runImage); err != nil {
+		return err
+	}
 
 	var runMixins []string
 	if _, err := dist.GetLabel(runImage, stack.MixinsLabel, &runMixins); err != nil {
@@ -476,6 +608,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 			if err != nil {
 				return fmt.Errorf("fetching lifecycle image: %w", err)
 			}
+			if err = c.lockImage(ctx, lockfile.KindLifecycle, lifecycleImageName, lifecycleImage); err != nil {
+				return err
+			}
+			if err = c.verifyImage(ctx, lockfile.KindLifecycle, lifecycleImageName, lifecycleImage); err != nil {
+				return err
+			}
 
 			// if lifecyle container os isn't windows, use ephemeral lifecycle to add /workspace with correct ownership
 			imageOS, err := lifecycleImage.OS()
@@ -638,6 +776,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		CreationTime:             opts.CreationTime,
 		Layout:                   opts.Layout(),
 		Keychain:                 c.keychain,
+		EventSink:                opts.EventSink,
 	}
 
 	switch {
@@ -797,10 +936,31 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return ephemeralRunImageName, nil
 	}
 
-	if err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
-		return fmt.Errorf("executing lifecycle: %w", err)
+	var signing *imageSigning
+	if opts.SignKey != nil {
+		if signing, err = c.prepareSigning(ctx, opts, rawBuilderImage, builderRef.Name(), lifecycleVersion, projectMetadata.Source, pathsConfig.hostImagePath); err != nil {
+			return err
+		}
 	}
-	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
+	if signing != nil && opts.Publish {
+		// the published image is signed by the digest the lifecycle reports, so the report is copied out of the build
+		if lifecycleOpts.ReportDestinationDir == "" {
+			reportDir, err := os.MkdirTemp("", "pack-build-report")
+			if err != nil {
+				return errors.Wrap(err, "creating temp dir for lifecycle report")
+			}
+			defer os.RemoveAll(reportDir)
+			lifecycleOpts.ReportDestinationDir = reportDir
+		}
+		signing.reportDir = lifecycleOpts.ReportDestinationDir
+	}
+
+	return execute(&preparedBuild{
+		lifecycleOpts: lifecycleOpts,
+		imageRef:      imageRef,
+		publish:       opts.Publish,
+		signing:       signing,
+	})
 }
 
 func getTargetFromBuilder(builderImage imgutil.Image) (*dist.Target, error) {
@@ -1311,6 +1471,7 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 			RelativeBaseDir: relativeBaseDir,
 			Daemon:          !publish,
 			PullPolicy:      pullPolicy,
+			Verifier:        c.moduleVerifier(),
 		}
 		if kind == buildpack.KindExtension {
 			downloadOptions.ModuleKind = kind
@@ -1332,6 +1493,10 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 			}
 			fetchedBPs = append(fetchedBPs, fetchedDeps...)
 		}
+
+		if err = c.lockModules(kind, bp, locatorType, targetToUse, fetchedBPs); err != nil {
+			return nil, nil, err
+		}
 	}
 	return fetchedBPs, moduleInfo, nil
 }
@@ -1348,6 +1513,7 @@ func (c *Client) fetchBuildpackDependencies(ctx context.Context, bp string, pack
 				Daemon:          downloadOptions.Daemon,
 				PullPolicy:      downloadOptions.PullPolicy,
 				RelativeBaseDir: filepath.Join(bp, packageCfg.Buildpack.URI),
+				Verifier:        downloadOptions.Verifier,
 			})
 
 			if err != nil {
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/ocilayout"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// platformBuild is the outcome of building the application image for a single target
+type platformBuild struct {
+	target dist.Target
+	// layoutPath is the OCI layout the per-platform image was exported to
+	layoutPath string
+}
+
+// buildMultiPlatform runs one lifecycle execution per target and assembles the resulting images into a single image index.
+// The index is pushed to the registry when publishing, otherwise it is written as an OCI layout at the requested path.
+func (c *Client) buildMultiPlatform(ctx context.Context, opts BuildOptions) error {
+	if !opts.Publish && !opts.Layout() {
+		return errors.New("building for multiple platforms requires publishing to a registry or exporting to OCI layout format")
+	}
+	if opts.PullPolicy != image.PullAlways {
+		return errors.New("pull policy must be 'always' when building for multiple platforms")
+	}
+	if opts.SignKey != nil {
+		return errors.New("signing the app image is not supported when building for multiple platforms")
+	}
+	if ParseInputImageReference(opts.Builder).Layout() {
+		return errors.New("builders in OCI layout format are not supported when building for multiple platforms")
+	}
+	if opts.PreviousImage != "" {
+		return errors.New("previous image is not supported when building for multiple platforms")
+	}
+	if opts.Layout() && opts.LayoutConfig.Sparse {
+		return errors.New("sparse OCI layout export is not supported when building for multiple platforms")
+	}
+
+	imageRef, err := c.parseReference(opts)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+	}
+	imageTag, ok := imageRef.(name.Tag)
+	if !ok {
+		return errors.Errorf("'%s' is not a tag reference", opts.Image)
+	}
+
+	var indexTags []string
+	for _, tag := range opts.AdditionalTags {
+		tagRef, err := name.NewTag(tag, name.WeakValidation)
+		if err != nil {
+			return errors.Wrapf(err, "invalid additional tag '%s'", tag)
+		}
+		if tagRef.Context().Name() != imageTag.Context().Name() {
+			return errors.Errorf("additional tag %s must be in the same repository as %s when building for multiple platforms", style.Symbol(tag), style.Symbol(imageTag.Context().Name()))
+		}
+		indexTags = append(indexTags, tagRef.TagStr())
+	}
+
+	layoutDir, err := os.MkdirTemp("", "pack-multi-platform-build")
+	if err != nil {
+		return errors.Wrap(err, "creating temp dir for OCI layout images")
+	}
+	defer os.RemoveAll(layoutDir)
+
+	var builds []platformBuild
+	for _, target := range dist.ExpandTargetsDistributions(opts.Targets...) {
+		c.logger.Infof("Building image %s for platform %s", style.Symbol(imageTag.Name()), style.Symbol(target.ValuesAsPlatform()))
+		result, err := c.buildPlatform(ctx, opts, target, layoutDir)
+		if err != nil {
+			return errors.Wrapf(err, "building for platform %s", style.Symbol(target.ValuesAsPlatform()))
+		}
+		builds = append(builds, result)
+	}
+
+	if opts.Layout() {
+		return c.saveLayoutIndex(opts.LayoutConfig.InputImage, imageTag, builds)
+	}
+	return c.pushBuildIndex(ctx, imageTag, indexTags, builds)
+}
+
+// buildPlatform builds the application image for the given target. Each platform is exported to its own OCI layout
+// directory, so that it can then be pushed by digest and referenced from the image index without being tagged.
+func (c *Client) buildPlatform(ctx context.Context, opts BuildOptions, target dist.Target, layoutDir string) (platformBuild, error) {
+	suffix := platformSuffix(target)
+
+	targetOpts := opts
+	targetOpts.Targets = nil
+	targetOpts.Platform = platformString(target)
+	targetOpts.AdditionalTags = nil
+	// the image is pushed to the registry once all platforms are built
+	targetOpts.Publish = false
+	if opts.CacheImage != "" {
+		targetOpts.CacheImage = fmt.Sprintf("%s-%s", opts.CacheImage, suffix)
+	}
+	if opts.ReportDestinationDir != "" {
+		targetOpts.ReportDestinationDir = filepath.Join(opts.ReportDestinationDir, suffix)
+	}
+
+	layoutConfig := LayoutConfig{LayoutRepoDir: filepath.Join(layoutDir, "repo")}
+	if opts.Layout() {
+		layoutConfig = *opts.LayoutConfig
+	}
+	layoutConfig.InputImage = ParseInputImageReference("oci:" + filepath.Join(layoutDir, suffix))
+	layoutConfig.PreviousInputImage = nil
+	// run images for different platforms can't share the same layout directory
+	layoutConfig.LayoutRepoDir = filepath.Join(layoutConfig.LayoutRepoDir, suffix)
+	targetOpts.LayoutConfig = &layoutConfig
+	targetOpts.Image = layoutConfig.InputImage.Name()
+
+	if err := c.Build(ctx, targetOpts); err != nil {
+		return platformBuild{}, err
+	}
+	layoutPath, err := layoutConfig.InputImage.FullName()
+	if err != nil {
+		return platformBuild{}, err
+	}
+	return platformBuild{target: target, layoutPath: layoutPath}, nil
+}
+
+// pushBuildIndex pushes the image of each platform by digest, then the image index referencing them
+func (c *Client) pushBuildIndex(ctx context.Context, imageTag name.Tag, tags []string, builds []platformBuild) error {
+	var images []v1.Image
+	for _, b := range builds {
+		img, _, err := readPlatformImage(b)
+		if err != nil {
+			return err
+		}
+		images = append(images, img)
+	}
+
+	if c.indexFactory.Exists(imageTag.Name()) {
+		return fmt.Errorf("manifest list '%s' already exists in local storage; use 'pack manifest remove' to "+
+			"remove it before building a multi-platform image with the same name", style.Symbol(imageTag.Name()))
+	}
+
+	idx, err := c.indexFactory.CreateIndex(imageTag.Name(), imgutil.WithMediaType(types.OCIImageIndex))
+	if err != nil {
+		return err
+	}
+
+	for i, img := range images {
+		platform := builds[i].target.ValuesAsPlatform()
+		digest, err := img.Digest()
+		if err != nil {
+			return errors.Wrapf(err, "getting digest of image for platform %s", style.Symbol(platform))
+		}
+		digestRef := imageTag.Context().Digest(digest.String())
+		if err := remote.Write(digestRef, img, remote.WithContext(ctx), remote.WithAuthFromKeychain(c.keychain)); err != nil {
+			return errors.Wrapf(err, "pushing image for platform %s", style.Symbol(platform))
+		}
+		c.logger.Debugf("Pushed image for platform %s as %s", style.Symbol(platform), style.Symbol(digestRef.Name()))
+		idx.AddManifest(img)
+	}
+
+	if err = idx.Push(imgutil.WithMediaType(types.OCIImageIndex), imgutil.WithPurge(true), imgutil.WithTags(tags...)); err != nil {
+		return errors.Wrapf(err, "pushing image index %s", style.Symbol(imageTag.Name()))
+	}
+
+	c.logger.Infof("Successfully pushed image index %s with %d platforms", style.Symbol(imageTag.Name()), len(builds))
+	return nil
+}
+
+func (c *Client) saveLayoutIndex(inputImage InputImageReference, imageTag name.Tag, builds []platformBuild) error {
+	indexPath, err := fullImagePath(inputImage, true)
+	if err != nil {
+		return err
+	}
+
+	p, err := layout.Write(indexPath, mutate.IndexMediaType(empty.Index, types.OCIImageIndex))
+	if err != nil {
+		return errors.Wrap(err, "writing index")
+	}
+
+	for _, b := range builds {
+		img, configFile, err := readPlatformImage(b)
+		if err != nil {
+			return err
+		}
+		if err = p.AppendImage(img,
+			layout.WithPlatform(v1.Platform{OS: configFile.OS, Architecture: configFile.Architecture, Variant: configFile.Variant, OSVersion: configFile.OSVersion}),
+			layout.WithAnnotations(map[string]string{"org.opencontainers.image.ref.name": imageTag.TagStr()}),
+		); err != nil {
+			return errors.Wrapf(err, "writing platform %s to OCI layout", style.Symbol(b.target.ValuesAsPlatform()))
+		}
+	}
+
+	c.logger.Infof("Successfully saved image index with %d platforms to %s", len(builds), style.Symbol(indexPath))
+	return nil
+}
+
+// readPlatformImage reads the image built for a platform, checking it was built for the platform
+func readPlatformImage(b platformBuild) (v1.Image, *v1.ConfigFile, error) {
+	_, img, err := ocilayout.ReadImage(b.layoutPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "reading OCI layout image for platform %s", style.Symbol(b.target.ValuesAsPlatform()))
+	}
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, nil, err
+	}
+	if configFile.OS != b.target.OS || (b.target.Arch != "" && configFile.Architecture != b.target.Arch) {
+		return nil, nil, errors.Errorf("image for platform %s was built for %s", style.Symbol(b.target.ValuesAsPlatform()), style.Symbol(configFile.OS+"/"+configFile.Architecture))
+	}
+	return img, configFile, nil
+}
+
+// platformString returns the os[/arch[/variant]] representation of the target, without distribution information.
+func platformString(target dist.Target) string {
+	t := dist.Target{OS: target.OS, Arch: target.Arch, ArchVariant: target.ArchVariant}
+	return t.ValuesAsPlatform()
+}
+
+// platformSuffix returns a tag-safe representation of the target, e.g. linux-arm64-v8
+func platformSuffix(target dist.Target) string {
+	var parts []string
+	for _, v := range target.ValuesAsSlice() {
+		parts = append(parts, strings.NewReplacer("@", "-", ".", "-").Replace(v))
+	}
+	return strings.Join(parts, "-")
+}
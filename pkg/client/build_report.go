@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildReportFile is the name of the report pack writes to the report destination directory, next to the lifecycle's
+// report.toml
+const BuildReportFile = "pack-report.json"
+
+// buildReport records where the time of a build went
+type buildReport struct {
+	mutex sync.Mutex
+
+	Image        string             `json:"image"`
+	StartedAt    time.Time          `json:"startedAt"`
+	DurationMS   int64              `json:"durationMs"`
+	Error        string             `json:"error,omitempty"`
+	Phases       []phaseTiming      `json:"phases"`
+	ImagePulls   []imagePullTiming  `json:"imagePulls"`
+	AppUploadMS  int64              `json:"appUploadMs"`
+	CacheVolumes []cacheVolumeUsage `json:"cacheVolumes"`
+}
+
+type phaseTiming struct {
+	Name       string `json:"name"`
+	DurationMS int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+type imagePullTiming struct {
+	Image      string `json:"image"`
+	DurationMS int64  `json:"durationMs"`
+}
+
+type cacheVolumeUsage struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// Size of the volume in bytes, or -1 if unknown.
+	Size int64 `json:"size"`
+}
+
+func newBuildReport(imageName string) *buildReport {
+	return &buildReport{
+		Image:        imageName,
+		StartedAt:    time.Now(),
+		Phases:       []phaseTiming{},
+		ImagePulls:   []imagePullTiming{},
+		CacheVolumes: []cacheVolumeUsage{},
+	}
+}
+
+func (r *buildReport) recordEvent(event logging.Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch event.Type {
+	case logging.EventPhaseFinished:
+		r.Phases = append(r.Phases, phaseTiming{Name: event.Phase, DurationMS: event.Duration.Milliseconds(), Error: event.Error})
+	case logging.EventAppUploaded:
+		r.AppUploadMS += event.Duration.Milliseconds()
+	}
+}
+
+func (r *buildReport) recordPull(imageName string, duration time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.ImagePulls = append(r.ImagePulls, imagePullTiming{Image: imageName, DurationMS: duration.Milliseconds()})
+}
+
+func (r *buildReport) write(dir string) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	contents, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, BuildReportFile), append(contents, '\n'), 0644)
+}
+
+// reportingImageFetcher records how long fetching each image takes, including pulling it when needed
+type reportingImageFetcher struct {
+	ImageFetcher
+	report *buildReport
+}
+
+func (f *reportingImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	start := time.Now()
+	img, err := f.ImageFetcher.Fetch(ctx, name, options)
+	if err == nil {
+		f.report.recordPull(name, time.Since(start))
+	}
+	return img, err
+}
+
+// buildWithReport builds the image, writing the time spent in each part of the build and the size of the cache volumes
+// to pack-report.json in the report destination directory. The report is written even if the build fails.
+func (c *Client) buildWithReport(ctx context.Context, opts BuildOptions) error {
+	report := newBuildReport(opts.Image)
+
+	reporting := *c
+	reporting.imageFetcher = &reportingImageFetcher{ImageFetcher: c.imageFetcher, report: report}
+	if !c.customBuildpackDownloader {
+		// buildpack images are fetched with the image fetcher the buildpack downloader was created with
+		reporting.buildpackDownloader = reporting.newBuildpackDownloader()
+	}
+	eventSink := opts.EventSink
+	opts.EventSink = func(event logging.Event) {
+		report.recordEvent(event)
+		if eventSink != nil {
+			eventSink(event)
+		}
+	}
+
+	buildErr := reporting.build(ctx, opts)
+	report.DurationMS = time.Since(report.StartedAt).Milliseconds()
+	if buildErr != nil {
+		report.Error = buildErr.Error()
+	}
+	report.CacheVolumes = c.cacheVolumeUsage(ctx, opts.Image)
+
+	if err := report.write(opts.ReportDestinationDir); err != nil {
+		if buildErr != nil {
+			c.logger.Warnf("Unable to write build report: %s", err)
+			return buildErr
+		}
+		return errors.Wrap(err, "writing build report")
+	}
+	return buildErr
+}
+
+// cacheVolumeUsage returns the size of the cache volumes of the given image
+func (c *Client) cacheVolumeUsage(ctx context.Context, imageName string) []cacheVolumeUsage {
+	usage := []cacheVolumeUsage{}
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return usage
+	}
+
+	volumes, err := c.ListCaches(ctx)
+	if err != nil {
+		c.logger.Debugf("Unable to read cache volume sizes: %s", err)
+		return usage
+	}
+	for _, v := range volumes {
+		if v.Image == ref.Name() {
+			usage = append(usage, cacheVolumeUsage{Name: v.Name, Kind: v.Kind, Size: v.Size})
+		}
+	}
+	return usage
+}
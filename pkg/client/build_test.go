@@ -6,14 +6,18 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
@@ -22,13 +26,24 @@ import (
 	"github.com/buildpacks/imgutil/remote"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/heroku/color"
 	"github.com/onsi/gomega/ghttp"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
 	cfg "github.com/buildpacks/pack/internal/config"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
@@ -36,10 +51,14 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/lockfile"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/signature"
+	"github.com/buildpacks/pack/pkg/testmocks"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -2377,6 +2396,224 @@ api = "0.2"
 			})
 		})
 
+		when("cache policy is configured", func() {
+			var (
+				mockController *gomock.Controller
+				mockDocker     *testmocks.MockCommonAPIClient
+			)
+
+			it.Before(func() {
+				mockController = gomock.NewController(t)
+				mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+				mockDocker.EXPECT().ImageRemove(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+				subject.docker = mockDocker
+				t.Setenv("PACK_HOME", tmpDir)
+				h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "volume-keys.toml"), []byte(`
+[volume-keys]
+"index.docker.io/some/app:latest" = "some-key"
+"index.docker.io/other/app:latest" = "other-key"
+`), 0600))
+			})
+
+			it.After(func() {
+				mockController.Finish()
+			})
+
+			it("evicts least recently used volumes of other images before running the lifecycle", func() {
+				ref, err := name.ParseReference("some/app", name.WeakValidation)
+				h.AssertNil(t, err)
+				appCache, err := cache.NewVolumeCache(ref, cache.CacheInfo{}, "build", mockDocker, logger)
+				h.AssertNil(t, err)
+				ref, err = name.ParseReference("other/app", name.WeakValidation)
+				h.AssertNil(t, err)
+				otherCache, err := cache.NewVolumeCache(ref, cache.CacheInfo{}, "build", mockDocker, logger)
+				h.AssertNil(t, err)
+
+				mockDocker.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(types.DiskUsage{Volumes: []*volume.Volume{
+					{Name: appCache.Name(), UsageData: &volume.UsageData{Size: 300}},
+					{Name: otherCache.Name(), UsageData: &volume.UsageData{Size: 300}},
+				}}, nil)
+				mockDocker.EXPECT().VolumeRemove(gomock.Any(), otherCache.Name(), false).Return(nil)
+				subject.cachePolicy = &cache.PruneOptions{MaxTotalSize: 500}
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+			})
+
+			it("doesn't fail the build when the policy can't be enforced", func() {
+				mockDocker.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(types.DiskUsage{}, errors.New("daemon unavailable"))
+				subject.cachePolicy = &cache.PruneOptions{MaxAge: time.Hour}
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertContains(t, outBuf.String(), "Unable to enforce cache policy: listing volumes: daemon unavailable")
+			})
+		})
+
+		when("Targets option", func() {
+			var (
+				mockController   *gomock.Controller
+				mockIndexFactory *testmocks.MockIndexFactory
+				index            *h.MockImageIndex
+				server           *httptest.Server
+				appRepo          string
+				indexRepoName    string
+				builtPlatforms   map[string]string
+			)
+
+			it.Before(func() {
+				mockController = gomock.NewController(t)
+				mockIndexFactory = testmocks.NewMockIndexFactory(mockController)
+				subject.indexFactory = mockIndexFactory
+
+				server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+				appRepo = strings.TrimPrefix(server.URL, "http://") + "/some/app"
+				indexRepoName = appRepo + ":latest"
+
+				fakeImageFetcher.RemoteImages["default/run"] = fakeDefaultRunImage
+
+				// the lifecycle exports the image of each platform to the OCI layout mounted writable, whose directory is
+				// named after the platform, e.g. 'linux-arm64'
+				builtPlatforms = map[string]string{}
+				fakeLifecycle.ExecuteFunc = func(opts build.LifecycleOptions) error {
+					for _, volume := range opts.Volumes {
+						if !strings.HasSuffix(volume, ":rw") {
+							continue
+						}
+						layoutPath := strings.SplitN(volume, ":", 2)[0]
+						platform := strings.Replace(filepath.Base(layoutPath), "-", "/", 1)
+						if built, ok := builtPlatforms[platform]; ok {
+							platform = built
+						}
+						img, err := random.Image(1024, 1)
+						if err != nil {
+							return err
+						}
+						config, err := img.ConfigFile()
+						if err != nil {
+							return err
+						}
+						config.OS, config.Architecture = strings.Split(platform, "/")[0], strings.Split(platform, "/")[1]
+						if img, err = mutate.ConfigFile(img, config); err != nil {
+							return err
+						}
+						p, err := layout.Write(layoutPath, empty.Index)
+						if err != nil {
+							return err
+						}
+						return p.AppendImage(img)
+					}
+					return nil
+				}
+			})
+
+			it.After(func() {
+				mockController.Finish()
+				server.Close()
+			})
+
+			when("more than one target is provided", func() {
+				it("builds each platform, pushes it by digest and pushes an image index", func() {
+					index = h.NewMockImageIndex(t, indexRepoName, 0, 0)
+					mockIndexFactory.EXPECT().Exists(gomock.Eq(indexRepoName)).Return(false)
+					mockIndexFactory.EXPECT().CreateIndex(gomock.Eq(indexRepoName), gomock.Any()).Return(index, nil)
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      appRepo,
+						Builder:    defaultBuilderName,
+						Publish:    true,
+						PullPolicy: image.PullAlways,
+						Targets: []dist.Target{
+							{OS: "linux", Arch: "amd64"},
+							{OS: "linux", Arch: "arm64"},
+						},
+					}))
+
+					h.AssertEq(t, fakeLifecycle.Executions(), 2)
+					h.AssertTrue(t, fakeLifecycle.Opts.Layout)
+					h.AssertFalse(t, fakeLifecycle.Opts.Publish)
+					h.AssertEq(t, fakeImageFetcher.FetchCalls[defaultBuilderName].Target.ValuesAsPlatform(), "linux/arm64")
+
+					h.AssertTrue(t, index.PushCalled)
+					h.AssertTrue(t, index.PurgeOption)
+					indexManifest, err := index.IndexManifest()
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(indexManifest.Manifests), 2)
+
+					repo, err := name.NewRepository(appRepo)
+					h.AssertNil(t, err)
+					for _, manifest := range indexManifest.Manifests {
+						_, err := ggcrremote.Get(repo.Digest(manifest.Digest.String()))
+						h.AssertNil(t, err)
+					}
+					tags, err := ggcrremote.List(repo)
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(tags), 0)
+				})
+
+				it("fails when a built image doesn't match the requested platform", func() {
+					builtPlatforms["linux/arm64"] = "linux/amd64"
+
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:      appRepo,
+						Builder:    defaultBuilderName,
+						Publish:    true,
+						PullPolicy: image.PullAlways,
+						Targets: []dist.Target{
+							{OS: "linux", Arch: "amd64"},
+							{OS: "linux", Arch: "arm64"},
+						},
+					})
+					h.AssertError(t, err, "image for platform 'linux/arm64' was built for 'linux/amd64'")
+				})
+
+				it("requires publishing or exporting to OCI layout", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						PullPolicy: image.PullAlways,
+						Targets: []dist.Target{
+							{OS: "linux", Arch: "amd64"},
+							{OS: "linux", Arch: "arm64"},
+						},
+					})
+					h.AssertError(t, err, "building for multiple platforms requires publishing to a registry or exporting to OCI layout format")
+				})
+
+				it("requires the pull policy to be always", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						Publish:    true,
+						PullPolicy: image.PullIfNotPresent,
+						Targets: []dist.Target{
+							{OS: "linux", Arch: "amd64"},
+							{OS: "linux", Arch: "arm64"},
+						},
+					})
+					h.AssertError(t, err, "pull policy must be 'always' when building for multiple platforms")
+				})
+			})
+
+			when("a single target is provided", func() {
+				it("builds a single image for the target", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						PullPolicy: image.PullAlways,
+						Targets:    []dist.Target{{OS: "linux", Arch: "arm64"}},
+					}))
+
+					h.AssertEq(t, fakeLifecycle.Opts.Image.Name(), "index.docker.io/some/app:latest")
+					h.AssertEq(t, fakeImageFetcher.FetchCalls[defaultBuilderName].Target.ValuesAsPlatform(), "linux/arm64")
+				})
+			})
+		})
+
 		when("PullPolicy", func() {
 			when("never", func() {
 				it("uses the local builder and run images without updating", func() {
@@ -3152,13 +3389,365 @@ api = "0.2"
 		})
 
 		when("report destination dir option", func() {
+			var reportDir string
+
+			it.Before(func() {
+				reportDir = filepath.Join(tmpDir, "a-destination-dir")
+			})
+
 			it("passthroughs to lifecycle", func() {
 				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
 					Builder:              defaultBuilderName,
 					Image:                "example.com/some/repo:tag",
-					ReportDestinationDir: "a-destination-dir",
+					ReportDestinationDir: reportDir,
 				}))
-				h.AssertEq(t, fakeLifecycle.Opts.ReportDestinationDir, "a-destination-dir")
+				h.AssertEq(t, fakeLifecycle.Opts.ReportDestinationDir, reportDir)
+			})
+
+			it("writes the time spent in each part of the build to pack-report.json", func() {
+				fakeLifecycle.Events = []logging.Event{
+					{Type: logging.EventPhaseStarted, Phase: "creator"},
+					{Type: logging.EventAppUploaded, Phase: "creator", Duration: 2 * time.Second},
+					{Type: logging.EventPhaseFinished, Phase: "creator", Duration: 90 * time.Second},
+				}
+				var events []logging.Event
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:              defaultBuilderName,
+					Image:                "example.com/some/repo:tag",
+					ReportDestinationDir: reportDir,
+					EventSink: func(event logging.Event) {
+						events = append(events, event)
+					},
+				}))
+				h.AssertEq(t, len(events), 3)
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, "pack-report.json"))
+				h.AssertNil(t, err)
+				var report struct {
+					Image  string
+					Phases []struct {
+						Name       string
+						DurationMS int64 `json:"durationMs"`
+					}
+					ImagePulls []struct {
+						Image string
+					} `json:"imagePulls"`
+					AppUploadMS int64 `json:"appUploadMs"`
+				}
+				h.AssertNil(t, json.Unmarshal(contents, &report))
+				h.AssertEq(t, report.Image, "example.com/some/repo:tag")
+				h.AssertEq(t, len(report.Phases), 1)
+				h.AssertEq(t, report.Phases[0].Name, "creator")
+				h.AssertEq(t, report.Phases[0].DurationMS, int64(90000))
+				h.AssertEq(t, report.AppUploadMS, int64(2000))
+				var pulled []string
+				for _, pull := range report.ImagePulls {
+					pulled = append(pulled, pull.Image)
+				}
+				h.AssertSliceContains(t, pulled, defaultBuilderName, "default/run")
+			})
+
+			it("records the pulls of buildpack images", func() {
+				fakePackage := makeFakePackage(t, tmpDir, defaultBuilderStackID)
+				fakeImageFetcher.LocalImages[fakePackage.Name()] = fakePackage
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:              defaultBuilderName,
+					Image:                "example.com/some/repo:tag",
+					Buildpacks:           []string{"example.com/some/package"},
+					ReportDestinationDir: reportDir,
+				}))
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, "pack-report.json"))
+				h.AssertNil(t, err)
+				var report struct {
+					ImagePulls []struct {
+						Image string
+					} `json:"imagePulls"`
+				}
+				h.AssertNil(t, json.Unmarshal(contents, &report))
+				var pulled []string
+				for _, pull := range report.ImagePulls {
+					pulled = append(pulled, pull.Image)
+				}
+				h.AssertSliceContains(t, pulled, "example.com/some/package")
+			})
+		})
+
+		when("lockfile option", func() {
+			var (
+				lockfilePath  string
+				lockedBuilder *fakes.Image
+				lockedRun     *fakes.Image
+			)
+
+			it.Before(func() {
+				lockfilePath = filepath.Join(tmpDir, "pack.lock")
+
+				lockedBuilder = newFakeBuilderImage(t, tmpDir, "example.com/locked/builder:latest", defaultBuilderStackID, "example.com/locked/run", builder.DefaultLifecycleVersion,
+					func(name, topLayerSha string, _ imgutil.Identifier) *fakes.Image {
+						return newLinuxImage(name, topLayerSha, &fakeIdentifier{name: "sha256:builder"})
+					})
+				fakeImageFetcher.LocalImages[lockedBuilder.Name()] = lockedBuilder
+
+				lockedRun = newLinuxImage("example.com/locked/run", "", &fakeIdentifier{name: "example.com/locked/run@sha256:run"})
+				h.AssertNil(t, lockedRun.SetLabel("io.buildpacks.stack.id", defaultBuilderStackID))
+				fakeImageFetcher.LocalImages[lockedRun.Name()] = lockedRun
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, lockedBuilder.Cleanup())
+				h.AssertNilE(t, lockedRun.Cleanup())
+			})
+
+			build := func() error {
+				return subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      lockedBuilder.Name(),
+					TrustBuilder: func(string) bool { return true },
+					Lockfile:     lockfilePath,
+				})
+			}
+
+			it("writes the digests of the builder and the run image to the lockfile", func() {
+				h.AssertNil(t, build())
+
+				written, err := lockfile.Read(lockfilePath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(written.Entries), 2)
+				h.AssertEq(t, written.Entries[0].Kind, lockfile.KindBuilder)
+				h.AssertEq(t, written.Entries[0].Ref, "example.com/locked/builder:latest")
+				h.AssertEq(t, written.Entries[0].Digest, "sha256:builder")
+				h.AssertEq(t, written.Entries[1].Kind, lockfile.KindRunImage)
+				h.AssertEq(t, written.Entries[1].Ref, "example.com/locked/run")
+				h.AssertEq(t, written.Entries[1].Digest, "sha256:run")
+			})
+
+			it("builds when the references still resolve to the locked digests", func() {
+				h.AssertNil(t, build())
+				h.AssertNil(t, build())
+				h.AssertEq(t, fakeLifecycle.Executions(), 2)
+			})
+
+			it("fails before building when a reference resolves to a different digest", func() {
+				h.AssertNil(t, build())
+
+				fakeImageFetcher.LocalImages[lockedRun.Name()] = newLinuxImage("example.com/locked/run", "", &fakeIdentifier{name: "example.com/locked/run@sha256:new-run"})
+				h.AssertNil(t, fakeImageFetcher.LocalImages[lockedRun.Name()].SetLabel("io.buildpacks.stack.id", defaultBuilderStackID))
+
+				h.AssertError(t, build(), "run-image 'example.com/locked/run' resolved to 'sha256:new-run', which doesn't match 'sha256:run'")
+				h.AssertEq(t, fakeLifecycle.Executions(), 1)
+			})
+
+			when("the builder is identified by its ID in the daemon", func() {
+				var (
+					otherDigest    = "sha256:" + strings.Repeat("0", 64)
+					server         *httptest.Server
+					builderRepo    string
+					indexDigest    string
+					amd64Digest    string
+					mockController *gomock.Controller
+					mockDocker     *testmocks.MockCommonAPIClient
+				)
+
+				it.Before(func() {
+					mockController = gomock.NewController(t)
+					mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+					subject.docker = mockDocker
+
+					server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+					builderRepo = strings.TrimPrefix(server.URL, "http://") + "/locked/builder"
+
+					// the builder was pulled from an index for several platforms, so its repository digest is the digest
+					// of the index rather than of its manifest
+					amd64Image, err := random.Image(1024, 1)
+					h.AssertNil(t, err)
+					arm64Image, err := random.Image(1024, 1)
+					h.AssertNil(t, err)
+					index := mutate.AppendManifests(empty.Index,
+						mutate.IndexAddendum{Add: arm64Image, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+						mutate.IndexAddendum{Add: amd64Image, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+					)
+					indexRef, err := name.ParseReference(builderRepo + ":latest")
+					h.AssertNil(t, err)
+					h.AssertNil(t, ggcrremote.WriteIndex(indexRef, index))
+					hash, err := index.Digest()
+					h.AssertNil(t, err)
+					indexDigest = hash.String()
+					hash, err = amd64Image.Digest()
+					h.AssertNil(t, err)
+					amd64Digest = hash.String()
+					configName, err := amd64Image.ConfigName()
+					h.AssertNil(t, err)
+
+					h.AssertNilE(t, lockedBuilder.Cleanup())
+					lockedBuilder = newFakeBuilderImage(t, tmpDir, builderRepo+":latest", defaultBuilderStackID, "example.com/locked/run", builder.DefaultLifecycleVersion,
+						func(name, topLayerSha string, _ imgutil.Identifier) *fakes.Image {
+							return newLinuxImage(name, topLayerSha, local.IDIdentifier{ImageID: configName.String()})
+						})
+					fakeImageFetcher.LocalImages[lockedBuilder.Name()] = lockedBuilder
+				})
+
+				it.After(func() {
+					mockController.Finish()
+					server.Close()
+				})
+
+				it("locks the digest of the manifest of its platform, as a build with the builder in the registry does", func() {
+					mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), gomock.Any()).Return(types.ImageInspect{
+						RepoDigests: []string{"example.com/other/builder@" + otherDigest, builderRepo + "@" + indexDigest},
+					}, nil, nil)
+
+					h.AssertNil(t, build())
+
+					written, err := lockfile.Read(lockfilePath)
+					h.AssertNil(t, err)
+					h.AssertEq(t, written.Entries[0].Kind, lockfile.KindBuilder)
+					h.AssertEq(t, written.Entries[0].Digest, amd64Digest)
+				})
+
+				it("doesn't lock the builder when it has no digest in its repository", func() {
+					mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), gomock.Any()).Return(types.ImageInspect{
+						RepoDigests: []string{"example.com/other/builder@" + otherDigest},
+					}, nil, nil)
+
+					h.AssertNil(t, build())
+
+					written, err := lockfile.Read(lockfilePath)
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(written.Entries), 1)
+					h.AssertEq(t, written.Entries[0].Kind, lockfile.KindRunImage)
+					h.AssertContains(t, outBuf.String(), fmt.Sprintf("Not locking builder '%s:latest', as it was never pulled from or pushed to its registry", builderRepo))
+				})
+			})
+		})
+
+		when("verification option", func() {
+			var (
+				builderDigest   = "sha256:" + strings.Repeat("b", 64)
+				runDigest       = "sha256:" + strings.Repeat("c", 64)
+				verifiedBuilder *fakes.Image
+				verifiedRun     *fakes.Image
+			)
+
+			it.Before(func() {
+				// nothing listens on port 1, so images that aren't allowed fail to resolve in their registry quickly
+				verifiedBuilder = newFakeBuilderImage(t, tmpDir, "localhost:1/verified/builder:latest", defaultBuilderStackID, "localhost:1/verified/run", builder.DefaultLifecycleVersion,
+					func(name, topLayerSha string, _ imgutil.Identifier) *fakes.Image {
+						return newLinuxImage(name, topLayerSha, &fakeIdentifier{name: "localhost:1/verified/builder@" + builderDigest})
+					})
+				fakeImageFetcher.LocalImages[verifiedBuilder.Name()] = verifiedBuilder
+
+				verifiedRun = newLinuxImage("localhost:1/verified/run", "", &fakeIdentifier{name: "localhost:1/verified/run@" + runDigest})
+				h.AssertNil(t, verifiedRun.SetLabel("io.buildpacks.stack.id", defaultBuilderStackID))
+				fakeImageFetcher.LocalImages[verifiedRun.Name()] = verifiedRun
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, verifiedBuilder.Cleanup())
+				h.AssertNilE(t, verifiedRun.Cleanup())
+			})
+
+			build := func(allowedDigests ...string) error {
+				return subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      verifiedBuilder.Name(),
+					TrustBuilder: func(string) bool { return true },
+					Verification: &signature.Policy{AllowedDigests: allowedDigests},
+				})
+			}
+
+			it("builds when the builder and the run image are allowed", func() {
+				h.AssertNil(t, build(builderDigest, runDigest))
+				h.AssertEq(t, fakeLifecycle.Executions(), 1)
+			})
+
+			it("fails before building when the run image isn't allowed or signed", func() {
+				h.AssertError(t, build(builderDigest), "run-image 'localhost:1/verified/run' ("+runDigest+") failed verification")
+				h.AssertEq(t, fakeLifecycle.Executions(), 0)
+			})
+		})
+
+		when("sign key option", func() {
+			it("errors when the image is exported to the daemon", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					SignKey: &signature.PrivateKey{Path: "cosign.key"},
+				}), "signing the app image requires publishing it to a registry or exporting it to OCI layout format")
+				h.AssertEq(t, fakeLifecycle.Executions(), 0)
+			})
+		})
+
+		when("watch option", func() {
+			var appDir string
+
+			it.Before(func() {
+				appDir = filepath.Join(tmpDir, "watched-app")
+				h.AssertNil(t, os.MkdirAll(appDir, 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main"), 0600))
+			})
+
+			it("rebuilds the app when a file sent to the build changes", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				done := make(chan error, 1)
+				go func() {
+					done <- subject.Build(ctx, BuildOptions{
+						Builder:       defaultBuilderName,
+						Image:         "example.com/some/repo:tag",
+						AppPath:       appDir,
+						ClearCache:    true,
+						Watch:         true,
+						WatchDebounce: time.Millisecond,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{Exclude: []string{"*.log"}},
+						},
+					})
+				}()
+				h.Eventually(t, func() bool { return fakeLifecycle.Executions() == 1 }, 10*time.Millisecond, 5*time.Second)
+
+				h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "debug.log"), []byte("ignored"), 0600))
+				h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main\n\nfunc main() {}"), 0600))
+				h.Eventually(t, func() bool { return fakeLifecycle.Executions() == 2 }, 10*time.Millisecond, 5*time.Second)
+
+				cancel()
+				h.AssertNil(t, <-done)
+				h.AssertEq(t, fakeLifecycle.Executions(), 2)
+				h.AssertEq(t, fakeLifecycle.Opts.ClearCache, false)
+				h.AssertContains(t, outBuf.String(), "Detected changes to 'main.go', rebuilding")
+			})
+
+			it("stops watching when the context is cancelled", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+
+				done := make(chan error, 1)
+				go func() {
+					done <- subject.Build(ctx, BuildOptions{
+						Builder: defaultBuilderName,
+						Image:   "example.com/some/repo:tag",
+						AppPath: appDir,
+						Watch:   true,
+					})
+				}()
+				h.Eventually(t, func() bool { return fakeLifecycle.Executions() == 1 }, 10*time.Millisecond, 5*time.Second)
+
+				cancel()
+				h.AssertNil(t, <-done)
+			})
+
+			it("errors when a build report is exported", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Builder:              defaultBuilderName,
+					Image:                "example.com/some/repo:tag",
+					AppPath:              appDir,
+					Watch:                true,
+					ReportDestinationDir: tmpDir,
+				})
+				h.AssertError(t, err, "watching the app can't be combined with exporting a build report")
+				h.AssertEq(t, fakeLifecycle.Executions(), 0)
 			})
 		})
 
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/buildpacks/pack/internal/style"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+// DefaultWatchDebounce is how long the app must stay unchanged before a watched build is rebuilt.
+const DefaultWatchDebounce = 500 * time.Millisecond
+
+// maxReportedChanges is the number of changed files listed when a rebuild is triggered.
+const maxReportedChanges = 5
+
+// watchPollInterval is how often the app is checked for changes.
+const watchPollInterval = 250 * time.Millisecond
+
+// buildAndWatch builds the app, then rebuilds it every time it changes until the context is cancelled. The ephemeral
+// builder and the cache volumes are created once and reused by every rebuild.
+func (c *Client) buildAndWatch(ctx context.Context, opts BuildOptions) error {
+	debounce := opts.WatchDebounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	return c.withPreparedBuild(ctx, opts, func(b *preparedBuild) error {
+		watcher := newAppWatcher(b.lifecycleOpts.AppPath, b.lifecycleOpts.FileFilter, getDirFilter(opts.ProjectDescriptor))
+		if _, err := watcher.scan(); err != nil {
+			return errors.Wrapf(err, "watching app %s", style.Symbol(b.lifecycleOpts.AppPath))
+		}
+
+		for {
+			if err := c.executeBuild(ctx, b); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				c.logger.Errorf("Build failed: %s", err)
+			} else {
+				c.logger.Infof("Successfully built image %s", style.Symbol(opts.Image))
+			}
+			// the cache is only cleared by the first build, rebuilds are incremental
+			b.lifecycleOpts.ClearCache = false
+
+			c.logger.Infof("Watching %s for changes, press Ctrl+C to stop", style.Symbol(b.lifecycleOpts.AppPath))
+			changes, err := watcher.waitForChange(ctx, debounce)
+			if err != nil {
+				return errors.Wrapf(err, "watching app %s", style.Symbol(b.lifecycleOpts.AppPath))
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.logger.Infof("Detected changes to %s, rebuilding", describeChanges(changes))
+		}
+	})
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	mode    os.FileMode
+}
+
+// appWatcher detects changes to the app by polling it, considering only the files that are sent to the build
+type appWatcher struct {
+	appPath    string
+	fileFilter func(string) bool
+	// dirFilter reports whether a directory may contain files sent to the build, the others aren't walked
+	dirFilter func(string) bool
+	snapshot  map[string]fileState
+}
+
+func newAppWatcher(appPath string, fileFilter, dirFilter func(string) bool) *appWatcher {
+	return &appWatcher{
+		appPath:    appPath,
+		fileFilter: fileFilter,
+		dirFilter:  dirFilter,
+	}
+}
+
+// getDirFilter returns which directories of the app may contain files sent to the build, or nil if any may. Only
+// excluded directories are left out: a directory matching no include pattern may still contain included files, and a
+// negated exclude pattern may include files back from an excluded directory.
+func getDirFilter(descriptor projectTypes.Descriptor) func(string) bool {
+	if len(descriptor.Build.Exclude) == 0 {
+		return nil
+	}
+	for _, pattern := range descriptor.Build.Exclude {
+		if strings.HasPrefix(strings.TrimSpace(pattern), "!") {
+			return nil
+		}
+	}
+
+	excludes := ignore.CompileIgnoreLines(descriptor.Build.Exclude...)
+	return func(dir string) bool {
+		return !excludes.MatchesPath(dir)
+	}
+}
+
+// scan records the current state of the app, returning the files that changed since the previous scan
+func (w *appWatcher) scan() ([]string, error) {
+	snapshot := map[string]fileState{}
+
+	fi, err := os.Stat(w.appPath)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		snapshot["."] = fileState{modTime: fi.ModTime(), size: fi.Size(), mode: fi.Mode()}
+	} else {
+		err = filepath.Walk(w.appPath, func(path string, fi os.FileInfo, err error) error {
+			if err != nil {
+				// files may be removed while walking the app, they are reported on the next scan
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			relPath, err := filepath.Rel(w.appPath, path)
+			if err != nil {
+				return err
+			}
+			if relPath == "." {
+				return nil
+			}
+			if fi.IsDir() {
+				if w.dirFilter != nil && !w.dirFilter(relPath) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if w.fileFilter != nil && !w.fileFilter(relPath) {
+				return nil
+			}
+
+			snapshot[filepath.ToSlash(relPath)] = fileState{modTime: fi.ModTime(), size: fi.Size(), mode: fi.Mode()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []string
+	for path, state := range snapshot {
+		if previous, ok := w.snapshot[path]; !ok || previous != state {
+			changes = append(changes, path)
+		}
+	}
+	for path := range w.snapshot {
+		if _, ok := snapshot[path]; !ok {
+			changes = append(changes, path)
+		}
+	}
+	sort.Strings(changes)
+
+	w.snapshot = snapshot
+	return changes, nil
+}
+
+// waitForChange blocks until the app changes and then stays unchanged for the debounce period, returning the changed
+// files. It returns early with no changes when the context is cancelled.
+func (w *appWatcher) waitForChange(ctx context.Context, debounce time.Duration) ([]string, error) {
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	changed := map[string]struct{}{}
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case now := <-ticker.C:
+			changes, err := w.scan()
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range changes {
+				changed[path] = struct{}{}
+			}
+
+			if len(changes) > 0 {
+				lastChange = now
+				continue
+			}
+			if len(changed) > 0 && now.Sub(lastChange) >= debounce {
+				return sortedKeys(changed, nil), nil
+			}
+		}
+	}
+}
+
+func describeChanges(changes []string) string {
+	var names []string
+	for i, path := range changes {
+		if i == maxReportedChanges {
+			names = append(names, "...")
+			break
+		}
+		names = append(names, style.Symbol(path))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAppWatcher(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "AppWatcher", testAppWatcher, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAppWatcher(t *testing.T, when spec.G, it spec.S) {
+	var appDir string
+
+	it.Before(func() {
+		appDir = t.TempDir()
+		h.AssertNil(t, os.MkdirAll(filepath.Join(appDir, "src"), 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "src", "main.go"), []byte("package main"), 0600))
+		h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "README.md"), []byte("readme"), 0600))
+	})
+
+	when("#scan", func() {
+		it("reports added, changed and removed files", func() {
+			watcher := newAppWatcher(appDir, nil, nil)
+			changes, err := watcher.scan()
+			h.AssertNil(t, err)
+			h.AssertEq(t, changes, []string{"README.md", "src/main.go"})
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "src", "main.go"), []byte("package main\n"), 0600))
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "src", "util.go"), []byte("package main"), 0600))
+			h.AssertNil(t, os.Remove(filepath.Join(appDir, "README.md")))
+
+			changes, err = watcher.scan()
+			h.AssertNil(t, err)
+			h.AssertEq(t, changes, []string{"README.md", "src/main.go", "src/util.go"})
+
+			changes, err = watcher.scan()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(changes), 0)
+		})
+
+		it("ignores files excluded from the build", func() {
+			watcher := newAppWatcher(appDir, func(path string) bool { return filepath.Ext(path) != ".md" }, nil)
+			changes, err := watcher.scan()
+			h.AssertNil(t, err)
+			h.AssertEq(t, changes, []string{"src/main.go"})
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "README.md"), []byte("updated readme"), 0600))
+
+			changes, err = watcher.scan()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(changes), 0)
+		})
+
+		it("doesn't walk excluded directories", func() {
+			h.AssertNil(t, os.MkdirAll(filepath.Join(appDir, "node_modules", "dep"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "node_modules", "dep", "index.js"), []byte("dep"), 0600))
+
+			var walked []string
+			descriptor := projectTypes.Descriptor{Build: projectTypes.Build{Exclude: []string{"node_modules"}}}
+			fileFilter, err := getFileFilter(descriptor)
+			h.AssertNil(t, err)
+			dirFilter := getDirFilter(descriptor)
+			watcher := newAppWatcher(appDir, fileFilter, func(dir string) bool {
+				walked = append(walked, filepath.ToSlash(dir))
+				return dirFilter(dir)
+			})
+			changes, err := watcher.scan()
+			h.AssertNil(t, err)
+			h.AssertEq(t, changes, []string{"README.md", "src/main.go"})
+			h.AssertEq(t, walked, []string{"node_modules", "src"})
+		})
+
+		it("watches an app given as an archive", func() {
+			appZip := filepath.Join(appDir, "app.zip")
+			h.AssertNil(t, os.WriteFile(appZip, []byte("zip"), 0600))
+
+			watcher := newAppWatcher(appZip, nil, nil)
+			_, err := watcher.scan()
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.WriteFile(appZip, []byte("updated zip"), 0600))
+
+			changes, err := watcher.scan()
+			h.AssertNil(t, err)
+			h.AssertEq(t, changes, []string{"."})
+		})
+	})
+
+	when("#getDirFilter", func() {
+		it("walks every directory when files are included", func() {
+			h.AssertTrue(t, getDirFilter(projectTypes.Descriptor{Build: projectTypes.Build{Include: []string{"src/*.go"}}}) == nil)
+		})
+
+		it("walks every directory when excluded files may be included back", func() {
+			h.AssertTrue(t, getDirFilter(projectTypes.Descriptor{Build: projectTypes.Build{Exclude: []string{"node_modules", "!node_modules/keep"}}}) == nil)
+		})
+	})
+
+	when("#waitForChange", func() {
+		it("returns the changed files once the app stops changing", func() {
+			watcher := newAppWatcher(appDir, nil, nil)
+			_, err := watcher.scan()
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "README.md"), []byte("updated readme"), 0600))
+
+			changes, err := watcher.waitForChange(context.Background(), time.Millisecond)
+			h.AssertNil(t, err)
+			h.AssertEq(t, changes, []string{"README.md"})
+		})
+
+		it("returns no changes when the context is cancelled", func() {
+			watcher := newAppWatcher(appDir, nil, nil)
+			_, err := watcher.scan()
+			h.AssertNil(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			changes, err := watcher.waitForChange(ctx, time.Millisecond)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(changes), 0)
+		})
+	})
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/lockfile"
+)
+
+// writeLayoutArchive writes the OCI layout in dir to a tar archive at path, as buildpack packages are written
+func writeLayoutArchive(dir, path string) error {
+	outputFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "creating output file")
+	}
+	defer outputFile.Close()
+
+	tw := tar.NewWriter(outputFile)
+	defer tw.Close()
+
+	return archive.WriteDirToTar(tw, dir, "/", 0, 0, 0755, true, false, nil)
+}
+
+// loadLayoutBuilder loads the builder image in the OCI layout directory or archive at path into the daemon, returning
+// the name it is tagged with. The name is derived from the digest of the image, so a builder already loaded isn't
+// loaded again. The builder is locked and verified by the digest of its manifest in the layout, under the reference
+// ref it was given as, before it is loaded.
+func (c *Client) loadLayoutBuilder(ctx context.Context, ref, path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading builder %s", style.Symbol(path))
+	}
+
+	layoutDir := path
+	if !fi.IsDir() {
+		tmpDir, err := os.MkdirTemp("", "layout-builder")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tmpDir)
+
+		if err := extractLayoutArchive(path, tmpDir); err != nil {
+			return "", errors.Wrapf(err, "extracting builder %s", style.Symbol(path))
+		}
+		layoutDir = tmpDir
+	}
+
+	img, err := readLayoutBuilder(layoutDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading builder %s", style.Symbol(path))
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+	if err := c.lockLayoutImage(lockfile.KindBuilder, ref, img); err != nil {
+		return "", err
+	}
+	if err := c.verifyLayoutImage(lockfile.KindBuilder, ref, layoutDir, digest); err != nil {
+		return "", err
+	}
+
+	tag, err := name.NewTag(fmt.Sprintf("pack.local/layout-builder/%s:latest", digest.Hex[:20]), name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	if _, _, err := c.docker.ImageInspectWithRaw(ctx, tag.Name()); err == nil {
+		c.logger.Debugf("Builder %s is already loaded as %s", style.Symbol(path), style.Symbol(tag.Name()))
+		return tag.Name(), nil
+	} else if !client.IsErrNotFound(err) {
+		return "", err
+	}
+
+	c.logger.Debugf("Loading builder %s as %s", style.Symbol(path), style.Symbol(tag.Name()))
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(tarball.Write(tag, img, pw))
+	}()
+
+	resp, err := c.docker.ImageLoad(ctx, pr, true)
+	if err != nil {
+		pr.CloseWithError(err)
+		return "", errors.Wrapf(err, "loading builder %s", style.Symbol(path))
+	}
+	defer resp.Body.Close()
+
+	if err := jsonmessage.DisplayJSONMessagesStream(resp.Body, io.Discard, 0, false, nil); err != nil {
+		return "", errors.Wrapf(err, "loading builder %s", style.Symbol(path))
+	}
+	return tag.Name(), nil
+}
+
+// readLayoutBuilder returns the only image in the OCI layout at path, ignoring the referrers attached to it such as
+// its signatures
+func readLayoutBuilder(path string) (v1.Image, error) {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := p.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	indexManifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var images []v1.Image
+	for _, desc := range indexManifest.Manifests {
+		img, err := idx.Image(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := img.Manifest()
+		if err != nil {
+			return nil, err
+		}
+		if manifest.Subject == nil {
+			images = append(images, img)
+		}
+	}
+	if len(images) != 1 {
+		return nil, errors.Errorf("expected exactly one image, found %d", len(images))
+	}
+	return images[0], nil
+}
+
+// extractLayoutArchive extracts the regular files and directories of an OCI layout archive to dir
+func extractLayoutArchive(archivePath, dir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+header.Name), "/")))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			/* #nosec G110 */
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrTypes "github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/lockfile"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signature"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderLayout(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderLayout", testBuilderLayout, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderLayout(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *Client
+		mockController *gomock.Controller
+		mockDocker     *testmocks.MockCommonAPIClient
+		builderImage   v1.Image
+		layoutDir      string
+		tmpDir         string
+		out            bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.builder.layout.test.")
+		h.AssertNil(t, err)
+
+		builderImage, err = random.Image(1024, 1)
+		h.AssertNil(t, err)
+		layoutDir = filepath.Join(tmpDir, "builder")
+		p, err := layout.Write(layoutDir, empty.Index)
+		h.AssertNil(t, err)
+		h.AssertNil(t, p.AppendImage(builderImage))
+
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		subject = &Client{
+			logger: logging.NewLogWithWriters(&out, &out),
+			docker: mockDocker,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	expectedTag := func() string {
+		digest, err := builderImage.Digest()
+		h.AssertNil(t, err)
+		return "pack.local/layout-builder/" + digest.Hex[:20] + ":latest"
+	}
+
+	when("#loadLayoutBuilder", func() {
+		it("loads the builder into the daemon", func() {
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), expectedTag()).Return(types.ImageInspect{}, nil, errdefs.NotFound(errors.New("no such image")))
+			mockDocker.EXPECT().ImageLoad(gomock.Any(), gomock.Any(), true).DoAndReturn(func(_ context.Context, r io.Reader, _ bool) (types.ImageLoadResponse, error) {
+				contents, err := io.ReadAll(r)
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(contents), "manifest.json")
+				return types.ImageLoadResponse{Body: io.NopCloser(strings.NewReader(`{"stream":"Loaded image"}`))}, nil
+			})
+
+			tag, err := subject.loadLayoutBuilder(context.TODO(), "oci:builder", layoutDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, tag, expectedTag())
+		})
+
+		it("doesn't load a builder already in the daemon", func() {
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), expectedTag()).Return(types.ImageInspect{}, nil, nil)
+
+			tag, err := subject.loadLayoutBuilder(context.TODO(), "oci:builder", layoutDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, tag, expectedTag())
+		})
+
+		it("reads builders saved as an archive", func() {
+			archivePath := filepath.Join(tmpDir, "builder.tar")
+			h.AssertNil(t, writeLayoutArchive(layoutDir, archivePath))
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), expectedTag()).Return(types.ImageInspect{}, nil, nil)
+
+			tag, err := subject.loadLayoutBuilder(context.TODO(), "oci:builder", archivePath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, tag, expectedTag())
+		})
+
+		it("errors when the layout holds more than one image", func() {
+			other, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			p, err := layout.FromPath(layoutDir)
+			h.AssertNil(t, err)
+			h.AssertNil(t, p.AppendImage(other))
+
+			_, err = subject.loadLayoutBuilder(context.TODO(), "oci:builder", layoutDir)
+			h.AssertError(t, err, "expected exactly one image, found 2")
+		})
+
+		it("ignores the referrers attached to the builder", func() {
+			digest, err := builderImage.Digest()
+			h.AssertNil(t, err)
+			referrer := signature.NewReferrer(empty.Image, signature.SignatureArtifactType, v1.Descriptor{MediaType: ggcrTypes.OCIManifestSchema1, Digest: digest})
+			p, err := layout.FromPath(layoutDir)
+			h.AssertNil(t, err)
+			h.AssertNil(t, p.AppendImage(referrer))
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), expectedTag()).Return(types.ImageInspect{}, nil, nil)
+
+			tag, err := subject.loadLayoutBuilder(context.TODO(), "oci:builder", layoutDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, tag, expectedTag())
+		})
+
+		it("locks the builder by the digest of its manifest in the layout", func() {
+			lockfilePath := filepath.Join(tmpDir, "pack.lock")
+			lock, err := lockfile.Open(lockfilePath, lockfile.ModeVerify, subject.logger)
+			h.AssertNil(t, err)
+			subject.lock = lock
+			mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), expectedTag()).Return(types.ImageInspect{}, nil, nil)
+
+			_, err = subject.loadLayoutBuilder(context.TODO(), "oci:builder", layoutDir)
+			h.AssertNil(t, err)
+			h.AssertNil(t, lock.Save())
+
+			digest, err := builderImage.Digest()
+			h.AssertNil(t, err)
+			written, err := lockfile.Read(lockfilePath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(written.Entries), 1)
+			h.AssertEq(t, written.Entries[0].Kind, lockfile.KindBuilder)
+			h.AssertEq(t, written.Entries[0].Ref, "oci:builder")
+			h.AssertEq(t, written.Entries[0].Digest, digest.String())
+		})
+
+		when("verifying", func() {
+			it("verifies the builder by the digest of its manifest in the layout", func() {
+				digest, err := builderImage.Digest()
+				h.AssertNil(t, err)
+				subject.verifier, err = signature.NewVerifier(signature.Policy{AllowedDigests: []string{digest.String()}}, nil, nil, subject.logger)
+				h.AssertNil(t, err)
+				mockDocker.EXPECT().ImageInspectWithRaw(gomock.Any(), expectedTag()).Return(types.ImageInspect{}, nil, nil)
+
+				_, err = subject.loadLayoutBuilder(context.TODO(), "oci:builder", layoutDir)
+				h.AssertNil(t, err)
+				h.AssertEq(t, subject.verifier.Results()[0].VerifiedBy, "allowed digest")
+			})
+
+			it("fails before loading a builder that isn't allowed", func() {
+				var err error
+				subject.verifier, err = signature.NewVerifier(signature.Policy{AllowedDigests: []string{"sha256:" + strings.Repeat("a", 64)}}, nil, nil, subject.logger)
+				h.AssertNil(t, err)
+
+				_, err = subject.loadLayoutBuilder(context.TODO(), "oci:builder", layoutDir)
+				h.AssertError(t, err, "builder 'oci:builder'")
+				h.AssertError(t, err, "failed verification")
+			})
+		})
+
+		it("errors when the builder doesn't exist", func() {
+			_, err := subject.loadLayoutBuilder(context.TODO(), "oci:builder", filepath.Join(tmpDir, "missing"))
+			h.AssertError(t, err, "reading builder")
+		})
+	})
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	internalConfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// ExportCacheOptions defines the configuration used to export a cache volume.
+type ExportCacheOptions struct {
+	// Name of the cache volume to export.
+	Volume string
+
+	// Path to write the exported cache to.
+	Path string
+
+	// Format of the export, either a tarball or an OCI layout directory.
+	Format cache.ArchiveFormat
+
+	// Image used to access the volume contents; defaults to the lifecycle image.
+	HelperImage string
+
+	// Strategy for pulling the helper image.
+	PullPolicy image.PullPolicy
+}
+
+// ImportCacheOptions defines the configuration used to import a cache volume.
+type ImportCacheOptions struct {
+	// Path to a tarball or OCI layout directory created by ExportCache.
+	Path string
+
+	// Name of the cache volume to restore the contents into.
+	Volume string
+
+	// Image used to access the volume contents; defaults to the lifecycle image.
+	HelperImage string
+
+	// Strategy for pulling the helper image.
+	PullPolicy image.PullPolicy
+}
+
+// ListCaches returns the cache volumes created by pack.
+func (c *Client) ListCaches(ctx context.Context) ([]cache.VolumeInfo, error) {
+	docker, err := c.volumeDocker()
+	if err != nil {
+		return nil, err
+	}
+	return cache.ListVolumes(ctx, docker)
+}
+
+// InspectCache returns the cache volumes matching the given volume name, or belonging to the given image.
+func (c *Client) InspectCache(ctx context.Context, volumeOrImage string) ([]cache.VolumeInfo, error) {
+	volumes, err := c.ListCaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchCaches(volumes, volumeOrImage)
+	if len(matches) == 0 {
+		return nil, errors.Errorf("no cache found for %s", style.Symbol(volumeOrImage))
+	}
+	return matches, nil
+}
+
+// RemoveCaches removes the cache volumes matching the given volume names, or belonging to the given images.
+func (c *Client) RemoveCaches(ctx context.Context, volumesOrImages []string) error {
+	docker, err := c.volumeDocker()
+	if err != nil {
+		return err
+	}
+	volumes, err := cache.ListVolumes(ctx, docker)
+	if err != nil {
+		return err
+	}
+
+	for _, volumeOrImage := range volumesOrImages {
+		matches := matchCaches(volumes, volumeOrImage)
+		if len(matches) == 0 {
+			return errors.Errorf("no cache found for %s", style.Symbol(volumeOrImage))
+		}
+		for _, v := range matches {
+			if err := cache.RemoveVolume(ctx, docker, v.Name); err != nil {
+				return err
+			}
+			c.logger.Infof("Removed cache volume %s", style.Symbol(v.Name))
+		}
+	}
+	return nil
+}
+
+// PruneCaches removes the cache volumes selected by the given options and returns them.
+func (c *Client) PruneCaches(ctx context.Context, opts cache.PruneOptions) ([]cache.VolumeInfo, error) {
+	docker, err := c.volumeDocker()
+	if err != nil {
+		return nil, err
+	}
+	return cache.PruneVolumes(ctx, docker, opts)
+}
+
+// ExportCache writes the contents of a cache volume to a tarball or OCI layout.
+func (c *Client) ExportCache(ctx context.Context, opts ExportCacheOptions) error {
+	format := opts.Format
+	if format == "" {
+		format = cache.FormatTarball
+	}
+
+	docker, err := c.volumeDocker()
+	if err != nil {
+		return err
+	}
+
+	helperImage, err := c.fetchCacheHelperImage(ctx, opts.HelperImage, opts.PullPolicy)
+	if err != nil {
+		return err
+	}
+
+	if err = cache.ExportVolume(ctx, docker, opts.Volume, helperImage, opts.Path, format); err != nil {
+		return errors.Wrapf(err, "exporting cache volume %s", style.Symbol(opts.Volume))
+	}
+	c.logger.Infof("Successfully exported cache volume %s to %s", style.Symbol(opts.Volume), style.Symbol(opts.Path))
+	return nil
+}
+
+// ImportCache restores the contents of a tarball or OCI layout created by ExportCache into a cache volume.
+func (c *Client) ImportCache(ctx context.Context, opts ImportCacheOptions) error {
+	docker, err := c.volumeDocker()
+	if err != nil {
+		return err
+	}
+
+	helperImage, err := c.fetchCacheHelperImage(ctx, opts.HelperImage, opts.PullPolicy)
+	if err != nil {
+		return err
+	}
+
+	if err = cache.ImportVolume(ctx, docker, opts.Volume, helperImage, opts.Path); err != nil {
+		return errors.Wrapf(err, "importing cache volume %s", style.Symbol(opts.Volume))
+	}
+	c.logger.Infof("Successfully imported %s into cache volume %s", style.Symbol(opts.Path), style.Symbol(opts.Volume))
+	return nil
+}
+
+// enforceCachePolicy evicts the least-recently-used cache volumes exceeding the configured cache policy. The caches
+// of the image being built are always kept, and failures are logged without failing the build.
+func (c *Client) enforceCachePolicy(ctx context.Context, imageRef name.Reference) {
+	if c.cachePolicy == nil {
+		return
+	}
+	opts := *c.cachePolicy
+	if opts.MaxAge <= 0 && opts.MaxTotalSize <= 0 && opts.MaxImages <= 0 {
+		return
+	}
+	opts.DryRun = false
+	opts.ExcludeImages = append(append([]string{}, opts.ExcludeImages...), imageRef.Name())
+
+	evicted, err := c.PruneCaches(ctx, opts)
+	if err != nil {
+		c.logger.Warnf("Unable to enforce cache policy: %s", err)
+		return
+	}
+	for _, v := range evicted {
+		c.logger.Debugf("Evicted cache volume %s", style.Symbol(v.Name))
+	}
+}
+
+// volumeDocker returns the Docker client as a cache.VolumeDockerClient. DiskUsage isn't part of DockerClient, so
+// Docker clients provided with WithDockerClient don't have to implement it unless cache volumes are managed.
+func (c *Client) volumeDocker() (cache.VolumeDockerClient, error) {
+	docker, ok := c.docker.(cache.VolumeDockerClient)
+	if !ok {
+		return nil, errors.New("the Docker client doesn't support managing cache volumes")
+	}
+	return docker, nil
+}
+
+func (c *Client) fetchCacheHelperImage(ctx context.Context, helperImage string, pullPolicy image.PullPolicy) (string, error) {
+	if helperImage == "" {
+		helperImage = fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion)
+	}
+	img, err := c.imageFetcher.Fetch(ctx, helperImage, image.FetchOptions{Daemon: true, PullPolicy: pullPolicy})
+	if err != nil {
+		return "", errors.Wrapf(err, "fetching helper image %s", style.Symbol(helperImage))
+	}
+	return img.Name(), nil
+}
+
+// matchCaches returns the volumes with the given name or, when no volume has that name, the volumes belonging to the
+// image with the given name
+func matchCaches(volumes []cache.VolumeInfo, volumeOrImage string) []cache.VolumeInfo {
+	for _, v := range volumes {
+		if v.Name == volumeOrImage {
+			return []cache.VolumeInfo{v}
+		}
+	}
+
+	ref, err := name.ParseReference(volumeOrImage, name.WeakValidation)
+	if err != nil {
+		return nil
+	}
+	var matches []cache.VolumeInfo
+	for _, v := range volumes {
+		if v.Image == ref.Name() {
+			matches = append(matches, v)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCache(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "cache", testCache, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController   *gomock.Controller
+		mockDocker       *testmocks.MockCommonAPIClient
+		mockImageFetcher *testmocks.MockImageFetcher
+		out              bytes.Buffer
+		subject          *Client
+		tmpPackHome      string
+		buildVolume      string
+		launchVolume     string
+	)
+
+	it.Before(func() {
+		var err error
+		logger := logging.NewLogWithWriters(&out, &out, logging.WithVerbose())
+		mockController = gomock.NewController(t)
+		mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		tmpPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		t.Setenv("PACK_HOME", tmpPackHome)
+
+		ref, err := name.ParseReference("my/app", name.WeakValidation)
+		h.AssertNil(t, err)
+		buildCache, err := cache.NewVolumeCache(ref, cache.CacheInfo{}, "build", mockDocker, logger)
+		h.AssertNil(t, err)
+		buildVolume = buildCache.Name()
+		launchCache, err := cache.NewVolumeCache(ref, cache.CacheInfo{}, "launch", mockDocker, logger)
+		h.AssertNil(t, err)
+		launchVolume = launchCache.Name()
+
+		mockDocker.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(types.DiskUsage{Volumes: []*volume.Volume{
+			{Name: buildVolume},
+			{Name: launchVolume},
+			{Name: "pack-cache-other_latest-000000000000.build"},
+		}}, nil).AnyTimes()
+
+		subject, err = NewClient(WithLogger(logger), WithDockerClient(mockDocker), WithFetcher(mockImageFetcher))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpPackHome))
+	})
+
+	when("#InspectCache", func() {
+		it("returns the volume with the given name", func() {
+			volumes, err := subject.InspectCache(context.TODO(), launchVolume)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(volumes), 1)
+			h.AssertEq(t, volumes[0].Name, launchVolume)
+		})
+
+		it("returns the volumes of the given image", func() {
+			volumes, err := subject.InspectCache(context.TODO(), "index.docker.io/my/app:latest")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(volumes), 2)
+			h.AssertEq(t, volumes[0].Name, buildVolume)
+			h.AssertEq(t, volumes[1].Name, launchVolume)
+		})
+
+		it("errors when nothing matches", func() {
+			_, err := subject.InspectCache(context.TODO(), "my/other-app")
+			h.AssertError(t, err, "no cache found for 'my/other-app'")
+		})
+
+		it("errors when the Docker client can't report disk usage", func() {
+			var err error
+			subject, err = NewClient(WithDockerClient(struct{ DockerClient }{mockDocker}), WithFetcher(mockImageFetcher))
+			h.AssertNil(t, err)
+
+			_, err = subject.InspectCache(context.TODO(), launchVolume)
+			h.AssertError(t, err, "the Docker client doesn't support managing cache volumes")
+		})
+	})
+
+	when("#RemoveCaches", func() {
+		it("removes all the volumes of the given image", func() {
+			mockDocker.EXPECT().VolumeRemove(gomock.Any(), buildVolume, false).Return(nil)
+			mockDocker.EXPECT().VolumeRemove(gomock.Any(), launchVolume, false).Return(nil)
+
+			h.AssertNil(t, subject.RemoveCaches(context.TODO(), []string{"my/app"}))
+			h.AssertContains(t, out.String(), "Removed cache volume '"+buildVolume+"'")
+		})
+	})
+
+	when("#ExportCache", func() {
+		it("fails when the helper image can't be fetched", func() {
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "buildpacksio/lifecycle:0.20.0", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+				Return(nil, image.ErrNotFound)
+
+			err := subject.ExportCache(context.TODO(), ExportCacheOptions{Volume: buildVolume, Path: filepath.Join(tmpPackHome, "cache.tar"), PullPolicy: image.PullNever})
+			h.AssertError(t, err, "fetching helper image 'buildpacksio/lifecycle:0.20.0'")
+		})
+
+		it("uses the given helper image", func() {
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "some/helper", image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways}).
+				Return(fakes.NewImage("some/helper", "", nil), nil)
+
+			err := subject.ExportCache(context.TODO(), ExportCacheOptions{Volume: buildVolume, Path: filepath.Join(tmpPackHome, "cache"), Format: "unknown", HelperImage: "some/helper"})
+			h.AssertError(t, err, "unsupported export format 'unknown'")
+		})
+	})
+}
@@ -32,10 +32,13 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/index"
+	"github.com/buildpacks/pack/pkg/lockfile"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signature"
 )
 
 const (
@@ -126,9 +129,20 @@ type Client struct {
 	lifecycleExecutor   LifecycleExecutor
 	buildpackDownloader BuildpackDownloader
 
+	// customBuildpackDownloader is true when the BuildpackDownloader was supplied with WithBuildpackDownloader, rather
+	// than created with the image fetcher of the client
+	customBuildpackDownloader bool
+
 	experimental    bool
 	registryMirrors map[string]string
 	version         string
+	cachePolicy     *cache.PruneOptions
+
+	// lock records the references resolved by a build, when building with a lockfile
+	lock *lockfile.Lock
+
+	// verifier verifies the images and blobs fetched by a build, when building with a verification policy
+	verifier *signature.Verifier
 }
 
 // Option is a type of function that mutate settings on the client.
@@ -177,6 +191,7 @@ func WithDownloader(d BlobDownloader) Option {
 func WithBuildpackDownloader(d BuildpackDownloader) Option {
 	return func(c *Client) {
 		c.buildpackDownloader = d
+		c.customBuildpackDownloader = true
 	}
 }
 
@@ -210,6 +225,13 @@ func WithRegistryMirrors(registryMirrors map[string]string) Option {
 	}
 }
 
+// WithCachePolicy sets the limits enforced on cache volumes before each build.
+func WithCachePolicy(policy cache.PruneOptions) Option {
+	return func(c *Client) {
+		c.cachePolicy = &policy
+	}
+}
+
 // WithKeychain sets keychain of credentials to image registries
 func WithKeychain(keychain authn.Keychain) Option {
 	return func(c *Client) {
@@ -277,14 +299,7 @@ func NewClient(opts ...Option) (*Client, error) {
 	}
 
 	if client.buildpackDownloader == nil {
-		client.buildpackDownloader = buildpack.NewDownloader(
-			client.logger,
-			client.imageFetcher,
-			client.downloader,
-			&registryResolver{
-				logger: client.logger,
-			},
-		)
+		client.buildpackDownloader = client.newBuildpackDownloader()
 	}
 
 	client.lifecycleExecutor = build.NewLifecycleExecutor(client.logger, client.docker)
@@ -292,12 +307,25 @@ func NewClient(opts ...Option) (*Client, error) {
 	return client, nil
 }
 
+func (c *Client) newBuildpackDownloader() BuildpackDownloader {
+	return buildpack.NewDownloader(
+		c.logger,
+		c.imageFetcher,
+		c.downloader,
+		&registryResolver{
+			logger:   c.logger,
+			keychain: c.keychain,
+		},
+	)
+}
+
 type registryResolver struct {
-	logger logging.Logger
+	logger   logging.Logger
+	keychain authn.Keychain
 }
 
 func (r *registryResolver) Resolve(registryName, bpName string) (string, error) {
-	cache, err := getRegistry(r.logger, registryName)
+	cache, err := getRegistry(r.logger, r.keychain, registryName)
 	if err != nil {
 		return "", errors.Wrapf(err, "lookup registry %s", style.Symbol(registryName))
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 
 	"github.com/buildpacks/pack/internal/builder"
@@ -14,6 +15,7 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 func (c *Client) addManifestToIndex(ctx context.Context, repoName string, index imgutil.ImageIndex) error {
@@ -77,7 +79,7 @@ func (c *Client) resolveRunImage(runImage, imgRegistry, bldrRegistry string, run
 	return runImageName
 }
 
-func getRegistry(logger logging.Logger, registryName string) (registry.Cache, error) {
+func getRegistry(logger logging.Logger, keychain authn.Keychain, registryName string) (registry.Cache, error) {
 	home, err := config.PackHome()
 	if err != nil {
 		return registry.Cache{}, err
@@ -98,6 +100,12 @@ func getRegistry(logger logging.Logger, registryName string) (registry.Cache, er
 
 	for _, reg := range config.GetRegistries(cfg) {
 		if reg.Name == registryName {
+			switch reg.Type {
+			case registryTypes.TypeOCI:
+				return registry.NewOCIRegistryCache(logger, home, reg.URL, keychain)
+			case registryTypes.TypeDir:
+				return registry.NewDirRegistryCache(logger, reg.URL)
+			}
 			return registry.NewRegistryCache(logger, home, reg.URL)
 		}
 	}
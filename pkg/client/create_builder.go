@@ -3,11 +3,14 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/layout"
 	"github.com/pkg/errors"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -19,6 +22,8 @@ import (
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/lockfile"
+	"github.com/buildpacks/pack/pkg/signature"
 )
 
 // CreateBuilderOptions is a configuration object used to change the behavior of
@@ -43,6 +48,11 @@ type CreateBuilderOptions struct {
 	// Requires BuilderName to be a valid registry location.
 	Publish bool
 
+	// Type of output format. The options are the const FormatImage, saving the builder to the daemon or a registry, or
+	// FormatOCILayout and FormatFile, saving it as an OCI layout directory or archive at the path given as BuilderName.
+	// Images the builder is created from are read from the registry when saving it in OCI layout format.
+	Format string
+
 	// Buildpack registry name. Defines where all registry buildpacks will be pulled from.
 	Registry string
 
@@ -54,11 +64,41 @@ type CreateBuilderOptions struct {
 
 	// Target platforms to build builder images for
 	Targets []dist.Target
+
+	// Path to a lockfile recording the digests that the build image, run images, lifecycle and remote buildpacks and
+	// extensions resolve to. When the lockfile exists, the builder is verified against it. New references are added to
+	// the lockfile once the builder is created.
+	Lockfile string
+
+	// What to do when a reference resolves to a different digest than the one in the lockfile.
+	LockfileMode lockfile.Mode
+
+	// Policy the build image, run images, lifecycle and remote buildpacks and extensions are verified against before
+	// they are added to the builder. Nothing is verified when nil.
+	Verification *signature.Policy
 }
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
 // If any configuration is invalid, it will error and exit without creating any images.
 func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) error {
+	if opts.Lockfile != "" && c.lock == nil {
+		return c.withLockfile(opts.Lockfile, opts.LockfileMode, func(locking *Client) error {
+			return locking.CreateBuilder(ctx, opts)
+		})
+	}
+	if opts.Verification != nil && c.verifier == nil {
+		return c.withVerification(*opts.Verification, func(verifying *Client) error {
+			return verifying.CreateBuilder(ctx, opts)
+		})
+	}
+
+	if opts.Format == "" {
+		opts.Format = FormatImage
+	}
+	if err := validateBuilderFormat(opts); err != nil {
+		return err
+	}
+
 	targets, err := c.processBuilderCreateTargets(ctx, opts)
 	if err != nil {
 		return err
@@ -98,6 +138,17 @@ func (c *Client) createBuilderTarget(ctx context.Context, opts CreateBuilderOpti
 		return "", err
 	}
 
+	// the archive is written from a layout saved in a temporary directory
+	archivePath := ""
+	if opts.Format == FormatFile {
+		tmpDir, err := os.MkdirTemp("", "create-builder")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tmpDir)
+		archivePath, opts.BuilderName = opts.BuilderName, filepath.Join(tmpDir, "oci-layout")
+	}
+
 	bldr, err := c.createBaseBuilder(ctx, opts, target)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create builder")
@@ -125,6 +176,12 @@ func (c *Client) createBuilderTarget(ctx context.Context, opts CreateBuilderOpti
 		return "", err
 	}
 
+	if archivePath != "" {
+		if err := writeLayoutArchive(opts.BuilderName, archivePath); err != nil {
+			return "", errors.Wrapf(err, "writing builder to %s", style.Symbol(archivePath))
+		}
+	}
+
 	if multiArch {
 		// We need to keep the identifier to create the image index
 		id, err := bldr.Image().Identifier()
@@ -152,7 +209,7 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	var runImages []imgutil.Image
 	for _, r := range opts.Config.Run.Images {
 		for _, i := range append([]string{r.Image}, r.Mirrors...) {
-			if !opts.Publish {
+			if useDaemon(opts) {
 				img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Target: target})
 				if err != nil {
 					if errors.Cause(err) != image.ErrNotFound {
@@ -177,6 +234,13 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	}
 
 	for _, img := range runImages {
+		if err := c.lockImage(ctx, lockfile.KindRunImage, img.Name(), img); err != nil {
+			return err
+		}
+		if err := c.verifyImage(ctx, lockfile.KindRunImage, img.Name(), img); err != nil {
+			return err
+		}
+
 		if opts.Config.Stack.ID != "" {
 			stackID, err := img.Label("io.buildpacks.stack.id")
 			if err != nil {
@@ -198,10 +262,21 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 }
 
 func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions, target *dist.Target) (*builder.Builder, error) {
-	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy, Target: target})
+	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: useDaemon(opts), PullPolicy: opts.PullPolicy, Target: target})
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch build image")
 	}
+	if err := c.lockImage(ctx, lockfile.KindBuildImage, opts.Config.Build.Image, baseImage); err != nil {
+		return nil, err
+	}
+	if err := c.verifyImage(ctx, lockfile.KindBuildImage, opts.Config.Build.Image, baseImage); err != nil {
+		return nil, err
+	}
+	if opts.Format != FormatImage {
+		if baseImage, err = layout.NewImage(opts.BuilderName, layout.FromBaseImageInstance(baseImage.UnderlyingImage())); err != nil {
+			return nil, errors.Wrap(err, "creating layout image")
+		}
+	}
 
 	c.logger.Debugf("Creating builder %s from build-image %s", style.Symbol(opts.BuilderName), style.Symbol(baseImage.Name()))
 
@@ -284,6 +359,12 @@ func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleCon
 	if err != nil {
 		return nil, errors.Wrap(err, "downloading lifecycle")
 	}
+	if err = c.lockBlob(lockfile.KindLifecycle, uri, targetPlatform(&dist.Target{OS: os, Arch: architecture}), blob); err != nil {
+		return nil, err
+	}
+	if err = c.verifyBlob(ctx, lockfile.KindLifecycle, uri, blob); err != nil {
+		return nil, err
+	}
 
 	lifecycle, err := builder.NewLifecycle(blob)
 	if err != nil {
@@ -327,13 +408,14 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 	c.logger.Debugf("Downloading buildpack for platform: %s", target.ValuesAsPlatform())
 
 	mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, config.URI, buildpack.DownloadOptions{
-		Daemon:          !opts.Publish,
+		Daemon:          useDaemon(opts),
 		ImageName:       config.ImageName,
 		ModuleKind:      kind,
 		PullPolicy:      opts.PullPolicy,
 		RegistryName:    opts.Registry,
 		RelativeBaseDir: opts.RelativeBaseDir,
 		Target:          target,
+		Verifier:        c.moduleVerifier(),
 	})
 	if err != nil {
 		return errors.Wrapf(err, "downloading %s", kind)
@@ -343,6 +425,16 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 		return errors.Wrapf(err, "invalid %s", kind)
 	}
 
+	ref, locatorType := config.URI, buildpack.PackageLocator
+	if ref == "" {
+		ref = config.ImageName
+	} else if locatorType, err = buildpack.GetLocatorType(ref, opts.RelativeBaseDir, nil); err != nil {
+		return err
+	}
+	if err = c.lockModules(kind, ref, locatorType, target, append([]buildpack.BuildModule{mainBP}, depBPs...)); err != nil {
+		return err
+	}
+
 	bpDesc := mainBP.Descriptor()
 	for _, deprecatedAPI := range bldr.LifecycleDescriptor().APIs.Buildpack.Deprecated {
 		if deprecatedAPI.Equal(bpDesc.API()) {
@@ -381,7 +473,7 @@ func (c *Client) processBuilderCreateTargets(ctx context.Context, opts CreateBui
 	var targets []dist.Target
 
 	if len(opts.Targets) > 0 {
-		if opts.Publish {
+		if !useDaemon(opts) {
 			targets = opts.Targets
 		} else {
 			// find a target that matches the daemon
@@ -395,6 +487,28 @@ func (c *Client) processBuilderCreateTargets(ctx context.Context, opts CreateBui
 	return targets, nil
 }
 
+// useDaemon returns true when the builder is created from images in the daemon and saved to it
+func useDaemon(opts CreateBuilderOptions) bool {
+	return !opts.Publish && opts.Format == FormatImage
+}
+
+func validateBuilderFormat(opts CreateBuilderOptions) error {
+	switch opts.Format {
+	case FormatImage:
+		return nil
+	case FormatOCILayout, FormatFile:
+		if opts.Publish {
+			return errors.Errorf("a builder saved in %s format cannot be published", style.Symbol(opts.Format))
+		}
+		if len(opts.Targets) > 1 {
+			return errors.Errorf("a builder saved in %s format can only be created for a single target", style.Symbol(opts.Format))
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	}
+}
+
 func validateModule(kind string, module buildpack.BuildModule, source, expectedID, expectedVersion string) error {
 	info := module.Descriptor().Info()
 	if expectedID != "" && info.ID != expectedID {
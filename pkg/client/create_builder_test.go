@@ -419,6 +419,31 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("validating the format", func() {
+			it("errors on an unknown format", func() {
+				opts.Format = "zip"
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "unknown format: 'zip'")
+			})
+
+			it("errors when publishing a builder saved to the host filesystem", func() {
+				opts.Format = client.FormatOCILayout
+				opts.Publish = true
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "a builder saved in 'oci-layout' format cannot be published")
+			})
+
+			it("errors when saving a builder for multiple targets to the host filesystem", func() {
+				opts.Format = client.FormatFile
+				opts.Targets = []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}}
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "a builder saved in 'file' format can only be created for a single target")
+			})
+		})
+
 		when("creating the base builder", func() {
 			when("build image not found", func() {
 				it("should fail", func() {
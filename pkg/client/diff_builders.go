@@ -0,0 +1,450 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DiffBuildersOptions is a configuration struct that controls comparing two builders.
+type DiffBuildersOptions struct {
+	// Name of the builder to compare from, e.g. the builder currently in use.
+	From string
+
+	// Name of the builder to compare to, e.g. a new release of the builder.
+	To string
+
+	// Whether to look the builders up in the daemon rather than in the registry.
+	Daemon bool
+
+	// Depth of the detection order to compare, see pubbldr.OrderDetectionMaxDepth.
+	OrderDetectionDepth int
+}
+
+// BuilderDiff is the difference between two builders.
+type BuilderDiff struct {
+	// Names of the compared builders.
+	From, To string
+
+	// Change of lifecycle, or nil if both builders have the same lifecycle.
+	Lifecycle *LifecycleDiff
+
+	// Change of build image, or nil if both builders are based on the same build image.
+	BuildImage *BuildImageDiff
+
+	// Run images added, removed or whose mirrors changed.
+	RunImages []BuilderRunImageDiff
+
+	// Buildpacks added, removed or upgraded.
+	Buildpacks []BuildpackDiff
+
+	// Extensions added, removed or upgraded.
+	Extensions []BuildpackDiff
+
+	// Detection order of both builders, or nil if they are the same.
+	Order *DetectionOrderDiff
+
+	// Detection order of the extensions of both builders, or nil if they are the same.
+	OrderExtensions *DetectionOrderDiff
+
+	// Build config env files added, removed or whose value changed.
+	BuildEnv []EnvDiff
+
+	// Whether the build config env of a builder can't be read, in which case BuildEnv is empty.
+	BuildEnvUnknown bool
+
+	// Change of target, or nil if both builders have the same target.
+	Target *TargetDiff
+}
+
+// LifecycleDiff describes the lifecycles of two builders.
+type LifecycleDiff struct {
+	FromVersion string
+	ToVersion   string
+
+	// Change of version, empty if only the supported APIs changed.
+	Change Change
+
+	BuildpackAPIs APIsDiff
+	PlatformAPIs  APIsDiff
+}
+
+// APIsDiff describes how the APIs supported by a lifecycle changed.
+type APIsDiff struct {
+	Added      []string
+	Removed    []string
+	Deprecated []string
+}
+
+// BuildImageDiff describes the build images of two builders. Build images are compared by their top layer, as recorded
+// in the builder metadata, or else found below the layer with the default directories pack adds to builders.
+type BuildImageDiff struct {
+	FromStack     string
+	ToStack       string
+	FromTopLayer  string
+	ToTopLayer    string
+	AddedMixins   []string
+	RemovedMixins []string
+
+	// Whether the layers of a build image can't be read, in which case the build images may differ.
+	LayersUnknown bool
+}
+
+// BuilderRunImageDiff describes a run image that differs between two builders.
+type BuilderRunImageDiff struct {
+	Image       string
+	FromMirrors []string
+	ToMirrors   []string
+	Change      Change
+}
+
+// DetectionOrderDiff holds the detection orders of two builders.
+type DetectionOrderDiff struct {
+	From pubbldr.DetectionOrder
+	To   pubbldr.DetectionOrder
+}
+
+// EnvDiff describes an environment variable that differs between two builders.
+type EnvDiff struct {
+	Name      string
+	FromValue string
+	ToValue   string
+	Change    Change
+}
+
+// TargetDiff describes the targets of two builders, formatted as os/arch/variant/distro@version.
+type TargetDiff struct {
+	From string
+	To   string
+}
+
+// IsEmpty reports whether the builders don't differ.
+func (d *BuilderDiff) IsEmpty() bool {
+	return d.Lifecycle == nil &&
+		d.BuildImage == nil &&
+		len(d.RunImages) == 0 &&
+		len(d.Buildpacks) == 0 &&
+		len(d.Extensions) == 0 &&
+		d.Order == nil &&
+		d.OrderExtensions == nil &&
+		len(d.BuildEnv) == 0 &&
+		!d.BuildEnvUnknown &&
+		d.Target == nil
+}
+
+// DiffBuilders compares two builders, reporting changes to their lifecycle, build and run images, buildpacks,
+// extensions, detection order, build config env and target.
+func (c *Client) DiffBuilders(ctx context.Context, opts DiffBuildersOptions) (*BuilderDiff, error) {
+	from, err := c.inspectDiffBuilder(ctx, opts.From, opts.Daemon, opts.OrderDetectionDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := c.inspectDiffBuilder(ctx, opts.To, opts.Daemon, opts.OrderDetectionDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BuilderDiff{
+		From:            opts.From,
+		To:              opts.To,
+		Lifecycle:       diffLifecycles(from.Lifecycle, to.Lifecycle),
+		BuildImage:      diffBuildImages(from, to),
+		RunImages:       diffBuilderRunImages(from.RunImages, to.RunImages),
+		Buildpacks:      diffModules(from.Buildpacks, to.Buildpacks),
+		Extensions:      diffModules(from.Extensions, to.Extensions),
+		Order:           diffDetectionOrders(from.Order, to.Order),
+		OrderExtensions: diffDetectionOrders(from.OrderExtensions, to.OrderExtensions),
+		BuildEnv:        diffEnv(from.buildConfigEnv, to.buildConfigEnv),
+		BuildEnvUnknown: from.buildConfigEnv == nil || to.buildConfigEnv == nil,
+		Target:          diffTargets(from.Target, to.Target),
+	}, nil
+}
+
+// diffBuilder is a compared builder, along with what is read from its layers when its metadata doesn't record it
+type diffBuilder struct {
+	*BuilderInfo
+
+	// buildImageTopLayer is the top layer of the build image of the builder, or empty when unknown
+	buildImageTopLayer string
+
+	// buildConfigEnv is the build config env of the builder, or nil when unknown
+	buildConfigEnv map[string]string
+}
+
+func (c *Client) inspectDiffBuilder(ctx context.Context, name string, daemon bool, depth int) (*diffBuilder, error) {
+	info, err := c.InspectBuilder(name, daemon, WithDetectionOrderDepth(depth))
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder '%s'", name)
+	}
+	if info == nil {
+		return nil, errors.Wrapf(image.ErrNotFound, "builder '%s' cannot be found", name)
+	}
+
+	b := &diffBuilder{BuilderInfo: info, buildConfigEnv: info.BuildConfigEnv}
+	img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		c.logger.Debugf("Unable to read the layers of builder %s: %s", style.Symbol(name), err)
+		return b, nil
+	}
+
+	bldr, err := builder.FromImage(img)
+	if err == nil {
+		b.buildImageTopLayer, err = bldr.BuildImageTopLayer()
+	}
+	if err != nil {
+		c.logger.Debugf("Unable to find the build image of builder %s: %s", style.Symbol(name), err)
+	}
+
+	if err := b.readBuildConfigEnv(img); err != nil {
+		c.logger.Debugf("Unable to read the layers of builder %s: %s", style.Symbol(name), err)
+	}
+	return b, nil
+}
+
+// readBuildConfigEnv reads the build config env of the builder when its metadata doesn't record it. The build config
+// env is read from the layers above the buildpacks and extensions, which only hold configuration files.
+func (b *diffBuilder) readBuildConfigEnv(img imgutil.Image) error {
+	if b.buildConfigEnv != nil {
+		return nil
+	}
+	underlying := img.UnderlyingImage()
+	if underlying == nil {
+		return nil
+	}
+	config, err := underlying.ConfigFile()
+	if err != nil {
+		return err
+	}
+	diffIDs := config.RootFS.DiffIDs
+	extensionLayers := dist.ModuleLayers{}
+	if _, err := dist.GetLabel(img, dist.ExtensionLayersLabel, &extensionLayers); err != nil {
+		return err
+	}
+	moduleLayers := map[string]bool{}
+	for _, layers := range []dist.ModuleLayers{b.BuildpackLayers, extensionLayers} {
+		for _, versions := range layers {
+			for _, layer := range versions {
+				moduleLayers[layer.LayerDiffID] = true
+			}
+		}
+	}
+	topModuleLayer := -1
+	for i, diffID := range diffIDs {
+		if moduleLayers[diffID.String()] {
+			topModuleLayer = i
+		}
+	}
+	if topModuleLayer < 0 {
+		return nil
+	}
+
+	env := map[string]string{}
+	for _, diffID := range diffIDs[topModuleLayer+1:] {
+		if err := readBuildConfigEnvLayer(img, diffID.String(), env); err != nil {
+			return errors.Wrapf(err, "reading layer %s", diffID)
+		}
+	}
+	b.buildConfigEnv = env
+	return nil
+}
+
+func readBuildConfigEnvLayer(img imgutil.Image, diffID string, env map[string]string) error {
+	rc, err := img.GetLayer(diffID)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return builder.ReadBuildConfigEnv(rc, env)
+}
+
+func diffLifecycles(from, to builder.LifecycleDescriptor) *LifecycleDiff {
+	version := func(descriptor builder.LifecycleDescriptor) string {
+		if descriptor.Info.Version == nil {
+			return ""
+		}
+		return descriptor.Info.Version.String()
+	}
+
+	fromVersion, toVersion := version(from), version(to)
+	change, _ := versionChange(fromVersion, toVersion, fromVersion != "", toVersion != "")
+	diff := &LifecycleDiff{
+		FromVersion:   fromVersion,
+		ToVersion:     toVersion,
+		Change:        change,
+		BuildpackAPIs: diffAPIs(from.APIs.Buildpack, to.APIs.Buildpack),
+		PlatformAPIs:  diffAPIs(from.APIs.Platform, to.APIs.Platform),
+	}
+	if diff.Change == "" && diff.BuildpackAPIs.isEmpty() && diff.PlatformAPIs.isEmpty() {
+		return nil
+	}
+	return diff
+}
+
+func diffAPIs(from, to builder.APIVersions) APIsDiff {
+	return APIsDiff{
+		Added:      missingFrom(from.Supported.AsStrings(), to.Supported.AsStrings()),
+		Removed:    missingFrom(to.Supported.AsStrings(), from.Supported.AsStrings()),
+		Deprecated: missingFrom(from.Deprecated.AsStrings(), to.Deprecated.AsStrings()),
+	}
+}
+
+func (d APIsDiff) isEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Deprecated) == 0
+}
+
+func diffBuildImages(from, to *diffBuilder) *BuildImageDiff {
+	topLayer := func(b *diffBuilder) string {
+		if b.BuildImage != nil && b.BuildImage.TopLayer != "" {
+			return b.BuildImage.TopLayer
+		}
+		return b.buildImageTopLayer
+	}
+
+	diff := &BuildImageDiff{
+		FromStack:     from.Stack,
+		ToStack:       to.Stack,
+		AddedMixins:   missingFrom(from.Mixins, to.Mixins),
+		RemovedMixins: missingFrom(to.Mixins, from.Mixins),
+	}
+	if topLayer(from) != "" && topLayer(to) != "" {
+		diff.FromTopLayer, diff.ToTopLayer = topLayer(from), topLayer(to)
+	} else {
+		diff.LayersUnknown = true
+	}
+	if diff.FromStack == diff.ToStack &&
+		diff.FromTopLayer == diff.ToTopLayer &&
+		!diff.LayersUnknown &&
+		len(diff.AddedMixins) == 0 &&
+		len(diff.RemovedMixins) == 0 {
+		return nil
+	}
+	return diff
+}
+
+func diffBuilderRunImages(from, to []pubbldr.RunImageConfig) []BuilderRunImageDiff {
+	mirrors := func(runImages []pubbldr.RunImageConfig) map[string][]string {
+		byImage := map[string][]string{}
+		for _, runImage := range runImages {
+			byImage[runImage.Image] = runImage.Mirrors
+		}
+		return byImage
+	}
+
+	var diffs []BuilderRunImageDiff
+	fromMirrors, toMirrors := mirrors(from), mirrors(to)
+	for _, runImage := range sortedKeys(fromMirrors, toMirrors) {
+		fromImageMirrors, inFrom := fromMirrors[runImage]
+		toImageMirrors, inTo := toMirrors[runImage]
+		change, changed := digestChange(
+			strings.Join(fromImageMirrors, ","),
+			strings.Join(toImageMirrors, ","),
+			inFrom,
+			inTo,
+		)
+		if changed {
+			diffs = append(diffs, BuilderRunImageDiff{
+				Image:       runImage,
+				FromMirrors: fromImageMirrors,
+				ToMirrors:   toImageMirrors,
+				Change:      change,
+			})
+		}
+	}
+	return diffs
+}
+
+// diffModules compares buildpacks or extensions by ID. When a builder has several versions of a module, they are
+// reported together, separated by commas.
+func diffModules(from, to []dist.ModuleInfo) []BuildpackDiff {
+	versions := func(modules []dist.ModuleInfo) map[string][]string {
+		byID := map[string][]string{}
+		for _, module := range modules {
+			byID[module.ID] = append(byID[module.ID], module.Version)
+		}
+		return byID
+	}
+
+	var diffs []BuildpackDiff
+	fromVersions, toVersions := versions(from), versions(to)
+	for _, id := range sortedKeys(fromVersions, toVersions) {
+		fromIDVersions, inFrom := fromVersions[id]
+		toIDVersions, inTo := toVersions[id]
+		fromVersion, toVersion := strings.Join(fromIDVersions, ", "), strings.Join(toIDVersions, ", ")
+
+		var (
+			change  Change
+			changed bool
+		)
+		if len(fromIDVersions) > 1 || len(toIDVersions) > 1 {
+			change, changed = digestChange(fromVersion, toVersion, inFrom, inTo)
+		} else {
+			change, changed = versionChange(fromVersion, toVersion, inFrom, inTo)
+		}
+		if changed {
+			diffs = append(diffs, BuildpackDiff{ID: id, FromVersion: fromVersion, ToVersion: toVersion, Change: change})
+		}
+	}
+	return diffs
+}
+
+func diffDetectionOrders(from, to pubbldr.DetectionOrder) *DetectionOrderDiff {
+	if (len(from) == 0 && len(to) == 0) || reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return &DetectionOrderDiff{From: from, To: to}
+}
+
+func diffEnv(from, to map[string]string) []EnvDiff {
+	var diffs []EnvDiff
+	for _, name := range sortedKeys(from, to) {
+		fromValue, inFrom := from[name]
+		toValue, inTo := to[name]
+		if change, changed := digestChange(fromValue, toValue, inFrom, inTo); changed {
+			diffs = append(diffs, EnvDiff{Name: name, FromValue: fromValue, ToValue: toValue, Change: change})
+		}
+	}
+	return diffs
+}
+
+func diffTargets(from, to *dist.Target) *TargetDiff {
+	platform := func(target *dist.Target) string {
+		if target == nil {
+			return ""
+		}
+		return target.ValuesAsPlatform()
+	}
+
+	fromPlatform, toPlatform := platform(from), platform(to)
+	if fromPlatform == toPlatform {
+		return nil
+	}
+	return &TargetDiff{From: fromPlatform, To: toPlatform}
+}
+
+// missingFrom returns the values that are in b but not in a, sorted
+func missingFrom(a, b []string) []string {
+	inA := map[string]bool{}
+	for _, value := range a {
+		inA[value] = true
+	}
+
+	var missing []string
+	for _, value := range b {
+		if !inA[value] {
+			missing = append(missing, value)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,363 @@
+package client_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffBuilders(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "diff_builders", testDiffBuilders, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffBuilders(t *testing.T, when spec.G, it spec.S) {
+	when("#DiffBuilders", func() {
+		var (
+			mockController   *gomock.Controller
+			mockImageFetcher *testmocks.MockImageFetcher
+			fromBuilder      *fakes.Image
+			toBuilder        *fakes.Image
+			fetched          map[string]imgutil.Image
+			subject          *client.Client
+			out              bytes.Buffer
+		)
+
+		newBuilder := func(name, mixins, metadata, layers, order string) *fakes.Image {
+			builderImage := fakes.NewImage(name, "", nil)
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.mixins", mixins))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", metadata))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.layers", layers))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.order", order))
+			return builderImage
+		}
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+			fromBuilder = newBuilder("some/builder:1", `["mixinX"]`, `{
+  "buildpacks": [{"id": "some/bp", "version": "1.0.0"}, {"id": "old/bp", "version": "1.0.0"}],
+  "lifecycle": {"version": "0.19.0", "apis": {"buildpack": {"deprecated": [], "supported": ["0.2", "0.3"]}, "platform": {"deprecated": [], "supported": ["0.11", "0.12"]}}},
+  "images": [{"image": "some/run-image", "mirrors": ["some/mirror"]}],
+  "buildImage": {"topLayer": "sha256:old-top-layer"},
+  "buildConfigEnv": {"SOME_VAR": "old", "OLD_VAR": "value"}
+}`, `{
+  "some/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:some-bp-1"}},
+  "old/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:old-bp-1"}}
+}`, `[{"group": [{"id": "some/bp", "version": "1.0.0"}, {"id": "old/bp", "version": "1.0.0", "optional": true}]}]`)
+
+			toBuilder = newBuilder("some/builder:2", `["mixinX", "mixinY"]`, `{
+  "buildpacks": [{"id": "some/bp", "version": "1.1.0"}, {"id": "new/bp", "version": "1.0.0"}],
+  "lifecycle": {"version": "0.20.0", "apis": {"buildpack": {"deprecated": ["0.2"], "supported": ["0.2", "0.3", "0.10"]}, "platform": {"deprecated": [], "supported": ["0.12", "0.13"]}}},
+  "images": [{"image": "some/run-image"}, {"image": "other/run-image"}],
+  "buildImage": {"topLayer": "sha256:new-top-layer"},
+  "buildConfigEnv": {"SOME_VAR": "new", "NEW_VAR": "value"}
+}`, `{
+  "some/bp": {"1.1.0": {"api": "0.3", "layerDiffID": "sha256:some-bp-2"}},
+  "new/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:new-bp-1"}}
+}`, `[{"group": [{"id": "some/bp", "version": "1.1.0"}, {"id": "new/bp", "version": "1.0.0"}]}]`)
+			h.AssertNil(t, toBuilder.SetArchitecture("arm64"))
+
+			fetched = map[string]imgutil.Image{"some/builder:1": fromBuilder, "some/builder:2": toBuilder}
+			fetch := func(_ context.Context, name string, _ image.FetchOptions) (imgutil.Image, error) {
+				return fetched[name], nil
+			}
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder:1", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).DoAndReturn(fetch).AnyTimes()
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder:2", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).DoAndReturn(fetch).AnyTimes()
+
+			var err error
+			subject, err = client.NewClient(
+				client.WithLogger(logging.NewLogWithWriters(&out, &out)),
+				client.WithFetcher(mockImageFetcher),
+			)
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		diffBuilders := func() *client.BuilderDiff {
+			diff, err := subject.DiffBuilders(context.TODO(), client.DiffBuildersOptions{
+				From:                "some/builder:1",
+				To:                  "some/builder:2",
+				Daemon:              true,
+				OrderDetectionDepth: pubbldr.OrderDetectionMaxDepth,
+			})
+			h.AssertNil(t, err)
+			return diff
+		}
+
+		it("reports the change of lifecycle and its APIs", func() {
+			h.AssertEq(t, diffBuilders().Lifecycle, &client.LifecycleDiff{
+				FromVersion: "0.19.0",
+				ToVersion:   "0.20.0",
+				Change:      client.ChangeUpgraded,
+				BuildpackAPIs: client.APIsDiff{
+					Added:      []string{"0.10"},
+					Deprecated: []string{"0.2"},
+				},
+				PlatformAPIs: client.APIsDiff{
+					Added:   []string{"0.13"},
+					Removed: []string{"0.11"},
+				},
+			})
+		})
+
+		it("reports the change of build image", func() {
+			h.AssertEq(t, diffBuilders().BuildImage, &client.BuildImageDiff{
+				FromStack:    "some.stack.id",
+				ToStack:      "some.stack.id",
+				FromTopLayer: "sha256:old-top-layer",
+				ToTopLayer:   "sha256:new-top-layer",
+				AddedMixins:  []string{"mixinY"},
+			})
+		})
+
+		it("reports run images added, removed or whose mirrors changed", func() {
+			h.AssertEq(t, diffBuilders().RunImages, []client.BuilderRunImageDiff{
+				{Image: "other/run-image", Change: client.ChangeAdded},
+				{Image: "some/run-image", FromMirrors: []string{"some/mirror"}, Change: client.ChangeChanged},
+			})
+		})
+
+		it("reports buildpacks added, removed or upgraded", func() {
+			h.AssertEq(t, diffBuilders().Buildpacks, []client.BuildpackDiff{
+				{ID: "new/bp", ToVersion: "1.0.0", Change: client.ChangeAdded},
+				{ID: "old/bp", FromVersion: "1.0.0", Change: client.ChangeRemoved},
+				{ID: "some/bp", FromVersion: "1.0.0", ToVersion: "1.1.0", Change: client.ChangeUpgraded},
+			})
+		})
+
+		it("reports the detection orders when they differ", func() {
+			diff := diffBuilders()
+			h.AssertNotNil(t, diff.Order)
+			h.AssertEq(t, len(diff.Order.From), 1)
+			h.AssertEq(t, diff.Order.To[0].GroupDetectionOrder[1].ID, "new/bp")
+			h.AssertNil(t, diff.OrderExtensions)
+		})
+
+		it("reports build config env added, removed or changed", func() {
+			h.AssertEq(t, diffBuilders().BuildEnv, []client.EnvDiff{
+				{Name: "NEW_VAR", ToValue: "value", Change: client.ChangeAdded},
+				{Name: "OLD_VAR", FromValue: "value", Change: client.ChangeRemoved},
+				{Name: "SOME_VAR", FromValue: "old", ToValue: "new", Change: client.ChangeChanged},
+			})
+		})
+
+		it("reports the change of target", func() {
+			h.AssertEq(t, diffBuilders().Target, &client.TargetDiff{From: "linux/amd64", To: "linux/arm64"})
+		})
+
+		when("the metadata doesn't record the build image and env", func() {
+			it.Before(func() {
+				for _, builderImage := range []*fakes.Image{fromBuilder, toBuilder} {
+					var md map[string]interface{}
+					_, err := dist.GetLabel(builderImage, "io.buildpacks.builder.metadata", &md)
+					h.AssertNil(t, err)
+					delete(md, "buildImage")
+					delete(md, "buildConfigEnv")
+					h.AssertNil(t, dist.SetLabel(builderImage, "io.buildpacks.builder.metadata", md))
+				}
+			})
+
+			it("reports them as unknown", func() {
+				diff := diffBuilders()
+				h.AssertEq(t, diff.BuildImage, &client.BuildImageDiff{
+					FromStack:     "some.stack.id",
+					ToStack:       "some.stack.id",
+					AddedMixins:   []string{"mixinY"},
+					LayersUnknown: true,
+				})
+				h.AssertEq(t, len(diff.BuildEnv), 0)
+				h.AssertTrue(t, diff.BuildEnvUnknown)
+			})
+
+			when("the layers of the builders can be read", func() {
+				var (
+					fromTopLayer v1.Hash
+					toTopLayer   v1.Hash
+				)
+
+				it.Before(func() {
+					fetched["some/builder:1"], fromTopLayer = withLayers(t, fromBuilder, "old-bp-1", map[string]string{"SOME_VAR": "old", "OLD_VAR": "value"})
+					fetched["some/builder:2"], toTopLayer = withLayers(t, toBuilder, "new-bp-1", map[string]string{"SOME_VAR": "new", "NEW_VAR": "value"})
+				})
+
+				it("compares the build images by the layer below the default directories", func() {
+					h.AssertEq(t, diffBuilders().BuildImage, &client.BuildImageDiff{
+						FromStack:    "some.stack.id",
+						ToStack:      "some.stack.id",
+						FromTopLayer: fromTopLayer.String(),
+						ToTopLayer:   toTopLayer.String(),
+						AddedMixins:  []string{"mixinY"},
+					})
+				})
+
+				it("reads the build config env from the layers above the buildpacks", func() {
+					diff := diffBuilders()
+					h.AssertEq(t, diff.BuildEnv, []client.EnvDiff{
+						{Name: "NEW_VAR", ToValue: "value", Change: client.ChangeAdded},
+						{Name: "OLD_VAR", FromValue: "value", Change: client.ChangeRemoved},
+						{Name: "SOME_VAR", FromValue: "old", ToValue: "new", Change: client.ChangeChanged},
+					})
+					h.AssertFalse(t, diff.BuildEnvUnknown)
+				})
+			})
+		})
+
+		when("the builders are the same", func() {
+			it("reports no differences", func() {
+				diff, err := subject.DiffBuilders(context.TODO(), client.DiffBuildersOptions{
+					From:   "some/builder:1",
+					To:     "some/builder:1",
+					Daemon: true,
+				})
+				h.AssertNil(t, err)
+				h.AssertTrue(t, diff.IsEmpty())
+			})
+		})
+
+		when("a builder can't be found", func() {
+			it("errors", func() {
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "missing/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+					Return(nil, image.ErrNotFound)
+
+				_, err := subject.DiffBuilders(context.TODO(), client.DiffBuildersOptions{
+					From:   "some/builder:1",
+					To:     "missing/builder",
+					Daemon: true,
+				})
+				h.AssertError(t, err, "builder 'missing/builder' cannot be found")
+			})
+		})
+
+		it("reports modules by version when a builder has several versions", func() {
+			h.AssertNil(t, toBuilder.SetLabel("io.buildpacks.builder.metadata", `{"buildpacks": [{"id": "some/bp", "version": "1.0.0"}, {"id": "some/bp", "version": "2.0.0"}]}`))
+
+			diff := diffBuilders()
+			h.AssertEq(t, diff.Buildpacks[1], client.BuildpackDiff{
+				ID:          "some/bp",
+				FromVersion: "1.0.0",
+				ToVersion:   "1.0.0, 2.0.0",
+				Change:      client.ChangeChanged,
+			})
+		})
+	})
+}
+
+// layeredImage is a fake image backed by a real image, whose layers are those of the fake image
+type layeredImage struct {
+	*fakes.Image
+	underlying v1.Image
+}
+
+func (i *layeredImage) UnderlyingImage() v1.Image {
+	return i.underlying
+}
+
+// withLayers backs a builder with an image of a base layer, a build image top layer, the layer with the default
+// directories pack adds to builders, a buildpack layer of the given sha256 and a build config env layer, returning it
+// along with the diff ID of the top layer of its build image
+func withLayers(t *testing.T, builderImage *fakes.Image, buildpackSHA string, env map[string]string) (imgutil.Image, v1.Hash) {
+	t.Helper()
+	h.AssertNil(t, builderImage.SetEnv("CNB_USER_ID", "1234"))
+	h.AssertNil(t, builderImage.SetEnv("CNB_GROUP_ID", "4321"))
+
+	baseLayer, err := random.Layer(512, "application/vnd.docker.image.rootfs.diff.tar")
+	h.AssertNil(t, err)
+	topLayer, err := random.Layer(512, "application/vnd.docker.image.rootfs.diff.tar")
+	h.AssertNil(t, err)
+	topDiffID, err := topLayer.DiffID()
+	h.AssertNil(t, err)
+
+	var headers []*tar.Header
+	for _, dir := range []string{"/workspace", "/layers"} {
+		headers = append(headers, &tar.Header{Typeflag: tar.TypeDir, Name: dir, Mode: 0755, ModTime: archive.NormalizedDateTime, Uid: 1234, Gid: 4321})
+	}
+	for _, dir := range []string{"/cnb", "/cnb/buildpacks", "/cnb/extensions", "/platform", "/platform/env", "/cnb/build-config", "/cnb/build-config/env"} {
+		headers = append(headers, &tar.Header{Typeflag: tar.TypeDir, Name: dir, Mode: 0755, ModTime: archive.NormalizedDateTime})
+	}
+	dirsLayer := tarLayer(t, headers, nil)
+
+	var envHeaders []*tar.Header
+	var envContents []string
+	for name, value := range env {
+		envHeaders = append(envHeaders, &tar.Header{Name: "/cnb/build-config/env/" + name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(value))})
+		envContents = append(envContents, value)
+	}
+	envLayer := tarLayer(t, envHeaders, envContents)
+	envDiffID, err := envLayer.DiffID()
+	h.AssertNil(t, err)
+	envPath := filepath.Join(t.TempDir(), "env.tar")
+	rc, err := envLayer.Uncompressed()
+	h.AssertNil(t, err)
+	contents, err := io.ReadAll(rc)
+	h.AssertNil(t, err)
+	h.AssertNil(t, os.WriteFile(envPath, contents, 0600))
+	h.AssertNil(t, builderImage.AddLayerWithDiffID(envPath, envDiffID.String()))
+
+	underlying, err := mutate.AppendLayers(empty.Image, baseLayer, topLayer, dirsLayer, envLayer)
+	h.AssertNil(t, err)
+	config, err := underlying.ConfigFile()
+	h.AssertNil(t, err)
+	dirsDiffID, err := dirsLayer.DiffID()
+	h.AssertNil(t, err)
+	baseDiffID, err := baseLayer.DiffID()
+	h.AssertNil(t, err)
+	config.RootFS.DiffIDs = []v1.Hash{baseDiffID, topDiffID, dirsDiffID, {Algorithm: "sha256", Hex: buildpackSHA}, envDiffID}
+	underlying, err = mutate.ConfigFile(underlying, config)
+	h.AssertNil(t, err)
+
+	return &layeredImage{Image: builderImage, underlying: underlying}, topDiffID
+}
+
+// tarLayer returns a layer of the given tar entries, the contents of regular files being given in order
+func tarLayer(t *testing.T, headers []*tar.Header, contents []string) v1.Layer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for i, header := range headers {
+		h.AssertNil(t, tw.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(contents[i]))
+			h.AssertNil(t, err)
+		}
+	}
+	h.AssertNil(t, tw.Close())
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	h.AssertNil(t, err)
+	return layer
+}
@@ -0,0 +1,428 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DiffImagesOptions is a configuration struct that controls comparing two app images.
+type DiffImagesOptions struct {
+	// Name of the image to compare from, e.g. the previous release of an app.
+	From string
+
+	// Name of the image to compare to.
+	To string
+
+	// Whether to look the images up in the daemon rather than in the registry.
+	Daemon bool
+}
+
+// Change describes how something differs between two images.
+type Change string
+
+const (
+	ChangeAdded      Change = "added"
+	ChangeRemoved    Change = "removed"
+	ChangeChanged    Change = "changed"
+	ChangeUpgraded   Change = "upgraded"
+	ChangeDowngraded Change = "downgraded"
+)
+
+// ImageDiff is the difference between two app images built using Cloud Native Buildpacks.
+type ImageDiff struct {
+	// Names of the compared images.
+	From, To string
+
+	// Change of run image, or nil if both images are based on the same run image.
+	RunImage *RunImageDiff
+
+	// Buildpacks added, removed or upgraded.
+	Buildpacks []BuildpackDiff
+
+	// Buildpack layers added, removed or whose contents changed.
+	Layers []LayerDiff
+
+	// Processes added, removed or whose command changed.
+	Processes []ProcessDiff
+
+	// Packages of the bill of materials or of the SBOM layer added, removed or upgraded.
+	Packages []PackageDiff
+
+	// Change of the SBOM layer, or nil if the SBOM of both images is the same. It is only reported when the packages
+	// of the SBOM layer of either image can't be read.
+	SBOM *LayerDiff
+}
+
+// RunImageDiff describes the run images of two app images.
+type RunImageDiff struct {
+	FromImage     string
+	ToImage       string
+	FromReference string
+	ToReference   string
+	FromTopLayer  string
+	ToTopLayer    string
+}
+
+// BuildpackDiff describes a buildpack that differs between two images.
+type BuildpackDiff struct {
+	ID          string
+	FromVersion string
+	ToVersion   string
+	Change      Change
+}
+
+// LayerDiff describes a layer that differs between two images.
+type LayerDiff struct {
+	// ID of the buildpack that contributed the layer, empty for the SBOM layer.
+	Buildpack  string
+	Name       string
+	FromDigest string
+	ToDigest   string
+	Change     Change
+}
+
+// ProcessDiff describes a process that differs between two images.
+type ProcessDiff struct {
+	Type        string
+	FromCommand string
+	ToCommand   string
+	Change      Change
+}
+
+// PackageDiff describes a package of the bill of materials that differs between two images.
+type PackageDiff struct {
+	Buildpack string
+	// Scope of the SBOM listing the package, either 'launch' or 'build', empty for the legacy bill of materials.
+	Scope       string
+	Name        string
+	FromVersion string
+	ToVersion   string
+	Change      Change
+}
+
+// IsEmpty reports whether the images don't differ.
+func (d *ImageDiff) IsEmpty() bool {
+	return d.RunImage == nil &&
+		len(d.Buildpacks) == 0 &&
+		len(d.Layers) == 0 &&
+		len(d.Processes) == 0 &&
+		len(d.Packages) == 0 &&
+		d.SBOM == nil
+}
+
+// diffImageMetadata is the metadata of an app image DiffImages compares
+type diffImageMetadata struct {
+	info   *ImageInfo
+	layers files.LayersMetadataCompat
+	// sbomPackages are the packages of the SBOM layer, nil when the image has no SBOM layer or it can't be read
+	sbomPackages map[string]map[string]sbomPackage
+}
+
+// DiffImages compares two app images, reporting changes to their run image, buildpacks, buildpack layers, processes
+// and packages, from both the legacy bill of materials and the SBOM layer. When the SBOM layer of either image can't
+// be read, changes to the SBOM are reported at the level of the layer.
+func (c *Client) DiffImages(ctx context.Context, opts DiffImagesOptions) (*ImageDiff, error) {
+	from, err := c.readDiffImageMetadata(ctx, opts.From, opts.Daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := c.readDiffImageMetadata(ctx, opts.To, opts.Daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageDiff{
+		From:       opts.From,
+		To:         opts.To,
+		RunImage:   diffRunImages(from.info, to.info),
+		Buildpacks: diffBuildpacks(from.info.Buildpacks, to.info.Buildpacks),
+		Layers:     diffLayers(from.layers.Buildpacks, to.layers.Buildpacks),
+		Processes:  diffProcesses(processesOf(from.info), processesOf(to.info)),
+		Packages:   append(diffPackages(from.info.BOM, to.info.BOM), diffSBOMLayerPackages(from.sbomPackages, to.sbomPackages)...),
+		SBOM:       diffSBOM(from, to),
+	}, nil
+}
+
+func (c *Client) readDiffImageMetadata(ctx context.Context, name string, daemon bool) (diffImageMetadata, error) {
+	img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		if errors.Cause(err) == image.ErrNotFound {
+			return diffImageMetadata{}, errors.Wrapf(image.ErrNotFound, "image '%s' cannot be found", name)
+		}
+		return diffImageMetadata{}, err
+	}
+
+	info, err := readImageInfo(img)
+	if err != nil {
+		return diffImageMetadata{}, errors.Wrapf(err, "reading metadata of image '%s'", name)
+	}
+
+	var layersMd files.LayersMetadataCompat
+	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &layersMd); err != nil {
+		return diffImageMetadata{}, err
+	}
+
+	md := diffImageMetadata{info: info, layers: layersMd}
+	if layersMd.BOM != nil {
+		if md.sbomPackages, err = sbomPackagesOf(img, name); err != nil {
+			c.logger.Debugf("Unable to read the SBOM layer of %s, comparing its digest: %s", style.Symbol(name), err)
+		}
+	}
+	return md, nil
+}
+
+func diffRunImages(from, to *ImageInfo) *RunImageDiff {
+	if from.Stack.RunImage.Image == to.Stack.RunImage.Image &&
+		from.Base.TopLayer == to.Base.TopLayer &&
+		from.Base.Reference == to.Base.Reference {
+		return nil
+	}
+
+	return &RunImageDiff{
+		FromImage:     from.Stack.RunImage.Image,
+		ToImage:       to.Stack.RunImage.Image,
+		FromReference: from.Base.Reference,
+		ToReference:   to.Base.Reference,
+		FromTopLayer:  from.Base.TopLayer,
+		ToTopLayer:    to.Base.TopLayer,
+	}
+}
+
+func diffBuildpacks(from, to []buildpack.GroupElement) []BuildpackDiff {
+	versions := func(buildpacks []buildpack.GroupElement) map[string]string {
+		byID := map[string]string{}
+		for _, bp := range buildpacks {
+			byID[bp.ID] = bp.Version
+		}
+		return byID
+	}
+
+	var diffs []BuildpackDiff
+	fromVersions, toVersions := versions(from), versions(to)
+	for _, id := range sortedKeys(fromVersions, toVersions) {
+		fromVersion, inFrom := fromVersions[id]
+		toVersion, inTo := toVersions[id]
+		if change, changed := versionChange(fromVersion, toVersion, inFrom, inTo); changed {
+			diffs = append(diffs, BuildpackDiff{ID: id, FromVersion: fromVersion, ToVersion: toVersion, Change: change})
+		}
+	}
+	return diffs
+}
+
+func diffLayers(from, to []buildpack.LayersMetadata) []LayerDiff {
+	digests := func(buildpacks []buildpack.LayersMetadata) map[string]string {
+		byName := map[string]string{}
+		for _, bp := range buildpacks {
+			for name, layer := range bp.Layers {
+				byName[bp.ID+"\x00"+name] = layer.SHA
+			}
+		}
+		return byName
+	}
+
+	var diffs []LayerDiff
+	fromDigests, toDigests := digests(from), digests(to)
+	for _, key := range sortedKeys(fromDigests, toDigests) {
+		fromDigest, inFrom := fromDigests[key]
+		toDigest, inTo := toDigests[key]
+		change, changed := digestChange(fromDigest, toDigest, inFrom, inTo)
+		if !changed {
+			continue
+		}
+		parts := strings.SplitN(key, "\x00", 2)
+		diffs = append(diffs, LayerDiff{
+			Buildpack:  parts[0],
+			Name:       parts[1],
+			FromDigest: fromDigest,
+			ToDigest:   toDigest,
+			Change:     change,
+		})
+	}
+	return diffs
+}
+
+func diffProcesses(from, to map[string]launch.Process) []ProcessDiff {
+	var diffs []ProcessDiff
+	for _, processType := range sortedKeys(from, to) {
+		fromProcess, inFrom := from[processType]
+		toProcess, inTo := to[processType]
+
+		var diff ProcessDiff
+		switch {
+		case !inFrom:
+			diff = ProcessDiff{Type: processType, ToCommand: processCommand(toProcess), Change: ChangeAdded}
+		case !inTo:
+			diff = ProcessDiff{Type: processType, FromCommand: processCommand(fromProcess), Change: ChangeRemoved}
+		case processCommand(fromProcess) != processCommand(toProcess) ||
+			fromProcess.WorkingDirectory != toProcess.WorkingDirectory ||
+			fromProcess.Direct != toProcess.Direct:
+			diff = ProcessDiff{Type: processType, FromCommand: processCommand(fromProcess), ToCommand: processCommand(toProcess), Change: ChangeChanged}
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func diffPackages(from, to []buildpack.BOMEntry) []PackageDiff {
+	versions := func(entries []buildpack.BOMEntry) map[string]string {
+		byName := map[string]string{}
+		for _, entry := range entries {
+			version := entry.Version
+			if version == "" {
+				if v, ok := entry.Metadata["version"].(string); ok {
+					version = v
+				}
+			}
+			byName[entry.Buildpack.ID+"\x00"+entry.Name] = version
+		}
+		return byName
+	}
+
+	var diffs []PackageDiff
+	fromVersions, toVersions := versions(from), versions(to)
+	for _, key := range sortedKeys(fromVersions, toVersions) {
+		fromVersion, inFrom := fromVersions[key]
+		toVersion, inTo := toVersions[key]
+		change, changed := versionChange(fromVersion, toVersion, inFrom, inTo)
+		if !changed {
+			continue
+		}
+		parts := strings.SplitN(key, "\x00", 2)
+		diffs = append(diffs, PackageDiff{
+			Buildpack:   parts[0],
+			Name:        parts[1],
+			FromVersion: fromVersion,
+			ToVersion:   toVersion,
+			Change:      change,
+		})
+	}
+	return diffs
+}
+
+// diffSBOMLayerPackages compares the packages of the SBOM layers when they could be read for both images
+func diffSBOMLayerPackages(from, to map[string]map[string]sbomPackage) []PackageDiff {
+	if from == nil || to == nil {
+		return nil
+	}
+
+	var diffs []PackageDiff
+	for _, key := range sortedKeys(from, to) {
+		parts := strings.SplitN(key, "\x00", 2)
+		for _, pkg := range diffSBOMPackages(from[key], to[key]) {
+			diffs = append(diffs, PackageDiff{
+				Buildpack:   parts[1],
+				Scope:       parts[0],
+				Name:        pkg.Name,
+				FromVersion: pkg.FromVersion,
+				ToVersion:   pkg.ToVersion,
+				Change:      pkg.Change,
+			})
+		}
+	}
+	return diffs
+}
+
+// diffSBOM compares the digests of the SBOM layers, as a fallback when their packages can't be compared
+func diffSBOM(from, to diffImageMetadata) *LayerDiff {
+	digest := func(md diffImageMetadata) string {
+		if md.layers.BOM == nil {
+			return ""
+		}
+		return md.layers.BOM.SHA
+	}
+
+	fromDigest, toDigest := digest(from), digest(to)
+	if fromDigest != "" && toDigest != "" && from.sbomPackages != nil && to.sbomPackages != nil {
+		return nil
+	}
+	change, changed := digestChange(fromDigest, toDigest, fromDigest != "", toDigest != "")
+	if !changed {
+		return nil
+	}
+	return &LayerDiff{Name: "sbom", FromDigest: fromDigest, ToDigest: toDigest, Change: change}
+}
+
+func processesOf(info *ImageInfo) map[string]launch.Process {
+	processes := map[string]launch.Process{}
+	if info.Processes.DefaultProcess != nil {
+		processes[info.Processes.DefaultProcess.Type] = *info.Processes.DefaultProcess
+	}
+	for _, process := range info.Processes.OtherProcesses {
+		processes[process.Type] = process
+	}
+	return processes
+}
+
+func processCommand(process launch.Process) string {
+	return strings.Join(append(append([]string{}, process.Command.Entries...), process.Args...), " ")
+}
+
+func versionChange(fromVersion, toVersion string, inFrom, inTo bool) (Change, bool) {
+	switch {
+	case !inFrom:
+		return ChangeAdded, true
+	case !inTo:
+		return ChangeRemoved, true
+	case fromVersion == toVersion:
+		return "", false
+	}
+
+	fromSemver, fromErr := semver.NewVersion(fromVersion)
+	toSemver, toErr := semver.NewVersion(toVersion)
+	switch {
+	case fromErr != nil || toErr != nil:
+		return ChangeChanged, true
+	case toSemver.GreaterThan(fromSemver):
+		return ChangeUpgraded, true
+	case toSemver.LessThan(fromSemver):
+		return ChangeDowngraded, true
+	default:
+		return ChangeChanged, true
+	}
+}
+
+func digestChange(fromDigest, toDigest string, inFrom, inTo bool) (Change, bool) {
+	switch {
+	case !inFrom && !inTo:
+		return "", false
+	case !inFrom:
+		return ChangeAdded, true
+	case !inTo:
+		return ChangeRemoved, true
+	case fromDigest != toDigest:
+		return ChangeChanged, true
+	default:
+		return "", false
+	}
+}
+
+// sortedKeys returns the keys of both maps, sorted
+func sortedKeys[V any](a, b map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffImages(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffImages", testDiffImages, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffImages(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		fromImage        *fakes.Image
+		toImage          *fakes.Image
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+
+		fromImage = fakes.NewImage("some/app:v1", "", nil)
+		h.AssertNil(t, fromImage.SetLabel("io.buildpacks.lifecycle.metadata", `{
+  "runImage": {"image": "some/run", "topLayer": "old-top-layer", "reference": "some/run@sha256:old"},
+  "sbom": {"sha": "sha256:old-sbom"},
+  "buildpacks": [
+    {"key": "some/node", "version": "1.0.0", "layers": {"node": {"sha": "sha256:node-1"}, "modules": {"sha": "sha256:modules-1"}}},
+    {"key": "some/procfile", "version": "2.0.0", "layers": {}}
+  ]
+}`))
+		h.AssertNil(t, fromImage.SetLabel("io.buildpacks.build.metadata", `{
+  "buildpacks": [{"id": "some/node", "version": "1.0.0"}, {"id": "some/procfile", "version": "2.0.0"}],
+  "bom": [
+    {"name": "node", "version": "18.0.0", "buildpack": {"id": "some/node"}},
+    {"name": "yarn", "metadata": {"version": "1.22.0"}, "buildpack": {"id": "some/node"}}
+  ],
+  "processes": [
+    {"type": "web", "command": "npm", "args": ["start"], "buildpackID": "some/procfile"},
+    {"type": "worker", "command": "node", "args": ["worker.js"], "buildpackID": "some/procfile"}
+  ]
+}`))
+		fakeImageFetcher.LocalImages["some/app:v1"] = fromImage
+
+		toImage = fakes.NewImage("some/app:v2", "", nil)
+		h.AssertNil(t, toImage.SetLabel("io.buildpacks.lifecycle.metadata", `{
+  "runImage": {"image": "some/run", "topLayer": "new-top-layer", "reference": "some/run@sha256:new"},
+  "sbom": {"sha": "sha256:new-sbom"},
+  "buildpacks": [
+    {"key": "some/node", "version": "1.1.0", "layers": {"node": {"sha": "sha256:node-2"}, "modules": {"sha": "sha256:modules-1"}, "cache": {"sha": "sha256:cache"}}},
+    {"key": "some/go", "version": "0.1.0", "layers": {}}
+  ]
+}`))
+		h.AssertNil(t, toImage.SetLabel("io.buildpacks.build.metadata", `{
+  "buildpacks": [{"id": "some/node", "version": "1.1.0"}, {"id": "some/go", "version": "0.1.0"}],
+  "bom": [
+    {"name": "node", "version": "20.0.0", "buildpack": {"id": "some/node"}},
+    {"name": "yarn", "metadata": {"version": "1.22.0"}, "buildpack": {"id": "some/node"}}
+  ],
+  "processes": [
+    {"type": "web", "command": "node", "args": ["server.js"], "buildpackID": "some/procfile"},
+    {"type": "worker", "command": "node", "args": ["worker.js"], "buildpackID": "some/procfile"}
+  ]
+}`))
+		fakeImageFetcher.LocalImages["some/app:v2"] = toImage
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, fromImage.Cleanup())
+		h.AssertNilE(t, toImage.Cleanup())
+	})
+
+	it("reports the differences between the images", func() {
+		diff, err := subject.DiffImages(context.TODO(), DiffImagesOptions{From: "some/app:v1", To: "some/app:v2", Daemon: true})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, diff.From, "some/app:v1")
+		h.AssertEq(t, diff.To, "some/app:v2")
+		h.AssertEq(t, diff.RunImage, &RunImageDiff{
+			FromImage:     "some/run",
+			ToImage:       "some/run",
+			FromReference: "some/run@sha256:old",
+			ToReference:   "some/run@sha256:new",
+			FromTopLayer:  "old-top-layer",
+			ToTopLayer:    "new-top-layer",
+		})
+		h.AssertEq(t, diff.Buildpacks, []BuildpackDiff{
+			{ID: "some/go", ToVersion: "0.1.0", Change: ChangeAdded},
+			{ID: "some/node", FromVersion: "1.0.0", ToVersion: "1.1.0", Change: ChangeUpgraded},
+			{ID: "some/procfile", FromVersion: "2.0.0", Change: ChangeRemoved},
+		})
+		h.AssertEq(t, diff.Layers, []LayerDiff{
+			{Buildpack: "some/node", Name: "cache", ToDigest: "sha256:cache", Change: ChangeAdded},
+			{Buildpack: "some/node", Name: "node", FromDigest: "sha256:node-1", ToDigest: "sha256:node-2", Change: ChangeChanged},
+		})
+		h.AssertEq(t, diff.Processes, []ProcessDiff{
+			{Type: "web", FromCommand: "npm start", ToCommand: "node server.js", Change: ChangeChanged},
+		})
+		h.AssertEq(t, diff.Packages, []PackageDiff{
+			{Buildpack: "some/node", Name: "node", FromVersion: "18.0.0", ToVersion: "20.0.0", Change: ChangeUpgraded},
+		})
+		h.AssertEq(t, diff.SBOM, &LayerDiff{Name: "sbom", FromDigest: "sha256:old-sbom", ToDigest: "sha256:new-sbom", Change: ChangeChanged})
+		h.AssertFalse(t, diff.IsEmpty())
+	})
+
+	when("the SBOM layers can be read", func() {
+		var tmpDir string
+
+		withSBOMLayer := func(img *fakes.Image, contents map[string]string) {
+			layerPath, diffID := writeSBOMLayer(t, tmpDir, img.Name(), contents)
+			h.AssertNil(t, img.AddLayerWithDiffID(layerPath, diffID))
+
+			var md map[string]interface{}
+			_, err := dist.GetLabel(img, "io.buildpacks.lifecycle.metadata", &md)
+			h.AssertNil(t, err)
+			md["sbom"] = map[string]string{"sha": diffID}
+			h.AssertNil(t, dist.SetLabel(img, "io.buildpacks.lifecycle.metadata", md))
+		}
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "pack.diff.images.test.")
+			h.AssertNil(t, err)
+
+			withSBOMLayer(fromImage, map[string]string{
+				"layers/sbom/launch/some_node/node/sbom.cdx.json": `{"bomFormat": "CycloneDX", "components": [
+  {"name": "openssl", "version": "3.0.0", "purl": "pkg:generic/openssl@3.0.0"}
+]}`,
+			})
+			withSBOMLayer(toImage, map[string]string{
+				"layers/sbom/launch/some_node/node/sbom.cdx.json": `{"bomFormat": "CycloneDX", "components": [
+  {"name": "openssl", "version": "3.0.1", "purl": "pkg:generic/openssl@3.0.1"}
+]}`,
+			})
+		})
+
+		it.After(func() {
+			h.AssertNilE(t, os.RemoveAll(tmpDir))
+		})
+
+		it("reports the package differences instead of the SBOM layer digests", func() {
+			diff, err := subject.DiffImages(context.TODO(), DiffImagesOptions{From: "some/app:v1", To: "some/app:v2", Daemon: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.Packages, []PackageDiff{
+				{Buildpack: "some/node", Name: "node", FromVersion: "18.0.0", ToVersion: "20.0.0", Change: ChangeUpgraded},
+				{Buildpack: "some/node", Scope: "launch", Name: "openssl", FromVersion: "3.0.0", ToVersion: "3.0.1", Change: ChangeUpgraded},
+			})
+			h.AssertNil(t, diff.SBOM)
+		})
+	})
+
+	it("reports no differences between an image and itself", func() {
+		diff, err := subject.DiffImages(context.TODO(), DiffImagesOptions{From: "some/app:v1", To: "some/app:v1", Daemon: true})
+		h.AssertNil(t, err)
+
+		h.AssertTrue(t, diff.IsEmpty())
+	})
+
+	it("looks the images up in the registry", func() {
+		fakeImageFetcher.RemoteImages["some/app:v1"] = fromImage
+		fakeImageFetcher.RemoteImages["some/app:v2"] = toImage
+
+		_, err := subject.DiffImages(context.TODO(), DiffImagesOptions{From: "some/app:v1", To: "some/app:v2"})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, fakeImageFetcher.FetchCalls["some/app:v1"].Daemon, false)
+		h.AssertEq(t, fakeImageFetcher.FetchCalls["some/app:v2"].Daemon, false)
+	})
+
+	it("errors when an image can't be found", func() {
+		_, err := subject.DiffImages(context.TODO(), DiffImagesOptions{From: "some/app:v1", To: "some/missing-app", Daemon: true})
+		h.AssertError(t, err, "image 'some/missing-app' cannot be found")
+	})
+}
+
+// writeSBOMLayer writes a layer holding the files of an SBOM layer to dir, returning its path and diff ID
+func writeSBOMLayer(t *testing.T, dir, name string, contents map[string]string) (string, string) {
+	t.Helper()
+
+	layerPath := filepath.Join(dir, fmt.Sprintf("%x.tar", sha256.Sum256([]byte(name))))
+	f, err := os.Create(layerPath)
+	h.AssertNil(t, err)
+	tw := tar.NewWriter(f)
+	var paths []string
+	for path := range contents {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: path, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents[path]))}))
+		_, err = tw.Write([]byte(contents[path]))
+		h.AssertNil(t, err)
+	}
+	h.AssertNil(t, tw.Close())
+	h.AssertNil(t, f.Close())
+
+	data, err := os.ReadFile(layerPath)
+	h.AssertNil(t, err)
+	return layerPath, fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
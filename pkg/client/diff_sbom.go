@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DiffSBOMOptions is a configuration struct that controls comparing the SBOMs of two app images.
+type DiffSBOMOptions struct {
+	// Name of the image to compare from, e.g. the previous release of an app.
+	From string
+
+	// Name of the image to compare to.
+	To string
+
+	// Whether to look the images up in the daemon rather than in the registry.
+	Daemon bool
+}
+
+// SBOMDiff is the difference between the SBOMs of two app images.
+type SBOMDiff struct {
+	// Names of the compared images.
+	From, To string
+
+	// Packages added, removed or whose version changed, grouped by the buildpack that listed them and their scope.
+	Groups []SBOMDiffGroup
+}
+
+// SBOMDiffGroup lists the packages that differ in the SBOMs of a buildpack.
+type SBOMDiffGroup struct {
+	// ID of the buildpack, or the name of its directory in the SBOM layer when the image doesn't list it.
+	Buildpack string
+
+	// Scope of the SBOMs, either 'launch' or 'build'.
+	Scope string
+
+	Packages []SBOMPackageDiff
+}
+
+// SBOMPackageDiff describes a package that differs between the SBOMs of two images.
+type SBOMPackageDiff struct {
+	Name string
+	// PURL is the package URL of the package without its version, when the SBOMs list one.
+	PURL        string
+	FromVersion string
+	ToVersion   string
+	Change      Change
+}
+
+// IsEmpty reports whether the SBOMs don't differ.
+func (d *SBOMDiff) IsEmpty() bool {
+	return len(d.Groups) == 0
+}
+
+// sbomPackage is a package listed by the SBOMs of a buildpack, with all the versions listed
+type sbomPackage struct {
+	name     string
+	purl     string
+	versions map[string]bool
+}
+
+func (p sbomPackage) version() string {
+	var versions []string
+	for v := range p.versions {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return strings.Join(versions, ", ")
+}
+
+// DiffSBOM compares the SBOMs of two app images, reporting packages added, removed and whose version changed. Packages
+// are compared within the SBOMs each buildpack wrote for either the launch or the build scope.
+func (c *Client) DiffSBOM(ctx context.Context, opts DiffSBOMOptions) (*SBOMDiff, error) {
+	from, err := c.readSBOMPackages(ctx, opts.From, opts.Daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := c.readSBOMPackages(ctx, opts.To, opts.Daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SBOMDiff{From: opts.From, To: opts.To}
+	for _, key := range sortedKeys(from, to) {
+		packages := diffSBOMPackages(from[key], to[key])
+		if len(packages) == 0 {
+			continue
+		}
+		parts := strings.SplitN(key, "\x00", 2)
+		diff.Groups = append(diff.Groups, SBOMDiffGroup{Scope: parts[0], Buildpack: parts[1], Packages: packages})
+	}
+	return diff, nil
+}
+
+// readSBOMPackages reads the packages of the SBOM layer of an image, keyed by scope and buildpack, then by package ID
+func (c *Client) readSBOMPackages(ctx context.Context, name string, daemon bool) (map[string]map[string]sbomPackage, error) {
+	img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		if errors.Cause(err) == image.ErrNotFound {
+			return nil, errors.Wrapf(image.ErrNotFound, "image '%s' cannot be found", name)
+		}
+		return nil, err
+	}
+	return sbomPackagesOf(img, name)
+}
+
+// sbomPackagesOf reads the packages of the SBOM layer of the named image, see readSBOMPackages
+func sbomPackagesOf(img imgutil.Image, name string) (map[string]map[string]sbomPackage, error) {
+	rc, buildpackIDs, err := sbomLayer(img, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	files, err := readSBOMFiles(rc, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM layer of '%s'", name)
+	}
+
+	groups := map[string]map[string]sbomPackage{}
+	for _, f := range files {
+		if f.Scope() == "" {
+			continue
+		}
+		components, err := f.Components()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading SBOM of '%s'", name)
+		}
+		if len(components) == 0 {
+			continue
+		}
+
+		buildpack := f.Buildpack()
+		if id, ok := buildpackIDs[buildpack]; ok {
+			buildpack = id
+		}
+		key := f.Scope() + "\x00" + buildpack
+		if groups[key] == nil {
+			groups[key] = map[string]sbomPackage{}
+		}
+		for _, component := range components {
+			pkg, ok := groups[key][component.ID()]
+			if !ok {
+				pkg = sbomPackage{name: component.Name, versions: map[string]bool{}}
+				if component.PURL != "" {
+					pkg.purl = component.ID()
+				}
+			}
+			if component.Version != "" {
+				pkg.versions[component.Version] = true
+			}
+			groups[key][component.ID()] = pkg
+		}
+	}
+	return groups, nil
+}
+
+func diffSBOMPackages(from, to map[string]sbomPackage) []SBOMPackageDiff {
+	var diffs []SBOMPackageDiff
+	for _, id := range sortedKeys(from, to) {
+		fromPackage, inFrom := from[id]
+		toPackage, inTo := to[id]
+		change, changed := versionChange(fromPackage.version(), toPackage.version(), inFrom, inTo)
+		if !changed {
+			continue
+		}
+
+		pkg := toPackage
+		if !inTo {
+			pkg = fromPackage
+		}
+		diffs = append(diffs, SBOMPackageDiff{
+			Name:        pkg.name,
+			PURL:        pkg.purl,
+			FromVersion: fromPackage.version(),
+			ToVersion:   toPackage.version(),
+			Change:      change,
+		})
+	}
+	return diffs
+}
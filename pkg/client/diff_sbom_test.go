@@ -0,0 +1,189 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffSBOM", testDiffSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffSBOM(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		fromImage        *fakes.Image
+		toImage          *fakes.Image
+		tmpDir           string
+		out              bytes.Buffer
+	)
+
+	// sbomImage returns an image whose SBOM layer holds the files
+	sbomImage := func(name string, contents map[string]string) *fakes.Image {
+		layerPath := filepath.Join(tmpDir, fmt.Sprintf("%x.tar", sha256.Sum256([]byte(name))))
+		f, err := os.Create(layerPath)
+		h.AssertNil(t, err)
+		tw := tar.NewWriter(f)
+		var paths []string
+		for path := range contents {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: path, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents[path]))}))
+			_, err = tw.Write([]byte(contents[path]))
+			h.AssertNil(t, err)
+		}
+		h.AssertNil(t, tw.Close())
+		h.AssertNil(t, f.Close())
+
+		data, err := os.ReadFile(layerPath)
+		h.AssertNil(t, err)
+		diffID := fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+
+		img := fakes.NewImage(name, "", nil)
+		h.AssertNil(t, img.AddLayerWithDiffID(layerPath, diffID))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{
+  "sbom": {"sha": "%s"},
+  "buildpacks": [{"key": "some-org/node", "version": "1.0.0"}, {"key": "some-org/npm", "version": "1.0.0"}]
+}`, diffID)))
+		return img
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.diff.sbom.test.")
+		h.AssertNil(t, err)
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+
+		fromImage = sbomImage("some/app:v1", map[string]string{
+			"layers/sbom/launch/some-org_node/node/sbom.cdx.json": `{"bomFormat": "CycloneDX", "components": [
+  {"name": "node", "version": "18.0.0", "purl": "pkg:generic/node@18.0.0"},
+  {"name": "yarn", "version": "1.22.0", "purl": "pkg:npm/yarn@1.22.0"}
+]}`,
+			"layers/sbom/launch/some-org_npm/modules/sbom.syft.json": `{"artifacts": [
+  {"name": "express", "version": "4.17.0", "purl": "pkg:npm/express@4.17.0"},
+  {"name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21"}
+]}`,
+			"layers/sbom/build/some-org_npm/sbom.cdx.json": `{"bomFormat": "CycloneDX", "components": [
+  {"name": "typescript", "version": "5.0.0"}
+]}`,
+			"layers/sbom/launch/some-org_npm/modules/sbom.toml": `ignored = true`,
+		})
+		fakeImageFetcher.LocalImages["some/app:v1"] = fromImage
+
+		toImage = sbomImage("some/app:v2", map[string]string{
+			"layers/sbom/launch/some-org_node/node/sbom.cdx.json": `{"bomFormat": "CycloneDX", "components": [
+  {"name": "node", "version": "20.0.0", "purl": "pkg:generic/node@20.0.0"},
+  {"name": "yarn", "version": "1.22.0", "purl": "pkg:npm/yarn@1.22.0"}
+]}`,
+			"layers/sbom/launch/some-org_node/node/sbom.spdx.json": `{"packages": [
+  {"name": "node", "versionInfo": "20.0.0", "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:generic/node@20.0.0"}]}
+]}`,
+			"layers/sbom/launch/some-org_npm/modules/sbom.syft.json": `{"artifacts": [
+  {"name": "express", "version": "4.16.0", "purl": "pkg:npm/express@4.16.0"},
+  {"name": "zod", "version": "3.0.0", "purl": "pkg:npm/zod@3.0.0?arch=all"}
+]}`,
+			"layers/sbom/build/some-org_npm/sbom.cdx.json": `{"bomFormat": "CycloneDX", "components": [
+  {"name": "typescript", "version": "5.1.0"}
+]}`,
+			"layers/sbom/launch/other_bp/sbom.syft.json": `{"artifacts": [{"name": "curl", "version": "8.0.0"}]}`,
+		})
+		fakeImageFetcher.LocalImages["some/app:v2"] = toImage
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, fromImage.Cleanup())
+		h.AssertNilE(t, toImage.Cleanup())
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	it("reports the packages that differ by buildpack and scope", func() {
+		diff, err := subject.DiffSBOM(context.TODO(), DiffSBOMOptions{From: "some/app:v1", To: "some/app:v2", Daemon: true})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, diff.From, "some/app:v1")
+		h.AssertEq(t, diff.To, "some/app:v2")
+		h.AssertEq(t, diff.Groups, []SBOMDiffGroup{
+			{
+				Buildpack: "some-org/npm",
+				Scope:     "build",
+				Packages: []SBOMPackageDiff{
+					{Name: "typescript", FromVersion: "5.0.0", ToVersion: "5.1.0", Change: ChangeUpgraded},
+				},
+			},
+			{
+				Buildpack: "other_bp",
+				Scope:     "launch",
+				Packages: []SBOMPackageDiff{
+					{Name: "curl", ToVersion: "8.0.0", Change: ChangeAdded},
+				},
+			},
+			{
+				Buildpack: "some-org/node",
+				Scope:     "launch",
+				Packages: []SBOMPackageDiff{
+					{Name: "node", PURL: "pkg:generic/node", FromVersion: "18.0.0", ToVersion: "20.0.0", Change: ChangeUpgraded},
+				},
+			},
+			{
+				Buildpack: "some-org/npm",
+				Scope:     "launch",
+				Packages: []SBOMPackageDiff{
+					{Name: "express", PURL: "pkg:npm/express", FromVersion: "4.17.0", ToVersion: "4.16.0", Change: ChangeDowngraded},
+					{Name: "lodash", PURL: "pkg:npm/lodash", FromVersion: "4.17.21", Change: ChangeRemoved},
+					{Name: "zod", PURL: "pkg:npm/zod", ToVersion: "3.0.0", Change: ChangeAdded},
+				},
+			},
+		})
+		h.AssertFalse(t, diff.IsEmpty())
+	})
+
+	it("reports no differences between the same image", func() {
+		diff, err := subject.DiffSBOM(context.TODO(), DiffSBOMOptions{From: "some/app:v1", To: "some/app:v1", Daemon: true})
+		h.AssertNil(t, err)
+
+		h.AssertTrue(t, diff.IsEmpty())
+	})
+
+	when("an image has no SBOM", func() {
+		it("errors", func() {
+			img := fakes.NewImage("some/app:no-sbom", "", nil)
+			fakeImageFetcher.LocalImages["some/app:no-sbom"] = img
+
+			_, err := subject.DiffSBOM(context.TODO(), DiffSBOMOptions{From: "some/app:v1", To: "some/app:no-sbom", Daemon: true})
+			h.AssertError(t, err, "could not find SBoM information on 'some/app:no-sbom'")
+		})
+	})
+
+	when("an image can't be found", func() {
+		it("errors", func() {
+			_, err := subject.DiffSBOM(context.TODO(), DiffSBOMOptions{From: "some/app:v1", To: "some/app:missing", Daemon: true})
+			h.AssertError(t, err, "image 'some/app:missing' cannot be found")
+		})
+	})
+}
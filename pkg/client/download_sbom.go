@@ -1,8 +1,16 @@
 package client
 
 import (
+	"archive/tar"
 	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/launch"
 	"github.com/buildpacks/lifecycle/layers"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/buildpacks/lifecycle/platform/files"
@@ -10,16 +18,31 @@ import (
 
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/sbom"
 )
 
 type DownloadSBOMOptions struct {
 	Daemon         bool
 	DestinationDir string
+
+	// Buildpacks limits the SBOMs downloaded to those of the buildpacks with these IDs. All SBOMs are downloaded when
+	// empty.
+	Buildpacks []string
+
+	// Format all SBOMs are merged into, as a single document. The SBOM files of each buildpack are extracted as they
+	// are when nil.
+	Format *sbom.Format
+
+	// Writer the merged SBOM is written to, instead of a file in DestinationDir. Requires a Format.
+	Writer io.Writer
 }
 
 // Deserialize just the subset of fields we need to avoid breaking changes
 type sbomMetadata struct {
-	BOM *files.LayerMetadata `json:"sbom" toml:"sbom"`
+	BOM        *files.LayerMetadata `json:"sbom" toml:"sbom"`
+	Buildpacks []struct {
+		ID string `json:"key" toml:"key"`
+	} `json:"buildpacks" toml:"buildpacks"`
 }
 
 func (s *sbomMetadata) isMissing() bool {
@@ -37,6 +60,10 @@ const (
 // It reads the SBOM metadata of an image then
 // pulls the corresponding diffId, if it exists
 func (c *Client) DownloadSBOM(name string, options DownloadSBOMOptions) error {
+	if options.Writer != nil && options.Format == nil {
+		return errors.New("writing the SBOM to stdout requires a format to merge it into")
+	}
+
 	img, err := c.imageFetcher.Fetch(context.Background(), name, image.FetchOptions{Daemon: options.Daemon, PullPolicy: image.PullNever})
 	if err != nil {
 		if errors.Cause(err) == image.ErrNotFound {
@@ -46,20 +73,111 @@ func (c *Client) DownloadSBOM(name string, options DownloadSBOMOptions) error {
 		return err
 	}
 
+	rc, _, err := sbomLayer(img, name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if options.Format == nil && len(options.Buildpacks) == 0 {
+		return layers.Extract(rc, options.DestinationDir)
+	}
+
+	files, err := readSBOMFiles(rc, options.Buildpacks)
+	if err != nil {
+		return errors.Wrapf(err, "reading SBOM layer of '%s'", name)
+	}
+	if len(files) == 0 {
+		return errors.Errorf("could not find SBoM files of buildpacks %s on '%s'", strings.Join(options.Buildpacks, ", "), name)
+	}
+	if options.Format == nil {
+		return writeSBOMFiles(files, options.DestinationDir)
+	}
+
+	doc, err := sbom.Merge(name, files)
+	if err != nil {
+		return err
+	}
+	contents, err := doc.Encode(*options.Format, c.version)
+	if err != nil {
+		return err
+	}
+	if options.Writer != nil {
+		_, err = options.Writer.Write(append(contents, '\n'))
+		return err
+	}
+
+	if err := os.MkdirAll(options.DestinationDir, 0755); err != nil {
+		return err
+	}
+	outputPath := filepath.Join(options.DestinationDir, "sbom"+options.Format.Extension())
+	if err := os.WriteFile(outputPath, contents, 0644); err != nil {
+		return errors.Wrapf(err, "writing SBOM to '%s'", outputPath)
+	}
+	c.logger.Infof("Merged %d components of the SBoM of '%s' into '%s'", len(doc.Components), name, outputPath)
+	return nil
+}
+
+// sbomLayer opens the SBOM layer of an image, also returning the IDs of the buildpacks of the image by their escaped
+// ID, which names their directory in the layer
+func sbomLayer(img imgutil.Image, name string) (io.ReadCloser, map[string]string, error) {
 	var sbomMD sbomMetadata
 	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &sbomMD); err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	if sbomMD.isMissing() {
-		return errors.Errorf("could not find SBoM information on '%s'", name)
+		return nil, nil, errors.Errorf("could not find SBoM information on '%s'", name)
 	}
 
 	rc, err := img.GetLayer(sbomMD.BOM.SHA)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	defer rc.Close()
 
-	return layers.Extract(rc, options.DestinationDir)
+	buildpackIDs := map[string]string{}
+	for _, bp := range sbomMD.Buildpacks {
+		buildpackIDs[launch.EscapeID(bp.ID)] = bp.ID
+	}
+	return rc, buildpackIDs, nil
+}
+
+// readSBOMFiles reads the files of the SBOM layer, keeping those of the given buildpacks
+func readSBOMFiles(r io.Reader, buildpacks []string) ([]sbom.File, error) {
+	var files []sbom.File
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		file := sbom.File{Path: strings.TrimPrefix(path.Clean("/"+header.Name), "/")}
+		if !file.MatchesBuildpacks(buildpacks) {
+			continue
+		}
+		if file.Contents, err = io.ReadAll(tr); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+}
+
+func writeSBOMFiles(files []sbom.File, destinationDir string) error {
+	for _, f := range files {
+		outputPath := filepath.Join(destinationDir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(outputPath, f.Contents, 0644); err != nil {
+			return errors.Wrapf(err, "writing SBOM to '%s'", outputPath)
+		}
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"archive/tar"
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
@@ -19,6 +20,7 @@ import (
 	"github.com/buildpacks/pack/pkg/archive"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
 	"github.com/buildpacks/pack/pkg/testmocks"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -108,6 +110,100 @@ func testDownloadSBOM(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("the SBOM layer lists the SBOMs of several buildpacks", func() {
+		var (
+			mockImage *testmocks.MockImage
+			tmpDir    string
+			layerFile string
+		)
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "pack.download.sbom.test.")
+			h.AssertNil(t, err)
+
+			layerFile = filepath.Join(tmpDir, "sbom.tar")
+			f, err := os.Create(layerFile)
+			h.AssertNil(t, err)
+			tw := tar.NewWriter(f)
+			for _, entry := range []struct{ path, content string }{
+				{"layers/sbom/launch/some-org_node/node/sbom.cdx.json", `{"bomFormat": "CycloneDX", "components": [{"type": "library", "name": "node", "version": "18.0.0", "purl": "pkg:generic/node@18.0.0"}]}`},
+				{"layers/sbom/launch/some-org_npm/sbom.syft.json", `{"artifacts": [{"name": "express", "version": "4.18.0", "purl": "pkg:npm/express@4.18.0", "licenses": ["MIT"]}]}`},
+			} {
+				h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: entry.path, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(entry.content))}))
+				_, err = tw.Write([]byte(entry.content))
+				h.AssertNil(t, err)
+			}
+			h.AssertNil(t, tw.Close())
+			h.AssertNil(t, f.Close())
+
+			data, err := os.ReadFile(layerFile)
+			h.AssertNil(t, err)
+			shasum := fmt.Sprintf("%x", sha256.Sum256(data))
+
+			mockImage = testmocks.NewImage("some/image", "", nil)
+			mockImage.AddLayerWithDiffID(layerFile, "sha256:"+shasum)
+			h.AssertNil(t, mockImage.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{"sbom": {"sha": "sha256:%s"}}`, shasum)))
+		})
+
+		it.After(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		it("extracts the SBOMs of the given buildpacks", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(mockImage, nil)
+
+			destDir := filepath.Join(tmpDir, "out")
+			err := subject.DownloadSBOM("some/image", DownloadSBOMOptions{Daemon: true, DestinationDir: destDir, Buildpacks: []string{"some-org/npm"}})
+			h.AssertNil(t, err)
+
+			h.AssertPathExists(t, filepath.Join(destDir, "layers", "sbom", "launch", "some-org_npm", "sbom.syft.json"))
+			h.AssertPathDoesNotExists(t, filepath.Join(destDir, "layers", "sbom", "launch", "some-org_node"))
+		})
+
+		it("errors when no buildpack matches", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(mockImage, nil)
+
+			err := subject.DownloadSBOM("some/image", DownloadSBOMOptions{Daemon: true, DestinationDir: tmpDir, Buildpacks: []string{"some-org/go"}})
+			h.AssertError(t, err, "could not find SBoM files of buildpacks some-org/go on 'some/image'")
+		})
+
+		it("merges the SBOMs into a file of the format", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(mockImage, nil)
+
+			format := sbom.FormatSPDXJSON
+			err := subject.DownloadSBOM("some/image", DownloadSBOMOptions{Daemon: true, DestinationDir: tmpDir, Format: &format})
+			h.AssertNil(t, err)
+
+			contents, err := os.ReadFile(filepath.Join(tmpDir, "sbom.spdx.json"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), `"spdxVersion": "SPDX-2.3"`)
+			h.AssertContains(t, string(contents), `"name": "express"`)
+			h.AssertContains(t, string(contents), `"name": "node"`)
+			h.AssertContains(t, out.String(), "Merged 2 components of the SBoM of 'some/image'")
+		})
+
+		it("writes the merged SBOM to the writer", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(mockImage, nil)
+
+			var buf bytes.Buffer
+			format := sbom.FormatCycloneDXJSON
+			err := subject.DownloadSBOM("some/image", DownloadSBOMOptions{Daemon: true, Format: &format, Writer: &buf, Buildpacks: []string{"some-org/node"}})
+			h.AssertNil(t, err)
+
+			h.AssertContains(t, buf.String(), `"bomFormat": "CycloneDX"`)
+			h.AssertContains(t, buf.String(), `"purl": "pkg:generic/node@18.0.0"`)
+			h.AssertNotContains(t, buf.String(), "express")
+		})
+	})
+
+	when("writing to a writer without a format", func() {
+		it("errors", func() {
+			err := subject.DownloadSBOM("some/image", DownloadSBOMOptions{Daemon: true, Writer: &bytes.Buffer{}})
+			h.AssertError(t, err, "writing the SBOM to stdout requires a format to merge it into")
+		})
+	})
+
 	when("the image doesn't exist", func() {
 		it("returns nil", func() {
 			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/non-existent-image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(nil, image.ErrNotFound)
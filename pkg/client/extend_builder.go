@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// ExtendBuilderOptions is a configuration object used to change the behavior of
+// ExtendBuilder.
+type ExtendBuilderOptions struct {
+	// Name of the builder to extend.
+	BaseBuilder string
+
+	// Name of the new builder.
+	BuilderName string
+
+	// The base directory to use to resolve relative buildpack and extension URIs.
+	RelativeBaseDir string
+
+	// Buildpacks to add to the builder. A buildpack replaces all versions of the buildpack with the same ID on the
+	// base builder, and references to them in the order are updated to the new version. The dependencies of a
+	// buildpackage are added alongside the versions already on the builder.
+	Buildpacks []pubbldr.ModuleConfig
+
+	// Extensions to add to the builder, replacing extensions with the same ID as buildpacks do.
+	Extensions []pubbldr.ModuleConfig
+
+	// Buildpacks to remove from the builder. When no version is given, all versions of the buildpack are removed.
+	RemoveBuildpacks []dist.ModuleInfo
+
+	// Extensions to remove from the builder. When no version is given, all versions of the extension are removed.
+	RemoveExtensions []dist.ModuleInfo
+
+	// Order of the buildpacks replacing the order of the base builder. The order of the base builder is kept when nil.
+	Order dist.Order
+
+	// Order of the extensions replacing the order of the base builder. The order of the base builder is kept when nil.
+	OrderExtensions dist.Order
+
+	// Build config environment variables to add to the builder, overriding variables of the base builder with the same
+	// name.
+	BuildConfigEnv map[string]string
+
+	// Skip saving the builder locally, directly publish to a registry.
+	// Requires BuilderName to be a valid registry location.
+	Publish bool
+
+	// Buildpack registry name. Defines where all registry buildpacks will be pulled from.
+	Registry string
+
+	// Strategy for updating images before extending the builder.
+	PullPolicy image.PullPolicy
+}
+
+// ExtendBuilder creates a builder from an existing builder, adding, replacing and removing buildpacks and extensions
+// and editing the order and build config environment. Layers of the base builder that aren't changed are reused.
+func (c *Client) ExtendBuilder(ctx context.Context, opts ExtendBuilderOptions) error {
+	baseImage, err := c.imageFetcher.Fetch(ctx, opts.BaseBuilder, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return errors.Wrapf(err, "fetching builder %s", style.Symbol(opts.BaseBuilder))
+	}
+
+	bldr, err := builder.FromImage(baseImage)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.BaseBuilder))
+	}
+	baseImage.Rename(opts.BuilderName)
+
+	c.logger.Debugf("Extending builder %s as %s", style.Symbol(opts.BaseBuilder), style.Symbol(opts.BuilderName))
+
+	order := bldr.Order()
+	orderExtensions := bldr.OrderExtensions()
+
+	for _, info := range opts.RemoveBuildpacks {
+		if err := removeFromBuilder(buildpack.KindBuildpack, bldr.Buildpacks(), info, bldr.RemoveBuildpack); err != nil {
+			return err
+		}
+	}
+	for _, info := range opts.RemoveExtensions {
+		if err := removeFromBuilder(buildpack.KindExtension, bldr.Extensions(), info, bldr.RemoveExtension); err != nil {
+			return err
+		}
+	}
+
+	createOpts := CreateBuilderOptions{
+		RelativeBaseDir: opts.RelativeBaseDir,
+		BuilderName:     opts.BuilderName,
+		Publish:         opts.Publish,
+		Format:          FormatImage,
+		Registry:        opts.Registry,
+		PullPolicy:      opts.PullPolicy,
+	}
+
+	existingBuildpacks := bldr.Buildpacks()
+	var addedBuildpacks []dist.ModuleInfo
+	for _, config := range opts.Buildpacks {
+		// the buildpack named by the config is added before the dependencies of its package
+		added := len(bldr.Buildpacks())
+		if err := c.addConfig(ctx, buildpack.KindBuildpack, config, createOpts, bldr); err != nil {
+			return errors.Wrap(err, "failed to add buildpacks to builder")
+		}
+		addedBuildpacks = append(addedBuildpacks, bldr.Buildpacks()[added])
+	}
+	order = replaceModules(existingBuildpacks, addedBuildpacks, order, bldr.RemoveBuildpack)
+
+	existingExtensions := bldr.Extensions()
+	var addedExtensions []dist.ModuleInfo
+	for _, config := range opts.Extensions {
+		added := len(bldr.Extensions())
+		if err := c.addConfig(ctx, buildpack.KindExtension, config, createOpts, bldr); err != nil {
+			return errors.Wrap(err, "failed to add extensions to builder")
+		}
+		addedExtensions = append(addedExtensions, bldr.Extensions()[added])
+	}
+	orderExtensions = replaceModules(existingExtensions, addedExtensions, orderExtensions, bldr.RemoveExtension)
+
+	if opts.Order != nil {
+		order = opts.Order
+	}
+	if opts.OrderExtensions != nil {
+		orderExtensions = opts.OrderExtensions
+	}
+	// the order is always written again, so that it's resolved against the buildpacks on the new builder
+	bldr.SetOrder(order)
+	bldr.SetOrderExtensions(orderExtensions)
+
+	if len(opts.BuildConfigEnv) > 0 {
+		bldr.SetBuildConfigEnv(opts.BuildConfigEnv)
+	}
+
+	return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+}
+
+// removeFromBuilder removes the modules matching info from the builder, erroring when none is on the builder
+func removeFromBuilder(kind string, modulesOnBuilder []dist.ModuleInfo, info dist.ModuleInfo, remove func(dist.ModuleInfo)) error {
+	found := false
+	for _, module := range modulesOnBuilder {
+		if module.ID == info.ID && (info.Version == "" || module.Version == info.Version) {
+			remove(module)
+			found = true
+		}
+	}
+	if !found {
+		return errors.Errorf("%s %s was not found on the builder", kind, style.Symbol(info.FullName()))
+	}
+	return nil
+}
+
+// replaceModules removes the modules that have the same ID as a module named by a config added to the builder, and
+// points the references to them in the order to the added version
+func replaceModules(existing, added []dist.ModuleInfo, order dist.Order, remove func(dist.ModuleInfo)) dist.Order {
+	addedVersions := map[string]string{}
+	for _, info := range added {
+		addedVersions[info.ID] = info.Version
+	}
+
+	for _, info := range existing {
+		if _, ok := addedVersions[info.ID]; ok {
+			remove(info)
+		}
+	}
+
+	var updated dist.Order
+	for _, entry := range order {
+		var group []dist.ModuleRef
+		for _, ref := range entry.Group {
+			if version, ok := addedVersions[ref.ID]; ok && ref.Version != "" {
+				ref.Version = version
+			}
+			group = append(group, ref)
+		}
+		updated = append(updated, dist.OrderEntry{Group: group})
+	}
+	return updated
+}
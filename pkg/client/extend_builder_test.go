@@ -0,0 +1,212 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExtendBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "extend_builder", testExtendBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExtendBuilder(t *testing.T, when spec.G, it spec.S) {
+	when("#ExtendBuilder", func() {
+		var (
+			mockController          *gomock.Controller
+			mockImageFetcher        *testmocks.MockImageFetcher
+			mockBuildpackDownloader *testmocks.MockBuildpackDownloader
+			baseBuilder             *fakes.Image
+			opts                    client.ExtendBuilderOptions
+			subject                 *client.Client
+			out                     bytes.Buffer
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+			mockBuildpackDownloader = testmocks.NewMockBuildpackDownloader(mockController)
+
+			baseBuilder = fakes.NewImage("some/base-builder", "", nil)
+			h.AssertNil(t, baseBuilder.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			h.AssertNil(t, baseBuilder.SetEnv("CNB_USER_ID", "1234"))
+			h.AssertNil(t, baseBuilder.SetEnv("CNB_GROUP_ID", "4321"))
+			h.AssertNil(t, baseBuilder.SetLabel("io.buildpacks.builder.metadata", `{
+  "buildpacks": [{"id": "some/bp", "version": "1.0.0"}, {"id": "other/bp", "version": "1.0.0"}],
+  "lifecycle": {"version": "0.20.0", "apis": {"buildpack": {"deprecated": [], "supported": ["0.2", "0.3"]}, "platform": {"deprecated": [], "supported": ["0.12"]}}},
+  "images": [{"image": "some/run-image"}]
+}`))
+			h.AssertNil(t, baseBuilder.SetLabel("io.buildpacks.buildpack.layers", `{
+  "some/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:some-bp-1"}},
+  "other/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:other-bp-1"}}
+}`))
+			h.AssertNil(t, baseBuilder.SetLabel("io.buildpacks.buildpack.order", `[{"group": [{"id": "some/bp", "version": "1.0.0"}]}]`))
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/base-builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(baseBuilder, nil)
+
+			var err error
+			subject, err = client.NewClient(
+				client.WithLogger(logging.NewLogWithWriters(&out, &out)),
+				client.WithFetcher(mockImageFetcher),
+				client.WithBuildpackDownloader(mockBuildpackDownloader),
+			)
+			h.AssertNil(t, err)
+
+			opts = client.ExtendBuilderOptions{
+				BaseBuilder: "some/base-builder",
+				BuilderName: "some/builder",
+				PullPolicy:  image.PullNever,
+			}
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		builderMetadata := func() builder.Metadata {
+			var metadata builder.Metadata
+			_, err := dist.GetLabel(baseBuilder, "io.buildpacks.builder.metadata", &metadata)
+			h.AssertNil(t, err)
+			return metadata
+		}
+
+		it("replaces buildpacks with the same ID and updates the order", func() {
+			bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.3"),
+				WithInfo:   dist.ModuleInfo{ID: "some/bp", Version: "2.0.0"},
+				WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+			}, 0644)
+			h.AssertNil(t, err)
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "./some-bp", gomock.Any()).Return(bp, nil, nil)
+			opts.Buildpacks = []pubbldr.ModuleConfig{{ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "./some-bp"}}}}
+
+			h.AssertNil(t, subject.ExtendBuilder(context.TODO(), opts))
+
+			h.AssertTrue(t, baseBuilder.IsSaved())
+			h.AssertEq(t, baseBuilder.Name(), "some/builder")
+			h.AssertEq(t, builderMetadata().Buildpacks, []dist.ModuleInfo{{ID: "other/bp", Version: "1.0.0"}, {ID: "some/bp", Version: "2.0.0"}})
+
+			var order dist.Order
+			_, err = dist.GetLabel(baseBuilder, "io.buildpacks.buildpack.order", &order)
+			h.AssertNil(t, err)
+			h.AssertEq(t, order[0].Group[0].FullName(), "some/bp@2.0.0")
+
+			layers := dist.ModuleLayers{}
+			_, err = dist.GetLabel(baseBuilder, "io.buildpacks.buildpack.layers", &layers)
+			h.AssertNil(t, err)
+			_, ok := layers["some/bp"]["1.0.0"]
+			h.AssertFalse(t, ok)
+			_, ok = layers["some/bp"]["2.0.0"]
+			h.AssertTrue(t, ok)
+			_, err = baseBuilder.FindLayerWithPath("/cnb/buildpacks/some_bp/.wh.1.0.0")
+			h.AssertNil(t, err)
+		})
+
+		it("removes buildpacks", func() {
+			opts.RemoveBuildpacks = []dist.ModuleInfo{{ID: "other/bp"}}
+
+			h.AssertNil(t, subject.ExtendBuilder(context.TODO(), opts))
+
+			h.AssertEq(t, builderMetadata().Buildpacks, []dist.ModuleInfo{{ID: "some/bp", Version: "1.0.0"}})
+			_, err := baseBuilder.FindLayerWithPath("/cnb/buildpacks/other_bp/.wh.1.0.0")
+			h.AssertNil(t, err)
+		})
+
+		it("errors when a buildpack still in the order is removed", func() {
+			opts.RemoveBuildpacks = []dist.ModuleInfo{{ID: "some/bp", Version: "1.0.0"}}
+
+			err := subject.ExtendBuilder(context.TODO(), opts)
+			h.AssertError(t, err, "no versions of buildpack 'some/bp' were found on the builder")
+		})
+
+		it("replaces the order", func() {
+			opts.RemoveBuildpacks = []dist.ModuleInfo{{ID: "some/bp"}}
+			opts.Order = dist.Order{{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "other/bp"}}}}}
+
+			h.AssertNil(t, subject.ExtendBuilder(context.TODO(), opts))
+
+			var order dist.Order
+			_, err := dist.GetLabel(baseBuilder, "io.buildpacks.buildpack.order", &order)
+			h.AssertNil(t, err)
+			h.AssertEq(t, order[0].Group[0].ID, "other/bp")
+		})
+
+		it("sets build config environment variables", func() {
+			opts.BuildConfigEnv = map[string]string{"SOME_VAR": "some-value"}
+
+			h.AssertNil(t, subject.ExtendBuilder(context.TODO(), opts))
+
+			_, err := baseBuilder.FindLayerWithPath("/cnb/build-config/env/SOME_VAR")
+			h.AssertNil(t, err)
+		})
+
+		it("keeps the buildpacks with the same ID as a dependency of an added package", func() {
+			dep, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.3"),
+				WithInfo:   dist.ModuleInfo{ID: "other/bp", Version: "2.0.0"},
+				WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+			}, 0644)
+			h.AssertNil(t, err)
+			meta, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:  api.MustParse("0.3"),
+				WithInfo: dist.ModuleInfo{ID: "meta/bp", Version: "1.0.0"},
+				WithOrder: dist.Order{{Group: []dist.ModuleRef{
+					{ModuleInfo: dist.ModuleInfo{ID: "other/bp", Version: "2.0.0"}},
+				}}},
+			}, 0644)
+			h.AssertNil(t, err)
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "./meta-bp", gomock.Any()).Return(meta, []buildpack.BuildModule{dep}, nil)
+			opts.Buildpacks = []pubbldr.ModuleConfig{{ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "./meta-bp"}}}}
+
+			h.AssertNil(t, subject.ExtendBuilder(context.TODO(), opts))
+
+			h.AssertEq(t, builderMetadata().Buildpacks, []dist.ModuleInfo{
+				{ID: "some/bp", Version: "1.0.0"},
+				{ID: "other/bp", Version: "1.0.0"},
+				{ID: "meta/bp", Version: "1.0.0"},
+				{ID: "other/bp", Version: "2.0.0"},
+			})
+			_, err = baseBuilder.FindLayerWithPath("/cnb/buildpacks/other_bp/.wh.1.0.0")
+			h.AssertNotNil(t, err)
+		})
+
+		it("errors when a removed buildpack is in the order of a composite buildpack on the builder", func() {
+			h.AssertNil(t, baseBuilder.SetLabel("io.buildpacks.buildpack.layers", `{
+  "some/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:some-bp-1"}},
+  "other/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:other-bp-1"}},
+  "meta/bp": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:meta-bp-1", "order": [{"group": [{"id": "other/bp", "version": "1.0.0"}]}]}}
+}`))
+			opts.RemoveBuildpacks = []dist.ModuleInfo{{ID: "other/bp"}}
+
+			err := subject.ExtendBuilder(context.TODO(), opts)
+			h.AssertError(t, err, "buildpack 'meta/bp@1.0.0' refers to buildpack 'other/bp@1.0.0', which is not on the builder")
+			h.AssertFalse(t, baseBuilder.IsSaved())
+		})
+
+		it("errors when a buildpack to remove isn't on the builder", func() {
+			opts.RemoveBuildpacks = []dist.ModuleInfo{{ID: "missing/bp", Version: "1.0.0"}}
+
+			err := subject.ExtendBuilder(context.TODO(), opts)
+			h.AssertError(t, err, "buildpack 'missing/bp@1.0.0' was not found on the builder")
+		})
+	})
+}
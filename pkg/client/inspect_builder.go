@@ -50,6 +50,15 @@ type BuilderInfo struct {
 
 	// Detailed ordering of extensions.
 	OrderExtensions pubbldr.DetectionOrder
+
+	// Build image the builder was created from, nil for builders created by older versions of pack.
+	BuildImage *builder.BuildImageMetadata
+
+	// Files in the build config env directory of the builder, by name.
+	BuildConfigEnv map[string]string
+
+	// Target of the builder image, nil if its platform can't be read.
+	Target *dist.Target
 }
 
 // BuildpackInfoKey contains all information needed to determine buildpack equivalence.
@@ -105,5 +114,8 @@ func (c *Client) InspectBuilder(name string, daemon bool, modifiers ...BuilderIn
 		CreatedBy:       info.CreatedBy,
 		Extensions:      info.Extensions,
 		OrderExtensions: info.OrderExtensions,
+		BuildImage:      info.BuildImage,
+		BuildConfigEnv:  info.BuildConfigEnv,
+		Target:          info.Target,
 	}, nil
 }
@@ -344,6 +344,7 @@ func testInspectBuilder(t *testing.T, when spec.G, it spec.S) {
 								Name:    "pack",
 								Version: "1.2.3",
 							},
+							Target: &dist.Target{OS: "linux", Arch: "amd64"},
 						}
 
 						if diff := cmp.Diff(want, *builderInfo); diff != "" {
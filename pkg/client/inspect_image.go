@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/Masterminds/semver"
+	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/lifecycle/buildpack"
 	"github.com/buildpacks/lifecycle/launch"
 	"github.com/buildpacks/lifecycle/platform"
@@ -101,6 +102,11 @@ func (c *Client) InspectImage(name string, daemon bool) (*ImageInfo, error) {
 		return nil, err
 	}
 
+	return readImageInfo(img)
+}
+
+// readImageInfo reads the metadata describing an app image from its labels
+func readImageInfo(img imgutil.Image) (*ImageInfo, error) {
 	var layersMd layersMetadata
 	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &layersMd); err != nil {
 		return nil, err
@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/lockfile"
+)
+
+// withLockfile calls fn with a copy of the client that verifies and records the references it resolves against the
+// given lockfile, which is saved once fn succeeds
+func (c *Client) withLockfile(path string, mode lockfile.Mode, fn func(locking *Client) error) error {
+	lock, err := lockfile.Open(path, mode, c.logger)
+	if err != nil {
+		return err
+	}
+
+	locking := *c
+	locking.lock = lock
+	if err := fn(&locking); err != nil {
+		return err
+	}
+	return lock.Save()
+}
+
+// lockImage records the manifest digest of an image, if building with a lockfile. An image only in the daemon, such
+// as one built locally, has no digest in a registry that a later build could verify, so it isn't locked.
+func (c *Client) lockImage(ctx context.Context, kind lockfile.Kind, ref string, img imgutil.Image) error {
+	if c.lock == nil {
+		return nil
+	}
+
+	digest, err := c.registryDigest(ctx, ref, img)
+	if err != nil {
+		return err
+	}
+	if digest == "" {
+		c.logger.Warnf("Not locking %s %s, as it was never pulled from or pushed to its registry", kind, style.Symbol(ref))
+		return nil
+	}
+	platform, err := imagePlatform(img)
+	if err != nil {
+		return err
+	}
+	return c.lock.Resolve(lockfile.Entry{Kind: kind, Ref: ref, Platform: platform, Digest: digest})
+}
+
+// lockLayoutImage records the manifest digest of an image read from an OCI layout, if building with a lockfile
+func (c *Client) lockLayoutImage(kind lockfile.Kind, ref string, img v1.Image) error {
+	if c.lock == nil {
+		return nil
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return errors.Wrapf(err, "getting digest of %s %s", kind, style.Symbol(ref))
+	}
+	config, err := img.ConfigFile()
+	if err != nil {
+		return errors.Wrapf(err, "reading config of %s %s", kind, style.Symbol(ref))
+	}
+	return c.lock.Resolve(lockfile.Entry{
+		Kind:     kind,
+		Ref:      ref,
+		Platform: targetPlatform(&dist.Target{OS: config.OS, Arch: config.Architecture, ArchVariant: config.Variant}),
+		Digest:   digest.String(),
+	})
+}
+
+// lockModules records the digest of the modules fetched from a remote reference, if building with a lockfile. Modules
+// on the local filesystem are part of the project and aren't locked.
+func (c *Client) lockModules(kind string, ref string, locatorType buildpack.LocatorType, target *dist.Target, modules []buildpack.BuildModule) error {
+	if c.lock == nil || len(modules) == 0 || !isRemoteLocator(locatorType, ref) {
+		return nil
+	}
+
+	hash := sha256.New()
+	for _, module := range modules {
+		if err := copyBlob(hash, module); err != nil {
+			return errors.Wrapf(err, "computing digest of %s %s", kind, style.Symbol(ref))
+		}
+	}
+
+	info := modules[0].Descriptor().Info()
+	return c.lock.Resolve(lockfile.Entry{
+		Kind:     lockfile.Kind(kind),
+		Ref:      ref,
+		Platform: targetPlatform(target),
+		ID:       info.ID,
+		Version:  info.Version,
+		Digest:   "sha256:" + hex.EncodeToString(hash.Sum(nil)),
+	})
+}
+
+// lockBlob records the digest of a blob downloaded from a remote URI, if building with a lockfile
+func (c *Client) lockBlob(kind lockfile.Kind, uri string, platform string, b blob.Blob) error {
+	if c.lock == nil || !paths.IsURI(uri) || strings.HasPrefix(uri, "file:") {
+		return nil
+	}
+
+	hash := sha256.New()
+	if err := copyBlob(hash, b); err != nil {
+		return errors.Wrapf(err, "computing digest of %s %s", kind, style.Symbol(uri))
+	}
+	return c.lock.Resolve(lockfile.Entry{
+		Kind:     kind,
+		Ref:      uri,
+		Platform: platform,
+		Digest:   "sha256:" + hex.EncodeToString(hash.Sum(nil)),
+	})
+}
+
+func isRemoteLocator(locatorType buildpack.LocatorType, locator string) bool {
+	switch locatorType {
+	case buildpack.RegistryLocator, buildpack.PackageLocator:
+		return true
+	case buildpack.URILocator:
+		return paths.IsURI(locator) && !strings.HasPrefix(locator, "file:")
+	default:
+		return false
+	}
+}
+
+func copyBlob(w io.Writer, b blob.Blob) error {
+	rc, err := b.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+// registryDigest returns the digest of the manifest of an image in the repository of ref. An image in the daemon is
+// identified by its ID instead, so it is looked up by the repository digest the daemon recorded when the image was
+// pulled or pushed. It returns an empty digest when the daemon has none for the repository.
+func (c *Client) registryDigest(ctx context.Context, ref string, img imgutil.Image) (string, error) {
+	id, err := img.Identifier()
+	if err != nil {
+		return "", errors.Wrapf(err, "getting identifier of image %s", style.Symbol(img.Name()))
+	}
+	if id == nil {
+		return "", errors.Errorf("image %s has no identifier", style.Symbol(img.Name()))
+	}
+	localID, ok := id.(local.IDIdentifier)
+	if !ok {
+		digest := id.String()
+		if i := strings.LastIndex(digest, "@"); i >= 0 {
+			digest = digest[i+1:]
+		}
+		return digest, nil
+	}
+
+	imageRef, err := name.ParseReference(ref, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing image reference %s", style.Symbol(ref))
+	}
+	inspect, _, err := c.docker.ImageInspectWithRaw(ctx, localID.String())
+	if err != nil {
+		return "", errors.Wrapf(err, "inspecting image %s", style.Symbol(img.Name()))
+	}
+	for _, repoDigest := range inspect.RepoDigests {
+		digestRef, err := name.NewDigest(repoDigest, name.WeakValidation)
+		if err != nil {
+			continue
+		}
+		if digestRef.Context().Name() == imageRef.Context().Name() {
+			return c.platformManifestDigest(ctx, digestRef, localID.String(), img)
+		}
+	}
+	return "", nil
+}
+
+// platformManifestDigest returns the digest of the manifest of an image in the daemon, given the repository digest the
+// daemon recorded for it. When the image was pulled from an index, that is the digest of the index, so the manifest is
+// found in the index by its config digest, which is the image ID, as the verifier does. Daemons that identify images by
+// the digest of their index instead have the manifest matched by the platform of the image.
+func (c *Client) platformManifestDigest(ctx context.Context, ref name.Digest, imageID string, img imgutil.Image) (string, error) {
+	desc, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(c.keychain))
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s in its registry", style.Symbol(ref.Name()))
+	}
+	if !desc.MediaType.IsIndex() {
+		return ref.DigestStr(), nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading index %s", style.Symbol(ref.Name()))
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading index %s", style.Symbol(ref.Name()))
+	}
+	platform, err := imagePlatform(img)
+	if err != nil {
+		return "", err
+	}
+
+	var platformDigest string
+	for _, m := range manifest.Manifests {
+		if m.MediaType.IsIndex() {
+			continue
+		}
+		child, err := index.Image(m.Digest)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading manifest %s of index %s", style.Symbol(m.Digest.String()), style.Symbol(ref.Name()))
+		}
+		config, err := child.ConfigName()
+		if err != nil {
+			return "", errors.Wrapf(err, "reading manifest %s of index %s", style.Symbol(m.Digest.String()), style.Symbol(ref.Name()))
+		}
+		if config.String() == imageID {
+			return m.Digest.String(), nil
+		}
+		if m.Platform != nil && platformDigest == "" &&
+			targetPlatform(&dist.Target{OS: m.Platform.OS, Arch: m.Platform.Architecture, ArchVariant: m.Platform.Variant}) == platform {
+			platformDigest = m.Digest.String()
+		}
+	}
+	if platformDigest == "" {
+		return "", errors.Errorf("image %s isn't in index %s in its registry", style.Symbol(img.Name()), style.Symbol(ref.Name()))
+	}
+	return platformDigest, nil
+}
+
+func imagePlatform(img imgutil.Image) (string, error) {
+	os, err := img.OS()
+	if err != nil {
+		return "", errors.Wrapf(err, "getting OS of image %s", style.Symbol(img.Name()))
+	}
+	arch, err := img.Architecture()
+	if err != nil {
+		return "", errors.Wrapf(err, "getting architecture of image %s", style.Symbol(img.Name()))
+	}
+	variant, err := img.Variant()
+	if err != nil {
+		return "", errors.Wrapf(err, "getting architecture variant of image %s", style.Symbol(img.Name()))
+	}
+	return targetPlatform(&dist.Target{OS: os, Arch: arch, ArchVariant: variant}), nil
+}
+
+func targetPlatform(target *dist.Target) string {
+	if target == nil {
+		return ""
+	}
+	return (&dist.Target{OS: target.OS, Arch: target.Arch, ArchVariant: target.ArchVariant}).ValuesAsPlatform()
+}
@@ -24,6 +24,9 @@ const (
 	// Packaging indicator that format of output will be a file on the host filesystem.
 	FormatFile = "file"
 
+	// Indicator that format of output will be an OCI layout directory on the host filesystem.
+	FormatOCILayout = "oci-layout"
+
 	// CNBExtension is the file extension for a cloud native buildpack tar archive
 	CNBExtension = ".cnb"
 )
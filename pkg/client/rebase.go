@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/lifecycle/phase"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/buildpacks/lifecycle/platform/files"
@@ -69,47 +70,22 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		return err
 	}
 
-	appOS, err := appImage.OS()
+	md, err := readRebaseMetadata(appImage)
 	if err != nil {
-		return errors.Wrapf(err, "getting app OS")
-	}
-
-	appArch, err := appImage.Architecture()
-	if err != nil {
-		return errors.Wrapf(err, "getting app architecture")
-	}
-
-	var md files.LayersMetadataCompat
-	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
 		return err
-	} else if !ok {
-		return errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
-	}
-	var runImageMD builder.RunImageMetadata
-	if md.RunImage.Image != "" {
-		runImageMD = builder.RunImageMetadata{
-			Image:   md.RunImage.Image,
-			Mirrors: md.RunImage.Mirrors,
-		}
-	} else if md.Stack != nil {
-		runImageMD = builder.RunImageMetadata{
-			Image:   md.Stack.RunImage.Image,
-			Mirrors: md.Stack.RunImage.Mirrors,
-		}
 	}
 
-	target := &dist.Target{OS: appOS, Arch: appArch}
 	fetchOptions := image.FetchOptions{
 		Daemon:     !opts.Publish,
 		PullPolicy: opts.PullPolicy,
-		Target:     target,
+		Target:     &md.target,
 	}
 
 	runImageName := c.resolveRunImage(
 		opts.RunImage,
 		imageRef.Context().RegistryStr(),
 		"",
-		runImageMD,
+		md.runImage,
 		opts.AdditionalMirrors,
 		opts.Publish,
 		fetchOptions,
@@ -155,3 +131,47 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 	}
 	return nil
 }
+
+// rebaseMetadata is what rebasing needs to know about an app image
+type rebaseMetadata struct {
+	layers   files.LayersMetadataCompat
+	runImage builder.RunImageMetadata
+	target   dist.Target
+}
+
+func readRebaseMetadata(appImage imgutil.Image) (rebaseMetadata, error) {
+	appOS, err := appImage.OS()
+	if err != nil {
+		return rebaseMetadata{}, errors.Wrapf(err, "getting app OS")
+	}
+
+	appArch, err := appImage.Architecture()
+	if err != nil {
+		return rebaseMetadata{}, errors.Wrapf(err, "getting app architecture")
+	}
+
+	var md files.LayersMetadataCompat
+	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
+		return rebaseMetadata{}, err
+	} else if !ok {
+		return rebaseMetadata{}, errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
+	}
+	var runImageMD builder.RunImageMetadata
+	if md.RunImage.Image != "" {
+		runImageMD = builder.RunImageMetadata{
+			Image:   md.RunImage.Image,
+			Mirrors: md.RunImage.Mirrors,
+		}
+	} else if md.Stack != nil {
+		runImageMD = builder.RunImageMetadata{
+			Image:   md.Stack.RunImage.Image,
+			Mirrors: md.Stack.RunImage.Mirrors,
+		}
+	}
+
+	return rebaseMetadata{
+		layers:   md,
+		runImage: runImageMD,
+		target:   dist.Target{OS: appOS, Arch: appArch},
+	}, nil
+}
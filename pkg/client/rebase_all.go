@@ -0,0 +1,327 @@
+package client
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/phase"
+	dockerImage "github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DefaultRebaseConcurrency is the number of images RebaseAll rebases at the same time when no concurrency is given
+const DefaultRebaseConcurrency = 4
+
+// RebaseAllOptions is a configuration struct that controls rebasing many images at once.
+type RebaseAllOptions struct {
+	// Names of the images to rebase. When rebasing images in the daemon, a name may be a glob pattern
+	// (e.g. 'my-org/*' or 'my-org/app:v*') matching the tags of images in the daemon.
+	RepoNames []string
+
+	// Flag to publish images to remote registry after rebase completion.
+	Publish bool
+
+	// Strategy for pulling images during rebase.
+	PullPolicy image.PullPolicy
+
+	// Image to rebase all images against. If omitted, each image is rebased on the
+	// run image recorded in its metadata.
+	RunImage string
+
+	// A mapping from StackID to an array of mirrors.
+	// This mapping used only if both RunImage is omitted and Publish is true.
+	AdditionalMirrors map[string][]string
+
+	// Pass-through force flag to lifecycle rebase command to skip target data
+	// validated (will not have any effect if API < 0.12).
+	Force bool
+
+	// Number of images to rebase at the same time. Defaults to DefaultRebaseConcurrency.
+	Concurrency int
+
+	// Report which images would be rebased, without rebasing them.
+	DryRun bool
+}
+
+// RebaseResult is the outcome of rebasing a single image with RebaseAll.
+type RebaseResult struct {
+	// Name of the rebased image.
+	RepoName string
+
+	// Run image the image was rebased on.
+	RunImage string
+
+	// Reference of the run image the image was based on before the rebase.
+	PreviousRunImageReference string
+
+	// Reference of the run image the image is based on after the rebase.
+	RunImageReference string
+
+	// Whether the image was rebased, or would have been rebased in a dry run. Images already
+	// based on the latest run image are left alone.
+	Rebased bool
+
+	// Error preventing the image from being rebased, if any.
+	Err error
+}
+
+// rebasePlan is an image to rebase and the run image group it belongs to
+type rebasePlan struct {
+	appImage     imgutil.Image
+	md           rebaseMetadata
+	runImageName string
+	groupKey     string
+}
+
+// runImageGroup is a run image shared by several images, fetched once for all of them
+type runImageGroup struct {
+	name      string
+	options   image.FetchOptions
+	baseImage imgutil.Image
+	topLayer  string
+	reference string
+	err       error
+}
+
+// RebaseAll rebases many app images at once. Images are grouped by their run image so that each run image is
+// resolved and fetched only once, and rebased in parallel. A result is returned for every image, in the order
+// of the given names, with the error for the images that could not be rebased; an error is only returned when
+// the names themselves can't be resolved.
+func (c *Client) RebaseAll(ctx context.Context, opts RebaseAllOptions) ([]RebaseResult, error) {
+	repoNames, err := c.expandRepoNames(ctx, opts.RepoNames, opts.Publish)
+	if err != nil {
+		return nil, err
+	}
+	if len(repoNames) == 0 {
+		return nil, errors.New("no images to rebase")
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultRebaseConcurrency
+	}
+
+	results := make([]RebaseResult, len(repoNames))
+	plans := make([]rebasePlan, len(repoNames))
+	forEach(concurrency, len(repoNames), func(i int) {
+		results[i].RepoName = repoNames[i]
+		plans[i], results[i].Err = c.planRebase(ctx, repoNames[i], opts)
+	})
+
+	groups := map[string]*runImageGroup{}
+	for i, plan := range plans {
+		if results[i].Err != nil {
+			continue
+		}
+		if _, ok := groups[plan.groupKey]; !ok {
+			target := plan.md.target
+			groups[plan.groupKey] = &runImageGroup{
+				name: plan.runImageName,
+				options: image.FetchOptions{
+					Daemon:     !opts.Publish,
+					PullPolicy: opts.PullPolicy,
+					Target:     &target,
+				},
+			}
+		}
+	}
+
+	groupList := make([]*runImageGroup, 0, len(groups))
+	for _, group := range groups {
+		groupList = append(groupList, group)
+	}
+	forEach(concurrency, len(groupList), func(i int) {
+		groupList[i].fetch(ctx, c.imageFetcher)
+	})
+
+	forEach(concurrency, len(plans), func(i int) {
+		if results[i].Err != nil {
+			return
+		}
+		plan := plans[i]
+		group := groups[plan.groupKey]
+
+		results[i].RunImage = group.name
+		results[i].PreviousRunImageReference = plan.md.layers.RunImage.Reference
+		if group.err != nil {
+			results[i].Err = group.err
+			return
+		}
+		results[i].RunImageReference = group.reference
+
+		if plan.md.layers.RunImage.TopLayer == group.topLayer {
+			c.logger.Debugf("Image %s is already based on run image %s", style.Symbol(repoNames[i]), style.Symbol(group.reference))
+			return
+		}
+		results[i].Rebased = true
+		if opts.DryRun {
+			return
+		}
+
+		c.logger.Infof("Rebasing %s on run image %s", style.Symbol(repoNames[i]), style.Symbol(group.name))
+		rebaser := &phase.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
+		if _, err := rebaser.Rebase(plan.appImage, group.baseImage, repoNames[i], nil); err != nil {
+			results[i].Rebased = false
+			results[i].Err = err
+		}
+	})
+
+	return results, nil
+}
+
+// planRebase reads the metadata of an image and works out which run image it should be rebased on
+func (c *Client) planRebase(ctx context.Context, repoName string, opts RebaseAllOptions) (rebasePlan, error) {
+	imageRef, err := c.parseTagReference(repoName)
+	if err != nil {
+		return rebasePlan{}, errors.Wrapf(err, "invalid image name '%s'", repoName)
+	}
+
+	appImage, err := c.imageFetcher.Fetch(ctx, repoName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return rebasePlan{}, err
+	}
+
+	md, err := readRebaseMetadata(appImage)
+	if err != nil {
+		return rebasePlan{}, err
+	}
+
+	runImageName := c.resolveRunImage(
+		opts.RunImage,
+		imageRef.Context().RegistryStr(),
+		"",
+		md.runImage,
+		opts.AdditionalMirrors,
+		opts.Publish,
+		image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy, Target: &md.target},
+	)
+	if runImageName == "" {
+		return rebasePlan{}, errors.New("run image must be specified")
+	}
+
+	return rebasePlan{
+		appImage:     appImage,
+		md:           md,
+		runImageName: runImageName,
+		groupKey:     runImageName + "|" + md.target.ValuesAsPlatform(),
+	}, nil
+}
+
+func (g *runImageGroup) fetch(ctx context.Context, fetcher ImageFetcher) {
+	g.baseImage, g.err = fetcher.Fetch(ctx, g.name, g.options)
+	if g.err != nil {
+		return
+	}
+
+	if g.topLayer, g.err = g.baseImage.TopLayer(); g.err != nil {
+		g.err = errors.Wrapf(g.err, "getting top layer of run image %s", style.Symbol(g.name))
+		return
+	}
+
+	identifier, err := g.baseImage.Identifier()
+	if err != nil {
+		g.err = errors.Wrapf(err, "getting identifier of run image %s", style.Symbol(g.name))
+		return
+	}
+	g.reference = identifier.String()
+}
+
+// imageLister is implemented by the Docker clients that can list the images in the daemon. ImageList isn't part of
+// DockerClient, so that Docker clients provided with WithDockerClient don't have to implement it.
+type imageLister interface {
+	ImageList(ctx context.Context, options dockerImage.ListOptions) ([]dockerImage.Summary, error)
+}
+
+// expandRepoNames replaces the glob patterns among the given names with the tags of the daemon images they match
+func (c *Client) expandRepoNames(ctx context.Context, repoNames []string, publish bool) ([]string, error) {
+	var (
+		expanded []string
+		tags     []string
+		seen     = map[string]bool{}
+	)
+	for _, repoName := range repoNames {
+		if !strings.ContainsAny(repoName, "*?[") {
+			if !seen[repoName] {
+				seen[repoName] = true
+				expanded = append(expanded, repoName)
+			}
+			continue
+		}
+
+		if publish {
+			return nil, errors.Errorf("image pattern %s can only be used with images in the daemon", style.Symbol(repoName))
+		}
+		if _, err := path.Match(repoName, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid image pattern %s", style.Symbol(repoName))
+		}
+
+		if tags == nil {
+			lister, ok := c.docker.(imageLister)
+			if !ok {
+				return nil, errors.Errorf("image pattern %s can't be used, as the Docker client doesn't support listing images", style.Symbol(repoName))
+			}
+			images, err := lister.ImageList(ctx, dockerImage.ListOptions{})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing images")
+			}
+			tags = []string{}
+			for _, img := range images {
+				tags = append(tags, img.RepoTags...)
+			}
+			sort.Strings(tags)
+		}
+
+		matched := false
+		for _, tag := range tags {
+			if !matchesImagePattern(repoName, tag) {
+				continue
+			}
+			matched = true
+			if !seen[tag] {
+				seen[tag] = true
+				expanded = append(expanded, tag)
+			}
+		}
+		if !matched {
+			return nil, errors.Errorf("no images match %s", style.Symbol(repoName))
+		}
+	}
+	return expanded, nil
+}
+
+// matchesImagePattern reports whether an image tag matches a pattern. Patterns without a tag match any tag.
+func matchesImagePattern(pattern, tag string) bool {
+	if ok, _ := path.Match(pattern, tag); ok {
+		return true
+	}
+	if strings.Contains(pattern[strings.LastIndex(pattern, "/")+1:], ":") {
+		return false
+	}
+	repo := tag
+	if i := strings.LastIndex(tag, ":"); i > strings.LastIndex(tag, "/") {
+		repo = tag[:i]
+	}
+	ok, _ := path.Match(pattern, repo)
+	return ok
+}
+
+// forEach calls fn for each index up to n, running at most limit calls at the same time
+func forEach(limit, n int, fn func(i int)) {
+	var group errgroup.Group
+	group.SetLimit(limit)
+	for i := 0; i < n; i++ {
+		group.Go(func() error {
+			fn(i)
+			return nil
+		})
+	}
+	_ = group.Wait()
+}
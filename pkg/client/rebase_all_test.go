@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/auth"
+	dockerImage "github.com/docker/docker/api/types/image"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRebaseAll(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "rebase_all", testRebaseAll, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRebaseAll(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		mockController   *gomock.Controller
+		mockDockerClient *testmocks.MockCommonAPIClient
+		subject          *Client
+		fakeAppImage     *fakes.Image
+		fakeOtherImage   *fakes.Image
+		fakeRunImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	newAppImage := func(name, runImageMetadata string) *fakes.Image {
+		img := fakes.NewImage(name, "", &fakeIdentifier{name: name + "-digest"})
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", runImageMetadata))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fakeImageFetcher.LocalImages[name] = img
+		return img
+	}
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		fakeAppImage = newAppImage("some/app", `{"runImage":{"image":"some/run","topLayer":"old-top-layer-sha","reference":"old-run-digest"}}`)
+		fakeOtherImage = newAppImage("some/other-app", `{"runImage":{"image":"some/run","topLayer":"old-top-layer-sha","reference":"old-run-digest"}}`)
+
+		fakeRunImage = fakes.NewImage("some/run", "run-image-top-layer-sha", &fakeIdentifier{name: "run-image-digest"})
+		h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fakeImageFetcher.LocalImages["some/run"] = fakeRunImage
+
+		keychain, err := auth.DefaultKeychain("pack-test/dummy")
+		h.AssertNil(t, err)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+			keychain:     keychain,
+			docker:       mockDockerClient,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, fakeAppImage.Cleanup())
+		h.AssertNilE(t, fakeOtherImage.Cleanup())
+		h.AssertNilE(t, fakeRunImage.Cleanup())
+	})
+
+	it("rebases every image on its run image", func() {
+		results, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+			RepoNames: []string{"some/app", "some/other-app"},
+		})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, results, []RebaseResult{
+			{RepoName: "some/app", RunImage: "some/run", PreviousRunImageReference: "old-run-digest", RunImageReference: "run-image-digest", Rebased: true},
+			{RepoName: "some/other-app", RunImage: "some/run", PreviousRunImageReference: "old-run-digest", RunImageReference: "run-image-digest", Rebased: true},
+		})
+		for _, img := range []*fakes.Image{fakeAppImage, fakeOtherImage} {
+			h.AssertEq(t, img.Base(), "some/run")
+			lbl, _ := img.Label("io.buildpacks.lifecycle.metadata")
+			h.AssertContains(t, lbl, `"runImage":{"topLayer":"run-image-top-layer-sha","reference":"run-image-digest"`)
+		}
+	})
+
+	it("leaves images already based on the run image alone", func() {
+		h.AssertNil(t, fakeOtherImage.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"runImage":{"image":"some/run","topLayer":"run-image-top-layer-sha","reference":"run-image-digest"}}`))
+
+		results, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+			RepoNames: []string{"some/app", "some/other-app"},
+		})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, results[0].Rebased, true)
+		h.AssertEq(t, results[1].Rebased, false)
+		h.AssertEq(t, fakeOtherImage.Base(), "")
+	})
+
+	it("reports the failures of each image", func() {
+		results, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+			RepoNames: []string{"some/missing-app", "some/app"},
+		})
+		h.AssertNil(t, err)
+
+		h.AssertError(t, results[0].Err, "image 'some/missing-app' does not exist on the daemon")
+		h.AssertNil(t, results[1].Err)
+		h.AssertEq(t, results[1].Rebased, true)
+	})
+
+	when("dry run", func() {
+		it("reports the images that would be rebased without rebasing them", func() {
+			results, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				RepoNames: []string{"some/app"},
+				DryRun:    true,
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, results[0].Rebased, true)
+			h.AssertEq(t, results[0].RunImageReference, "run-image-digest")
+			h.AssertEq(t, fakeAppImage.Base(), "")
+			h.AssertEq(t, fakeAppImage.IsSaved(), false)
+		})
+	})
+
+	when("an image name is a pattern", func() {
+		it("rebases the daemon images matching the pattern", func() {
+			mockDockerClient.EXPECT().ImageList(gomock.Any(), gomock.Any()).Return([]dockerImage.Summary{
+				{RepoTags: []string{"some/app:latest"}},
+				{RepoTags: []string{"some/other-app:latest", "unrelated/app:latest"}},
+			}, nil)
+			fakeImageFetcher.LocalImages["some/app:latest"] = fakeAppImage
+			fakeImageFetcher.LocalImages["some/other-app:latest"] = fakeOtherImage
+
+			results, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				RepoNames: []string{"some/*"},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(results), 2)
+			h.AssertEq(t, results[0].RepoName, "some/app:latest")
+			h.AssertEq(t, results[1].RepoName, "some/other-app:latest")
+		})
+
+		it("errors when nothing matches the pattern", func() {
+			mockDockerClient.EXPECT().ImageList(gomock.Any(), gomock.Any()).Return([]dockerImage.Summary{
+				{RepoTags: []string{"unrelated/app:latest"}},
+			}, nil)
+
+			_, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				RepoNames: []string{"some/*"},
+			})
+			h.AssertError(t, err, "no images match 'some/*'")
+		})
+
+		it("errors when publishing", func() {
+			_, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				RepoNames: []string{"some/*"},
+				Publish:   true,
+			})
+			h.AssertError(t, err, "image pattern 'some/*' can only be used with images in the daemon")
+		})
+
+		it("errors when the Docker client can't list images", func() {
+			subject.docker = struct{ DockerClient }{mockDockerClient}
+
+			_, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				RepoNames: []string{"some/*"},
+			})
+			h.AssertError(t, err, "image pattern 'some/*' can't be used, as the Docker client doesn't support listing images")
+		})
+	})
+}
@@ -77,7 +77,7 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 
 		return cmd.Start()
 	} else if opts.Type == "git" {
-		registryCache, err := getRegistry(c.logger, opts.Name)
+		registryCache, err := getRegistry(c.logger, c.keychain, opts.Name)
 		if err != nil {
 			return err
 		}
@@ -90,6 +90,15 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 		if err := registry.GitCommit(buildpack, username, registryCache); err != nil {
 			return err
 		}
+	} else if opts.Type == "oci" || opts.Type == "dir" {
+		registryCache, err := getRegistry(c.logger, c.keychain, opts.Name)
+		if err != nil {
+			return err
+		}
+
+		if err := registryCache.Register(buildpack); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -3,13 +3,21 @@ package client
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
 	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	"github.com/buildpacks/pack/internal/config"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	"github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -135,3 +143,88 @@ func testRegisterBuildpack(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 }
+
+func TestRegisterBuildpackInIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "register_buildpack_in_index", testRegisterBuildpackInIndex, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testRegisterBuildpackInIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server       *httptest.Server
+		fakeAppImage *fakes.Image
+		subject      *Client
+		out          bytes.Buffer
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+
+		fakeImageFetcher := ifakes.NewFakeImageFetcher()
+		fakeAppImage = fakes.NewImage("buildpack/image", "", &fakeIdentifier{
+			name: "example.com/buildpack/image@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+		})
+		h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.buildpackage.metadata", `{"id":"example/java","version":"1.1.1"}`))
+		fakeImageFetcher.RemoteImages["buildpack/image"] = fakeAppImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+		_ = fakeAppImage.Cleanup()
+	})
+
+	for _, registryType := range []string{"oci", "dir"} {
+		when(fmt.Sprintf("the registry is of type '%s'", registryType), func() {
+			it.Before(func() {
+				packHome := t.TempDir()
+				t.Setenv("PACK_HOME", packHome)
+
+				url := strings.TrimPrefix(server.URL, "http://") + "/buildpacks/index"
+				if registryType == "dir" {
+					url = filepath.Join(packHome, "index")
+				}
+				h.AssertNil(t, config.Write(config.Config{
+					Registries: []config.Registry{
+						{
+							Name: "some-registry",
+							Type: registryType,
+							URL:  url,
+						},
+					},
+				}, filepath.Join(packHome, "config.toml")))
+			})
+
+			it("registers and yanks the buildpack in the index", func() {
+				h.AssertNil(t, subject.RegisterBuildpack(context.TODO(), RegisterBuildpackOptions{
+					ImageName: "buildpack/image",
+					Type:      registryType,
+					Name:      "some-registry",
+				}))
+
+				results, err := subject.SearchBuildpack(SearchBuildpackOptions{Term: "java", RegistryName: "some-registry"})
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(results), 1)
+				h.AssertEq(t, results[0].Latest, "1.1.1")
+				h.AssertEq(t, results[0].Address, "example.com/buildpack/image@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566")
+
+				h.AssertNil(t, subject.YankBuildpack(YankBuildpackOptions{
+					ID:      "example/java",
+					Version: "1.1.1",
+					Type:    registryType,
+					Name:    "some-registry",
+					Yank:    true,
+				}))
+
+				results, err = subject.SearchBuildpack(SearchBuildpackOptions{Term: "java", RegistryName: "some-registry"})
+				h.AssertNil(t, err)
+				h.AssertEq(t, results[0].Yanked, []string{"1.1.1"})
+			})
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// RunOptions is a configuration struct that controls running an app image.
+type RunOptions struct {
+	// Name of the app image to run. The image must be in the daemon.
+	Image string
+
+	// Type of the process to run. If omitted, the default process of the image is run.
+	Process string
+
+	// Environment variables set in the container, in addition to the ones of the image.
+	Env map[string]string
+
+	// Ports to publish, in the format of 'docker run --publish' (e.g. '8080', '8080:8080',
+	// or '127.0.0.1:80:8080/tcp'). A port given alone is published on the same host port.
+	Ports []string
+}
+
+// Run runs a process of an app image in a container, streaming its output to the logger until it exits or the
+// context is cancelled. The container is removed once done.
+func (c *Client) Run(ctx context.Context, opts RunOptions) error {
+	info, err := c.InspectImage(opts.Image, true)
+	if err != nil {
+		return errors.Wrapf(err, "inspecting image %s", style.Symbol(opts.Image))
+	}
+	if info == nil {
+		return errors.Errorf("image %s does not exist on the daemon", style.Symbol(opts.Image))
+	}
+
+	processType, err := selectProcess(info.Processes, opts.Process)
+	if err != nil {
+		return err
+	}
+
+	exposedPorts, portBindings, err := parsePorts(opts.Ports)
+	if err != nil {
+		return err
+	}
+
+	ctrConf := &dcontainer.Config{
+		Image:        opts.Image,
+		Env:          envList(opts.Env),
+		ExposedPorts: exposedPorts,
+	}
+	if opts.Process != "" {
+		entrypoint, err := c.processEntrypoint(ctx, processType)
+		if err != nil {
+			return err
+		}
+		ctrConf.Entrypoint = []string{entrypoint}
+	}
+
+	ctr, err := c.docker.ContainerCreate(ctx, ctrConf, &dcontainer.HostConfig{PortBindings: portBindings}, nil, nil, "")
+	if err != nil {
+		return errors.Wrapf(err, "creating container for image %s", style.Symbol(opts.Image))
+	}
+	defer func() {
+		if err := c.docker.ContainerRemove(context.Background(), ctr.ID, dcontainer.RemoveOptions{Force: true}); err != nil {
+			c.logger.Warnf("Unable to remove container %s: %s", style.Symbol(ctr.ID), err)
+		}
+	}()
+
+	c.logger.Infof("Running process %s of image %s", style.Symbol(processType), style.Symbol(opts.Image))
+	for _, binding := range describePortBindings(portBindings) {
+		c.logger.Infof("Listening on %s", binding)
+	}
+
+	err = container.RunWithHandler(ctx, c.docker, ctr.ID, container.DefaultHandler(
+		logging.GetWriterForLevel(c.logger, logging.InfoLevel),
+		logging.GetWriterForLevel(c.logger, logging.ErrorLevel),
+	))
+	if err != nil && ctx.Err() != nil {
+		c.logger.Infof("Stopped image %s", style.Symbol(opts.Image))
+		return nil
+	}
+	return err
+}
+
+// selectProcess returns the type of the process to run, which is the default process if none is requested
+func selectProcess(processes ProcessDetails, requested string) (string, error) {
+	var types []string
+	if processes.DefaultProcess != nil {
+		types = append(types, processes.DefaultProcess.Type)
+	}
+	for _, process := range processes.OtherProcesses {
+		types = append(types, process.Type)
+	}
+	sort.Strings(types)
+
+	if requested == "" {
+		if processes.DefaultProcess == nil {
+			return "", errors.Errorf("image has no default process, a process must be specified (available: %s)", strings.Join(types, ", "))
+		}
+		return processes.DefaultProcess.Type, nil
+	}
+
+	for _, processType := range types {
+		if processType == requested {
+			return processType, nil
+		}
+	}
+	return "", errors.Errorf("image has no process %s (available: %s)", style.Symbol(requested), strings.Join(types, ", "))
+}
+
+// processEntrypoint returns the launcher entrypoint running the given process type
+func (c *Client) processEntrypoint(ctx context.Context, processType string) (string, error) {
+	info, err := c.docker.Info(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting docker info")
+	}
+
+	if info.OSType == "windows" {
+		return windowsEntrypointPrefix + processType + ".exe", nil
+	}
+	return entrypointPrefix + processType, nil
+}
+
+func parsePorts(ports []string) (nat.PortSet, nat.PortMap, error) {
+	if len(ports) == 0 {
+		return nil, nil, nil
+	}
+
+	var specs []string
+	for _, port := range ports {
+		if !strings.Contains(port, ":") {
+			hostPort := strings.SplitN(port, "/", 2)[0]
+			port = hostPort + ":" + port
+		}
+		specs = append(specs, port)
+	}
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(specs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing ports")
+	}
+	return exposedPorts, portBindings, nil
+}
+
+func describePortBindings(portBindings nat.PortMap) []string {
+	var bindings []string
+	for port, hostBindings := range portBindings {
+		for _, binding := range hostBindings {
+			hostIP := binding.HostIP
+			if hostIP == "" {
+				hostIP = "localhost"
+			}
+			bindings = append(bindings, fmt.Sprintf("%s:%s -> %s", hostIP, binding.HostPort, port))
+		}
+	}
+	sort.Strings(bindings)
+	return bindings
+}
+
+func envList(env map[string]string) []string {
+	var list []string
+	for key, value := range env {
+		list = append(list, key+"="+value)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRun(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Run", testRun, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRun(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		mockController   *gomock.Controller
+		mockDockerClient *testmocks.MockCommonAPIClient
+		appImage         *fakes.Image
+		out              bytes.Buffer
+	)
+
+	// expectRun expects the container to be started, writing the given output and exiting with the given status code
+	expectRun := func(output string, statusCode int64) {
+		var frames bytes.Buffer
+		_, err := stdcopy.NewStdWriter(&frames, stdcopy.Stdout).Write([]byte(output))
+		h.AssertNil(t, err)
+		conn, peer := net.Pipe()
+		t.Cleanup(func() { peer.Close() })
+
+		waitChan := make(chan dcontainer.WaitResponse, 1)
+		waitChan <- dcontainer.WaitResponse{StatusCode: statusCode}
+		mockDockerClient.EXPECT().ContainerWait(gomock.Any(), "some-container-id", gomock.Any()).Return(waitChan, make(chan error))
+		mockDockerClient.EXPECT().ContainerAttach(gomock.Any(), "some-container-id", gomock.Any()).
+			Return(types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(&frames)}, nil)
+		mockDockerClient.EXPECT().ContainerStart(gomock.Any(), "some-container-id", gomock.Any()).Return(nil)
+		mockDockerClient.EXPECT().ContainerRemove(gomock.Any(), "some-container-id", dcontainer.RemoveOptions{Force: true}).Return(nil)
+	}
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+			docker:       mockDockerClient,
+		}
+
+		appImage = fakes.NewImage("some/app", "", nil)
+		h.AssertNil(t, appImage.SetEnv("CNB_PLATFORM_API", "0.12"))
+		h.AssertNil(t, appImage.SetEntrypoint("/cnb/process/web"))
+		h.AssertNil(t, appImage.SetLabel("io.buildpacks.build.metadata", `{
+  "processes": [
+    {"type": "web", "command": "npm", "args": ["start"]},
+    {"type": "worker", "command": "node", "args": ["worker.js"]}
+  ]
+}`))
+		fakeImageFetcher.LocalImages["some/app"] = appImage
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, appImage.Cleanup())
+	})
+
+	it("runs the default process of the image", func() {
+		mockDockerClient.EXPECT().ContainerCreate(gomock.Any(), &dcontainer.Config{Image: "some/app"}, gomock.Any(), nil, nil, "").
+			Return(dcontainer.CreateResponse{ID: "some-container-id"}, nil)
+		expectRun("listening\n", 0)
+
+		h.AssertNil(t, subject.Run(context.TODO(), RunOptions{Image: "some/app"}))
+
+		h.AssertContains(t, out.String(), "Running process 'web' of image 'some/app'")
+		h.AssertContains(t, out.String(), "listening")
+	})
+
+	it("runs the given process with the given environment and ports", func() {
+		mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux"}, nil)
+		mockDockerClient.EXPECT().ContainerCreate(gomock.Any(),
+			&dcontainer.Config{
+				Image:        "some/app",
+				Entrypoint:   []string{"/cnb/process/worker"},
+				Env:          []string{"SOME_KEY=some-value"},
+				ExposedPorts: nat.PortSet{"8080/tcp": {}},
+			},
+			&dcontainer.HostConfig{PortBindings: nat.PortMap{"8080/tcp": {{HostPort: "9090"}}}},
+			nil, nil, "").
+			Return(dcontainer.CreateResponse{ID: "some-container-id"}, nil)
+		expectRun("working\n", 0)
+
+		h.AssertNil(t, subject.Run(context.TODO(), RunOptions{
+			Image:   "some/app",
+			Process: "worker",
+			Env:     map[string]string{"SOME_KEY": "some-value"},
+			Ports:   []string{"9090:8080"},
+		}))
+
+		h.AssertContains(t, out.String(), "Listening on localhost:9090 -> 8080/tcp")
+	})
+
+	it("publishes a port given alone on the same host port", func() {
+		mockDockerClient.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(),
+			&dcontainer.HostConfig{PortBindings: nat.PortMap{"8080/tcp": {{HostPort: "8080"}}}},
+			nil, nil, "").
+			Return(dcontainer.CreateResponse{ID: "some-container-id"}, nil)
+		expectRun("", 0)
+
+		h.AssertNil(t, subject.Run(context.TODO(), RunOptions{Image: "some/app", Ports: []string{"8080"}}))
+	})
+
+	it("errors when the process exits with an error", func() {
+		mockDockerClient.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+			Return(dcontainer.CreateResponse{ID: "some-container-id"}, nil)
+		expectRun("", 1)
+
+		h.AssertError(t, subject.Run(context.TODO(), RunOptions{Image: "some/app"}), "failed with status code: 1")
+	})
+
+	it("errors when the image has no such process", func() {
+		err := subject.Run(context.TODO(), RunOptions{Image: "some/app", Process: "some-process"})
+		h.AssertError(t, err, "image has no process 'some-process' (available: web, worker)")
+	})
+
+	it("errors when the image is not in the daemon", func() {
+		err := subject.Run(context.TODO(), RunOptions{Image: "some/missing-app"})
+		h.AssertError(t, err, "image 'some/missing-app' does not exist on the daemon")
+	})
+
+	it("errors when a port is invalid", func() {
+		err := subject.Run(context.TODO(), RunOptions{Image: "some/app", Ports: []string{"not-a-port"}})
+		h.AssertError(t, err, "parsing ports")
+	})
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// SearchBuildpackOptions are options available for SearchBuildpack
+type SearchBuildpackOptions struct {
+	// Term to match against the namespace and name of buildpacks. An empty term matches every buildpack.
+	Term string
+	// RegistryName to search. When empty, every configured registry is searched.
+	RegistryName string
+}
+
+// BuildpackSearchResult describes a buildpack found in a registry
+type BuildpackSearchResult struct {
+	Registry string `json:"registry"`
+	ID       string `json:"id"`
+	// Latest is the highest version that wasn't yanked, if any
+	Latest string `json:"latest,omitempty"`
+	// Versions are all the versions of the buildpack, highest first
+	Versions []string `json:"versions"`
+	// Yanked are the versions that were yanked
+	Yanked []string `json:"yanked,omitempty"`
+	// Address of the latest version
+	Address string `json:"address,omitempty"`
+}
+
+// SearchBuildpack searches the index of buildpack registries for buildpacks matching a term. Registries that can't be
+// read are skipped with a warning, unless a single registry is searched.
+func (c *Client) SearchBuildpack(opts SearchBuildpackOptions) ([]BuildpackSearchResult, error) {
+	registryNames := []string{opts.RegistryName}
+	if opts.RegistryName == "" {
+		cfg, err := getConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		registryNames = nil
+		for _, reg := range config.GetRegistries(cfg) {
+			registryNames = append(registryNames, reg.Name)
+		}
+	}
+
+	var results []BuildpackSearchResult
+	for _, registryName := range registryNames {
+		registryResults, err := c.searchRegistry(registryName, opts.Term)
+		if err != nil {
+			if opts.RegistryName != "" {
+				return nil, err
+			}
+			c.logger.Warnf("Skipping registry %s: %s", style.Symbol(registryName), err)
+			continue
+		}
+		results = append(results, registryResults...)
+	}
+	return results, nil
+}
+
+func (c *Client) searchRegistry(registryName, term string) ([]BuildpackSearchResult, error) {
+	registryCache, err := getRegistry(c.logger, c.keychain, registryName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := registryCache.Search(term)
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching registry %s", style.Symbol(registryName))
+	}
+
+	var results []BuildpackSearchResult
+	for _, entry := range entries {
+		results = append(results, newBuildpackSearchResult(registryName, entry))
+	}
+	return results, nil
+}
+
+func newBuildpackSearchResult(registryName string, entry registry.Entry) BuildpackSearchResult {
+	buildpacks := append([]registry.Buildpack{}, entry.Buildpacks...)
+	sort.SliceStable(buildpacks, func(i, j int) bool {
+		return semver.Compare(fmt.Sprintf("v%s", buildpacks[i].Version), fmt.Sprintf("v%s", buildpacks[j].Version)) > 0
+	})
+
+	result := BuildpackSearchResult{
+		Registry: registryName,
+		ID:       fmt.Sprintf("%s/%s", buildpacks[0].Namespace, buildpacks[0].Name),
+	}
+	for _, bp := range buildpacks {
+		result.Versions = append(result.Versions, bp.Version)
+		if bp.Yanked {
+			result.Yanked = append(result.Yanked, bp.Version)
+			continue
+		}
+		if result.Latest == "" {
+			result.Latest = bp.Version
+			result.Address = bp.Address
+		}
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSearchBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "search_buildpack", testSearchBuildpack, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testSearchBuildpack(t *testing.T, when spec.G, it spec.S) {
+	when("#SearchBuildpack", func() {
+		var (
+			subject *Client
+			out     bytes.Buffer
+		)
+
+		it.Before(func() {
+			tmpDir := t.TempDir()
+			registryFixture := h.CreateRegistryFixture(t, tmpDir, filepath.Join("..", "..", "testdata", "registry"))
+
+			packHome := filepath.Join(tmpDir, "packHome")
+			t.Setenv("PACK_HOME", packHome)
+			h.AssertNil(t, config.Write(config.Config{
+				Registries: []config.Registry{
+					{
+						Name: "some-registry",
+						Type: "github",
+						URL:  registryFixture,
+					},
+				},
+			}, filepath.Join(packHome, "config.toml")))
+
+			subject = &Client{logger: logging.NewLogWithWriters(&out, &out)}
+		})
+
+		it("returns the versions of the matching buildpacks, highest first", func() {
+			results, err := subject.SearchBuildpack(SearchBuildpackOptions{
+				Term:         "node",
+				RegistryName: "some-registry",
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, results, []BuildpackSearchResult{
+				{
+					Registry: "some-registry",
+					ID:       "example/node",
+					Latest:   "2.1.3",
+					Versions: []string{"2.1.3", "2.0.0", "1.5.0", "1.4.2", "1.4.0", "1.3.0"},
+					Yanked:   []string{"1.5.0"},
+					Address:  "example.com/some/node@sha256:c0ec560c1097b7e7db2f1a073de0c7eb72fcc77abc19be6390306ae389ae2a72",
+				},
+			})
+		})
+
+		it("returns every buildpack for an empty term", func() {
+			results, err := subject.SearchBuildpack(SearchBuildpackOptions{RegistryName: "some-registry"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(results), 3)
+			h.AssertEq(t, results[0].ID, "example/foo")
+			h.AssertEq(t, results[0].Latest, "1.2.0")
+		})
+
+		it("errors when the registry isn't configured", func() {
+			_, err := subject.SearchBuildpack(SearchBuildpackOptions{RegistryName: "other-registry"})
+			h.AssertError(t, err, "registry 'other-registry' is not defined in your config file")
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// UpdateBuilderBaseOptions is a configuration object used to change the behavior of
+// UpdateBuilderBase.
+type UpdateBuilderBaseOptions struct {
+	// Name of the builder to update.
+	BuilderName string
+
+	// Name of the build image to rebase the builder onto. The build image of the builder is kept when empty.
+	BuildImage string
+
+	// Lifecycle to replace the lifecycle of the builder with. The lifecycle of the builder is kept when empty.
+	Lifecycle pubbldr.LifecycleConfig
+
+	// The base directory to use to resolve a relative lifecycle URI.
+	RelativeBaseDir string
+
+	// Skip saving the builder locally, directly publish to a registry.
+	// Requires BuilderName to be a valid registry location.
+	Publish bool
+
+	// Strategy for updating images before updating the builder.
+	PullPolicy image.PullPolicy
+}
+
+// UpdateBuilderBase rebases a builder onto a new build image and/or replaces its lifecycle. The buildpacks, extensions,
+// order and stack of the builder are reused, and validated against the new build image and lifecycle.
+func (c *Client) UpdateBuilderBase(ctx context.Context, opts UpdateBuilderBaseOptions) error {
+	if opts.BuildImage == "" && opts.Lifecycle.URI == "" && opts.Lifecycle.Version == "" {
+		return errors.New("a build image or lifecycle must be provided")
+	}
+
+	builderImage, err := c.imageFetcher.Fetch(ctx, opts.BuilderName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return errors.Wrapf(err, "fetching builder %s", style.Symbol(opts.BuilderName))
+	}
+
+	bldr, err := builder.FromImage(builderImage)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.BuilderName))
+	}
+
+	target, err := getTargetFromBuilder(builderImage)
+	if err != nil {
+		return err
+	}
+
+	if opts.BuildImage != "" {
+		buildImage, err := c.imageFetcher.Fetch(ctx, opts.BuildImage, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy, Target: target})
+		if err != nil {
+			return errors.Wrap(err, "fetch build image")
+		}
+
+		c.logger.Debugf("Rebasing builder %s onto build image %s", style.Symbol(opts.BuilderName), style.Symbol(opts.BuildImage))
+		if err := bldr.Rebase(buildImage); err != nil {
+			return err
+		}
+	}
+
+	if opts.Lifecycle.URI != "" || opts.Lifecycle.Version != "" {
+		lifecycle, err := c.fetchLifecycle(ctx, opts.Lifecycle, opts.RelativeBaseDir, target.OS, target.Arch)
+		if err != nil {
+			return errors.Wrap(err, "fetch lifecycle")
+		}
+		bldr.ReplaceLifecycle(lifecycle)
+	}
+
+	return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+}
@@ -0,0 +1,169 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestUpdateBuilderBase(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "update_builder_base", testUpdateBuilderBase, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testUpdateBuilderBase(t *testing.T, when spec.G, it spec.S) {
+	when("#UpdateBuilderBase", func() {
+		var (
+			mockController   *gomock.Controller
+			mockImageFetcher *testmocks.MockImageFetcher
+			mockDownloader   *testmocks.MockBlobDownloader
+			builderImage     *fakes.Image
+			buildImage       *fakes.Image
+			opts             client.UpdateBuilderBaseOptions
+			subject          *client.Client
+			out              bytes.Buffer
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+			mockDownloader = testmocks.NewMockBlobDownloader(mockController)
+
+			builderImage = fakes.NewImage("some/builder", "", nil)
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.mixins", `["mixinX"]`))
+			h.AssertNil(t, builderImage.SetEnv("CNB_USER_ID", "1234"))
+			h.AssertNil(t, builderImage.SetEnv("CNB_GROUP_ID", "4321"))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", `{
+  "buildpacks": [{"id": "some/bp", "version": "1.0.0"}],
+  "lifecycle": {"version": "0.20.0", "apis": {"buildpack": {"deprecated": [], "supported": ["0.2", "0.3"]}, "platform": {"deprecated": [], "supported": ["0.12"]}}},
+  "images": [{"image": "some/run-image"}],
+  "buildImage": {"topLayer": "sha256:old-build-image-top-layer"}
+}`))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.layers", `{
+  "some/bp": {"1.0.0": {"api": "0.3", "stacks": [{"id": "some.stack.id", "mixins": ["mixinX"]}], "layerDiffID": "sha256:some-bp-1"}}
+}`))
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.order", `[{"group": [{"id": "some/bp", "version": "1.0.0"}]}]`))
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(builderImage, nil).AnyTimes()
+
+			buildImage = fakes.NewImage("some/new-build-image", "sha256:new-build-image-top-layer", nil)
+			h.AssertNil(t, buildImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			h.AssertNil(t, buildImage.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "mixinY"]`))
+			h.AssertNil(t, buildImage.SetEnv("CNB_USER_ID", "1234"))
+			h.AssertNil(t, buildImage.SetEnv("CNB_GROUP_ID", "4321"))
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/new-build-image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever, Target: &dist.Target{OS: "linux", Arch: "amd64"}}).Return(buildImage, nil).AnyTimes()
+
+			var err error
+			subject, err = client.NewClient(
+				client.WithLogger(logging.NewLogWithWriters(&out, &out)),
+				client.WithFetcher(mockImageFetcher),
+				client.WithDownloader(mockDownloader),
+			)
+			h.AssertNil(t, err)
+
+			opts = client.UpdateBuilderBaseOptions{
+				BuilderName: "some/builder",
+				BuildImage:  "some/new-build-image",
+				PullPolicy:  image.PullNever,
+			}
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		builderMetadata := func() builder.Metadata {
+			var metadata builder.Metadata
+			_, err := dist.GetLabel(builderImage, "io.buildpacks.builder.metadata", &metadata)
+			h.AssertNil(t, err)
+			return metadata
+		}
+
+		it("rebases the builder onto the build image", func() {
+			h.AssertNil(t, subject.UpdateBuilderBase(context.TODO(), opts))
+
+			h.AssertTrue(t, builderImage.IsSaved())
+			h.AssertEq(t, builderImage.Base(), "some/new-build-image")
+			h.AssertEq(t, builderMetadata().BuildImage.TopLayer, "sha256:new-build-image-top-layer")
+			h.AssertEq(t, builderMetadata().Buildpacks, []dist.ModuleInfo{{ID: "some/bp", Version: "1.0.0"}})
+
+			var mixins []string
+			_, err := dist.GetLabel(builderImage, "io.buildpacks.stack.mixins", &mixins)
+			h.AssertNil(t, err)
+			h.AssertEq(t, mixins, []string{"mixinX", "mixinY"})
+		})
+
+		it("replaces the lifecycle", func() {
+			opts.BuildImage = ""
+			opts.Lifecycle = pubbldr.LifecycleConfig{URI: "file:///some-lifecycle"}
+			mockDownloader.EXPECT().Download(gomock.Any(), "file:///some-lifecycle").Return(blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")), nil)
+
+			h.AssertNil(t, subject.UpdateBuilderBase(context.TODO(), opts))
+
+			h.AssertEq(t, builderImage.Base(), "")
+			h.AssertEq(t, builderMetadata().Lifecycle.Version.String(), "0.0.0")
+			_, err := builderImage.FindLayerWithPath("/cnb/lifecycle/detector")
+			h.AssertNil(t, err)
+		})
+
+		it("errors when the build image is missing mixins required by a buildpack", func() {
+			h.AssertNil(t, buildImage.SetLabel("io.buildpacks.stack.mixins", `["mixinY"]`))
+
+			err := subject.UpdateBuilderBase(context.TODO(), opts)
+			h.AssertError(t, err, "buildpack 'some/bp@1.0.0' requires missing mixin(s): mixinX")
+		})
+
+		it("errors when the build image has another stack", func() {
+			h.AssertNil(t, buildImage.SetLabel("io.buildpacks.stack.id", "other.stack.id"))
+
+			err := subject.UpdateBuilderBase(context.TODO(), opts)
+			h.AssertError(t, err, "stack 'some.stack.id' of builder is incompatible with stack 'other.stack.id' from build image 'some/new-build-image'")
+		})
+
+		it("errors when the build image is for another platform", func() {
+			h.AssertNil(t, buildImage.SetArchitecture("arm64"))
+
+			err := subject.UpdateBuilderBase(context.TODO(), opts)
+			h.AssertError(t, err, "build image 'some/new-build-image' is for 'linux/arm64' but builder 'some/builder' is for 'linux/amd64'")
+		})
+
+		it("errors when the build image runs as another user", func() {
+			h.AssertNil(t, buildImage.SetEnv("CNB_USER_ID", "1000"))
+
+			err := subject.UpdateBuilderBase(context.TODO(), opts)
+			h.AssertError(t, err, "build image 'some/new-build-image' runs as '1000:4321' but builder 'some/builder' was created for '1234:4321'")
+		})
+
+		it("errors when the top layer of the build image of the builder can't be found", func() {
+			h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", `{"buildpacks": []}`))
+
+			err := subject.UpdateBuilderBase(context.TODO(), opts)
+			h.AssertError(t, err, "unable to find the layers of the build image of builder 'some/builder'")
+		})
+
+		it("errors when nothing is updated", func() {
+			opts.BuildImage = ""
+
+			err := subject.UpdateBuilderBase(context.TODO(), opts)
+			h.AssertError(t, err, "a build image or lifecycle must be provided")
+		})
+	})
+}
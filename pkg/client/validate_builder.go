@@ -0,0 +1,574 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver"
+	"github.com/buildpacks/imgutil"
+	ptoml "github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/stack"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DiagnosticSeverity is the severity of a problem found while validating a builder configuration
+type DiagnosticSeverity string
+
+const (
+	// DiagnosticError is a problem that fails the creation of the builder
+	DiagnosticError DiagnosticSeverity = "error"
+	// DiagnosticWarning is a problem that doesn't fail the creation of the builder, but likely makes it misbehave
+	DiagnosticWarning DiagnosticSeverity = "warning"
+	// DiagnosticInfo is a part of the builder configuration that couldn't be validated
+	DiagnosticInfo DiagnosticSeverity = "info"
+)
+
+// BuilderDiagnostic is a problem found while validating a builder configuration.
+type BuilderDiagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	File     string             `json:"file"`
+	// Line of the element of the file the problem was found in, 0 when the problem isn't tied to an element
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidateBuilderOptions is a configuration object used to change the behavior of ValidateBuilder.
+type ValidateBuilderOptions struct {
+	// Path to the builder configuration file.
+	ConfigPath string
+
+	// Buildpack registry name. Registry buildpacks are looked up in the local cache of this registry.
+	Registry string
+}
+
+// ValidateBuilder validates a builder configuration without creating the builder. Only local files, images in the
+// daemon and the local cache of the buildpack registry are read, parts of the configuration that need anything else
+// are reported as not validated.
+//
+// Problems with the configuration are returned as diagnostics, sorted by line, while an error is returned when the
+// validation itself fails.
+func (c *Client) ValidateBuilder(ctx context.Context, opts ValidateBuilderOptions) ([]BuilderDiagnostic, error) {
+	relativeBaseDir, err := filepath.Abs(filepath.Dir(opts.ConfigPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "getting absolute path for config")
+	}
+
+	v := &builderValidation{
+		client:          c,
+		opts:            opts,
+		relativeBaseDir: relativeBaseDir,
+		modules:         map[string]map[string][]string{},
+	}
+	if err := v.validate(ctx); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		return v.diagnostics[i].Line < v.diagnostics[j].Line
+	})
+	return v.diagnostics, nil
+}
+
+type builderValidation struct {
+	client          *Client
+	opts            ValidateBuilderOptions
+	relativeBaseDir string
+	lines           configLines
+	config          pubbldr.Config
+	diagnostics     []BuilderDiagnostic
+
+	// lifecycle is nil when the lifecycle can't be read offline
+	lifecycle *builder.LifecycleDescriptor
+	// target the modules are validated for
+	target dist.Target
+	mixins []string
+
+	// modules lists the versions of the modules, by kind and ID, declared or found in the configuration
+	modules map[string]map[string][]string
+	// unresolved is true when some modules couldn't be read offline, so that their IDs may be unknown
+	unresolved bool
+}
+
+func (v *builderValidation) report(severity DiagnosticSeverity, line int, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, BuilderDiagnostic{
+		Severity: severity,
+		File:     v.opts.ConfigPath,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *builderValidation) validate(ctx context.Context) error {
+	if _, err := os.Stat(v.opts.ConfigPath); err != nil {
+		return errors.Wrap(err, "opening config file")
+	}
+
+	tree, err := ptoml.LoadFile(v.opts.ConfigPath)
+	if err == nil {
+		v.lines = configLines{tree: tree}
+	}
+
+	config, warnings, err := pubbldr.ReadConfig(v.opts.ConfigPath)
+	if err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			v.report(DiagnosticError, parseErr.Position.Line, "invalid TOML: %s", parseErr.Message)
+			return nil
+		}
+		v.report(DiagnosticError, 0, "%s", errors.Cause(err).Error())
+		return nil
+	}
+	v.config = config
+	for _, warning := range warnings {
+		v.report(DiagnosticWarning, 0, "%s", warning)
+	}
+
+	if err := pubbldr.ValidateConfig(config); err != nil {
+		v.report(DiagnosticError, 0, "%s", err.Error())
+	}
+
+	v.validateImages(ctx)
+	v.validateLifecycle(ctx)
+
+	for i, module := range config.Buildpacks {
+		v.validateModule(ctx, buildpack.KindBuildpack, i, module)
+	}
+	for i, module := range config.Extensions {
+		v.validateModule(ctx, buildpack.KindExtension, i, module)
+	}
+
+	v.validateOrder(buildpack.KindBuildpack, "order", config.Order)
+	v.validateOrder(buildpack.KindExtension, "order-extensions", config.OrderExtensions)
+	return nil
+}
+
+// validateImages validates the build and run images in the daemon against the targets and stack of the configuration
+func (v *builderValidation) validateImages(ctx context.Context) {
+	if len(v.config.Targets) > 0 {
+		v.target = v.config.Targets[0]
+	}
+
+	buildImageLine := v.lines.line("build", "image")
+	if buildImageLine == 0 {
+		buildImageLine = v.lines.line("stack", "build-image")
+	}
+	if v.config.Build.Image != "" {
+		if img := v.localImage(ctx, "build image", v.config.Build.Image, buildImageLine); img != nil {
+			v.validateImage("build image", img, buildImageLine)
+			if v.target.OS == "" {
+				v.target.OS, _ = img.OS()
+				v.target.Arch, _ = img.Architecture()
+			}
+			if _, err := dist.GetLabel(img, stack.MixinsLabel, &v.mixins); err != nil {
+				v.report(DiagnosticWarning, buildImageLine, "reading mixins of build image %s: %s", style.Symbol(img.Name()), err)
+			}
+		}
+	}
+
+	for i, runImage := range v.config.Run.Images {
+		line := v.lines.line("run", "images", i)
+		if img := v.localImage(ctx, "run image", runImage.Image, line); img != nil {
+			v.validateImage("run image", img, line)
+		}
+	}
+
+	if v.target.OS == "" {
+		v.target = dist.Target{OS: dist.DefaultTargetOSLinux, Arch: dist.DefaultTargetArch}
+	}
+}
+
+func (v *builderValidation) localImage(ctx context.Context, kind, name string, line int) imgutil.Image {
+	img, err := v.client.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+	if err != nil {
+		if errors.Is(err, image.ErrNotFound) {
+			v.report(DiagnosticInfo, line, "%s %s isn't in the daemon, it is not validated", kind, style.Symbol(name))
+		} else {
+			v.report(DiagnosticWarning, line, "%s %s can't be read, it is not validated: %s", kind, style.Symbol(name), err)
+		}
+		return nil
+	}
+	return img
+}
+
+func (v *builderValidation) validateImage(kind string, img imgutil.Image, line int) {
+	imageOS, err := img.OS()
+	if err != nil {
+		v.report(DiagnosticWarning, line, "reading OS of %s %s: %s", kind, style.Symbol(img.Name()), err)
+		return
+	}
+	imageArch, err := img.Architecture()
+	if err != nil {
+		v.report(DiagnosticWarning, line, "reading architecture of %s %s: %s", kind, style.Symbol(img.Name()), err)
+		return
+	}
+
+	if len(v.config.Targets) > 0 {
+		found := false
+		for _, target := range v.config.Targets {
+			if target.OS == imageOS && (target.Arch == "" || target.Arch == imageArch) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.report(DiagnosticError, line, "%s %s is %s, which doesn't match any of the targets of the builder",
+				kind, style.Symbol(img.Name()), style.Symbol(imageOS+"/"+imageArch))
+		}
+	}
+
+	if v.config.Stack.ID != "" {
+		stackID, err := img.Label("io.buildpacks.stack.id")
+		if err != nil {
+			v.report(DiagnosticWarning, line, "reading stack of %s %s: %s", kind, style.Symbol(img.Name()), err)
+		} else if stackID != v.config.Stack.ID {
+			v.report(DiagnosticError, line, "stack %s from builder config is incompatible with stack %s from %s %s",
+				style.Symbol(v.config.Stack.ID), style.Symbol(stackID), kind, style.Symbol(img.Name()))
+		}
+	}
+}
+
+// validateLifecycle reads the lifecycle when it is a local file
+func (v *builderValidation) validateLifecycle(ctx context.Context) {
+	config := v.config.Lifecycle
+	line := v.lines.line("lifecycle")
+	if config.Version != "" && config.URI != "" {
+		v.report(DiagnosticError, line, "%s can only declare %s or %s, not both",
+			style.Symbol("lifecycle"), style.Symbol("version"), style.Symbol("uri"))
+		return
+	}
+
+	switch {
+	case config.Version != "":
+		if _, err := semver.NewVersion(config.Version); err != nil {
+			v.report(DiagnosticError, v.lines.line("lifecycle", "version"), "%s must be a valid semver", style.Symbol("lifecycle.version"))
+			return
+		}
+		v.report(DiagnosticInfo, line, "lifecycle %s is downloaded, the Buildpack API of the modules is not validated", style.Symbol(config.Version))
+	case config.URI != "":
+		line = v.lines.line("lifecycle", "uri")
+		uri, err := paths.FilePathToURI(config.URI, v.relativeBaseDir)
+		if err != nil {
+			v.report(DiagnosticError, line, "invalid lifecycle URI %s: %s", style.Symbol(config.URI), err)
+			return
+		}
+		if !isLocalURI(uri) {
+			v.report(DiagnosticInfo, line, "lifecycle %s is downloaded, the Buildpack API of the modules is not validated", style.Symbol(config.URI))
+			return
+		}
+
+		blob, err := v.client.downloader.Download(ctx, uri)
+		if err != nil {
+			v.report(DiagnosticError, line, "reading lifecycle %s: %s", style.Symbol(config.URI), err)
+			return
+		}
+		lifecycle, err := builder.NewLifecycle(blob)
+		if err != nil {
+			v.report(DiagnosticError, line, "invalid lifecycle %s: %s", style.Symbol(config.URI), err)
+			return
+		}
+		descriptor := lifecycle.Descriptor()
+		v.lifecycle = &descriptor
+	default:
+		v.report(DiagnosticInfo, 0, "lifecycle %s is downloaded, the Buildpack API of the modules is not validated", style.Symbol(builder.DefaultLifecycleVersion))
+	}
+}
+
+// validateModule reads a module of the configuration offline, and validates it and the modules it contains
+func (v *builderValidation) validateModule(ctx context.Context, kind string, index int, config pubbldr.ModuleConfig) {
+	key := "buildpacks"
+	if kind == buildpack.KindExtension {
+		key = "extensions"
+	}
+	line := v.lines.line(key, index)
+
+	if config.ID != "" {
+		v.addModule(kind, config.ID, config.Version)
+	}
+
+	uri := config.URI
+	if uri == "" && config.ImageName == "" {
+		v.report(DiagnosticError, line, "%s %s must declare a %s", kind, style.Symbol(config.DisplayString()), style.Symbol("uri"))
+		v.unresolved = true
+		return
+	}
+
+	if uri != "" {
+		locatorType, err := buildpack.GetLocatorType(uri, v.relativeBaseDir, nil)
+		if err != nil {
+			v.report(DiagnosticError, line, "invalid %s URI %s: %s", kind, style.Symbol(uri), err)
+			v.unresolved = true
+			return
+		}
+
+		switch locatorType {
+		case buildpack.RegistryLocator:
+			address, err := v.cachedRegistryAddress(uri)
+			if err != nil {
+				v.report(DiagnosticWarning, line, "%s %s isn't in the local registry cache, it is not validated: %s", kind, style.Symbol(uri), err)
+				v.unresolved = true
+				return
+			}
+			uri = "docker://" + address
+		case buildpack.URILocator:
+			absoluteURI, err := paths.FilePathToURI(uri, v.relativeBaseDir)
+			if err != nil {
+				v.report(DiagnosticError, line, "invalid %s URI %s: %s", kind, style.Symbol(uri), err)
+				v.unresolved = true
+				return
+			}
+			if !isLocalURI(absoluteURI) {
+				v.report(DiagnosticInfo, line, "%s %s is downloaded, it is not validated", kind, style.Symbol(uri))
+				v.unresolved = true
+				return
+			}
+		}
+	}
+
+	mainModule, depModules, err := v.client.buildpackDownloader.Download(ctx, uri, buildpack.DownloadOptions{
+		Daemon:          true,
+		ImageName:       config.ImageName,
+		ModuleKind:      kind,
+		PullPolicy:      image.PullNever,
+		RelativeBaseDir: v.relativeBaseDir,
+		Target:          &v.target,
+	})
+	if err != nil {
+		if errors.Is(err, image.ErrNotFound) {
+			v.report(DiagnosticInfo, line, "%s %s isn't in the daemon, it is not validated", kind, style.Symbol(config.DisplayString()))
+		} else {
+			v.report(DiagnosticError, line, "reading %s %s: %s", kind, style.Symbol(config.DisplayString()), err)
+		}
+		v.unresolved = true
+		return
+	}
+
+	if err := validateModule(kind, mainModule, config.URI, config.ID, config.Version); err != nil {
+		v.report(DiagnosticError, line, "%s", err.Error())
+	}
+
+	modules := append([]buildpack.BuildModule{mainModule}, depModules...)
+	packaged := map[string]bool{}
+	for _, module := range modules {
+		info := module.Descriptor().Info()
+		v.addModule(kind, info.ID, info.Version)
+		packaged[info.FullName()] = true
+		packaged[info.ID] = true
+	}
+
+	for _, module := range modules {
+		descriptor := module.Descriptor()
+		v.validateDescriptor(descriptor, line)
+
+		// the nested order of a buildpackage references the buildpacks packaged with it
+		for _, group := range descriptor.Order() {
+			for _, ref := range group.Group {
+				if !packaged[ref.FullName()] {
+					v.report(DiagnosticError, line, "order of %s %s references %s, which isn't in %s",
+						kind, style.Symbol(descriptor.Info().FullName()), style.Symbol(ref.FullName()), style.Symbol(config.DisplayString()))
+				}
+			}
+		}
+	}
+}
+
+// validateDescriptor validates a module against the lifecycle, and the targets and stack of the configuration
+func (v *builderValidation) validateDescriptor(descriptor buildpack.Descriptor, line int) {
+	if v.lifecycle != nil {
+		if err := builder.ValidateLifecycleCompat(descriptor, *v.lifecycle); err != nil {
+			v.report(DiagnosticError, line, "%s", err.Error())
+		}
+	}
+
+	if len(descriptor.Order()) > 0 {
+		return
+	}
+
+	targets := v.config.Targets
+	if len(targets) == 0 {
+		targets = []dist.Target{v.target}
+	}
+	for _, target := range targets {
+		distributions := target.Distributions
+		if len(distributions) == 0 {
+			distributions = []dist.Distribution{{}}
+		}
+		for _, distribution := range distributions {
+			if err := descriptor.EnsureTargetSupport(target.OS, target.Arch, distribution.Name, distribution.Version); err != nil {
+				v.report(DiagnosticError, line, "%s", err.Error())
+			}
+		}
+	}
+
+	if v.config.Stack.ID != "" && v.mixins != nil {
+		if err := descriptor.EnsureStackSupport(v.config.Stack.ID, v.mixins, false); err != nil {
+			v.report(DiagnosticError, line, "%s", err.Error())
+		}
+	}
+}
+
+// validateOrder validates that the groups of an order reference modules of the configuration, and can all be reached
+func (v *builderValidation) validateOrder(kind, key string, order dist.Order) {
+	missing := DiagnosticError
+	if v.unresolved {
+		// the missing module may be one that wasn't read
+		missing = DiagnosticWarning
+	}
+
+	for i, group := range order {
+		groupLine := v.lines.line(key, i)
+		for j, ref := range group.Group {
+			line := v.lines.line(key, i, "group", j)
+			if line == 0 {
+				line = groupLine
+			}
+			versions, ok := v.modules[kind][ref.ID]
+			if !ok {
+				v.report(missing, line, "%s %s is in the %s but isn't declared", kind, style.Symbol(ref.FullName()), style.Symbol(key))
+			} else if ref.Version != "" && !containsVersion(versions, ref.Version) && !containsVersion(versions, "") {
+				v.report(missing, line, "%s %s is in the %s but only versions %s are declared",
+					kind, style.Symbol(ref.FullName()), style.Symbol(key), strings.Join(versions, ", "))
+			}
+		}
+
+		for k := 0; k < i; k++ {
+			if sameGroup(order[k], group) {
+				v.report(DiagnosticWarning, groupLine, "group %d of the %s is a duplicate of group %d", i+1, style.Symbol(key), k+1)
+				break
+			}
+			if mayShadowGroup(order[k], group) {
+				v.report(DiagnosticInfo, groupLine, "group %d of the %s may be shadowed by group %d, which passes detection whenever it does unless its build plan can't be resolved", i+1, style.Symbol(key), k+1)
+				break
+			}
+		}
+	}
+}
+
+func (v *builderValidation) addModule(kind, id, version string) {
+	if v.modules[kind] == nil {
+		v.modules[kind] = map[string][]string{}
+	}
+	if containsVersion(v.modules[kind][id], version) {
+		return
+	}
+	v.modules[kind][id] = append(v.modules[kind][id], version)
+}
+
+func (v *builderValidation) cachedRegistryAddress(uri string) (string, error) {
+	registryCache, err := getRegistry(v.client.logger, v.client.keychain, v.opts.Registry)
+	if err != nil {
+		return "", errors.Wrapf(err, "lookup registry %s", style.Symbol(v.opts.Registry))
+	}
+
+	registryBuildpack, err := registryCache.LocateCachedBuildpack(uri)
+	if err != nil {
+		return "", err
+	}
+	return registryBuildpack.Address, nil
+}
+
+// sameGroup returns true when both groups have the same modules, in the same order
+func sameGroup(a, b dist.OrderEntry) bool {
+	if len(a.Group) != len(b.Group) {
+		return false
+	}
+	for i := range a.Group {
+		if a.Group[i].FullName() != b.Group[i].FullName() || a.Group[i].Optional != b.Group[i].Optional {
+			return false
+		}
+	}
+	return true
+}
+
+// mayShadowGroup returns true when every required module of the earlier group is a required module of the later one.
+// The earlier group then passes detection whenever the later group does, and shadows it unless the build plan of the
+// earlier group can't be resolved, which depends on the app and isn't known until the build.
+func mayShadowGroup(earlier, later dist.OrderEntry) bool {
+	required := map[string]bool{}
+	for _, ref := range later.Group {
+		if !ref.Optional {
+			required[ref.FullName()] = true
+		}
+	}
+
+	found := false
+	for _, ref := range earlier.Group {
+		if ref.Optional {
+			continue
+		}
+		if !required[ref.FullName()] {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+func containsVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocalURI returns true for URIs of files on the local filesystem
+func isLocalURI(uri string) bool {
+	parsed, err := url.Parse(uri)
+	return err == nil && parsed.Scheme == "file"
+}
+
+// configLines looks up the lines of the elements of a TOML file. Lines are 0 when the file couldn't be parsed or the
+// element isn't in it.
+type configLines struct {
+	tree *ptoml.Tree
+}
+
+// line returns the line of the element at path, made of keys and indexes in arrays of tables
+func (l configLines) line(path ...interface{}) int {
+	if l.tree == nil {
+		return 0
+	}
+
+	var current interface{} = l.tree
+	for i, element := range path {
+		switch element := element.(type) {
+		case string:
+			tree, ok := current.(*ptoml.Tree)
+			if !ok {
+				return 0
+			}
+			if i == len(path)-1 {
+				if _, isArray := tree.Get(element).([]*ptoml.Tree); !isArray {
+					return tree.GetPosition(element).Line
+				}
+			}
+			current = tree.Get(element)
+		case int:
+			trees, ok := current.([]*ptoml.Tree)
+			if !ok || element >= len(trees) {
+				return 0
+			}
+			current = trees[element]
+		}
+	}
+
+	if tree, ok := current.(*ptoml.Tree); ok {
+		return tree.Position().Line
+	}
+	return 0
+}
@@ -0,0 +1,280 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestValidateBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "validate_builder", testValidateBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testValidateBuilder(t *testing.T, when spec.G, it spec.S) {
+	when("#ValidateBuilder", func() {
+		var (
+			mockController          *gomock.Controller
+			mockImageFetcher        *testmocks.MockImageFetcher
+			mockDownloader          *testmocks.MockBlobDownloader
+			mockBuildpackDownloader *testmocks.MockBuildpackDownloader
+			tmpDir                  string
+			configPath              string
+			subject                 *client.Client
+			out                     bytes.Buffer
+		)
+
+		newBuildpack := func(descriptor dist.BuildpackDescriptor) buildpack.BuildModule {
+			bp, err := ifakes.NewFakeBuildpack(descriptor, 0644)
+			h.AssertNil(t, err)
+			return bp
+		}
+
+		writeConfig := func(contents string) {
+			h.AssertNil(t, os.WriteFile(configPath, []byte(strings.TrimPrefix(contents, "\n")), 0600))
+		}
+
+		findDiagnostic := func(diagnostics []client.BuilderDiagnostic, message string) client.BuilderDiagnostic {
+			t.Helper()
+			for _, diagnostic := range diagnostics {
+				if strings.Contains(diagnostic.Message, message) {
+					return diagnostic
+				}
+			}
+			t.Fatalf("no diagnostic containing %q in %+v", message, diagnostics)
+			return client.BuilderDiagnostic{}
+		}
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "validate-builder-test")
+			h.AssertNil(t, err)
+			configPath = filepath.Join(tmpDir, "builder.toml")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "some-bp"), 0755))
+
+			mockController = gomock.NewController(t)
+			mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+			mockDownloader = testmocks.NewMockBlobDownloader(mockController)
+			mockBuildpackDownloader = testmocks.NewMockBuildpackDownloader(mockController)
+
+			buildImage := fakes.NewImage("some/build-image", "", nil)
+			h.AssertNil(t, buildImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(buildImage, nil).AnyTimes()
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/run-image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+				Return(nil, errors.Wrap(image.ErrNotFound, "image 'some/run-image' does not exist on the daemon")).AnyTimes()
+
+			subject, err = client.NewClient(
+				client.WithLogger(logging.NewLogWithWriters(&out, &out)),
+				client.WithFetcher(mockImageFetcher),
+				client.WithDownloader(mockDownloader),
+				client.WithBuildpackDownloader(mockBuildpackDownloader),
+			)
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			mockController.Finish()
+			h.AssertNil(t, os.RemoveAll(tmpDir))
+		})
+
+		when("the config is valid", func() {
+			it("only reports what isn't validated offline", func() {
+				writeConfig(`
+[[buildpacks]]
+  id = "some/bp"
+  uri = "./some-bp"
+
+[[order]]
+  [[order.group]]
+    id = "some/bp"
+
+[build]
+  image = "some/build-image"
+
+[[run.images]]
+  image = "some/run-image"
+
+[lifecycle]
+  version = "0.20.0"
+`)
+				mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "./some-bp", gomock.Any()).Return(newBuildpack(dist.BuildpackDescriptor{
+					WithAPI:     api.MustParse("0.3"),
+					WithInfo:    dist.ModuleInfo{ID: "some/bp", Version: "1.0.0"},
+					WithTargets: []dist.Target{{OS: "linux", Arch: "amd64"}},
+				}), nil, nil)
+
+				diagnostics, err := subject.ValidateBuilder(context.TODO(), client.ValidateBuilderOptions{ConfigPath: configPath})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, diagnostics, []client.BuilderDiagnostic{
+					{Severity: client.DiagnosticInfo, File: configPath, Line: 12, Message: "run image 'some/run-image' isn't in the daemon, it is not validated"},
+					{Severity: client.DiagnosticInfo, File: configPath, Line: 15, Message: "lifecycle '0.20.0' is downloaded, the Buildpack API of the modules is not validated"},
+				})
+			})
+		})
+
+		when("the config has problems", func() {
+			var diagnostics []client.BuilderDiagnostic
+
+			it.Before(func() {
+				writeConfig(`
+[[buildpacks]]
+  uri = "./some-bp"
+
+[[buildpacks]]
+  uri = "docker://some/composite-bp"
+
+[[order]]
+  [[order.group]]
+    id = "some/bp"
+    version = "1.0.0"
+
+[[order]]
+  [[order.group]]
+    id = "some/bp"
+    version = "1.0.0"
+  [[order.group]]
+    id = "other/bp"
+    optional = true
+
+[[order]]
+  [[order.group]]
+    id = "missing/bp"
+
+[[order]]
+  [[order.group]]
+    id = "some/bp"
+    version = "1.0.0"
+
+[build]
+  image = "some/build-image"
+
+[[run.images]]
+  image = "some/run-image"
+
+[lifecycle]
+  uri = "./lifecycle"
+
+[[targets]]
+  os = "linux"
+  arch = "amd64"
+`)
+				lifecycleURI, err := paths.FilePathToURI("./lifecycle", tmpDir)
+				h.AssertNil(t, err)
+				mockDownloader.EXPECT().Download(gomock.Any(), lifecycleURI).Return(blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")), nil)
+
+				mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "./some-bp", gomock.Any()).Return(newBuildpack(dist.BuildpackDescriptor{
+					WithAPI:     api.MustParse("0.3"),
+					WithInfo:    dist.ModuleInfo{ID: "some/bp", Version: "1.0.0"},
+					WithTargets: []dist.Target{{OS: "linux", Arch: "amd64"}},
+				}), nil, nil)
+				mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "docker://some/composite-bp", gomock.Any()).Return(
+					newBuildpack(dist.BuildpackDescriptor{
+						WithAPI:  api.MustParse("0.3"),
+						WithInfo: dist.ModuleInfo{ID: "some/composite-bp", Version: "1.0.0"},
+						WithOrder: dist.Order{{Group: []dist.ModuleRef{
+							{ModuleInfo: dist.ModuleInfo{ID: "other/bp", Version: "1.0.0"}},
+							{ModuleInfo: dist.ModuleInfo{ID: "nested/bp", Version: "1.0.0"}},
+						}}},
+					}),
+					[]buildpack.BuildModule{newBuildpack(dist.BuildpackDescriptor{
+						WithAPI:     api.MustParse("0.10"),
+						WithInfo:    dist.ModuleInfo{ID: "other/bp", Version: "1.0.0"},
+						WithTargets: []dist.Target{{OS: "linux", Arch: "arm64"}},
+					})},
+					nil,
+				)
+
+				diagnostics, err = subject.ValidateBuilder(context.TODO(), client.ValidateBuilderOptions{ConfigPath: configPath})
+				h.AssertNil(t, err)
+			})
+
+			it("reports buildpacks incompatible with the lifecycle", func() {
+				diagnostic := findDiagnostic(diagnostics, "'other/bp@1.0.0' (Buildpack API 0.10) is incompatible with lifecycle")
+				h.AssertEq(t, diagnostic.Severity, client.DiagnosticError)
+				h.AssertEq(t, diagnostic.Line, 4)
+			})
+
+			it("reports buildpacks that don't support the targets", func() {
+				diagnostic := findDiagnostic(diagnostics, "unable to satisfy target os/arch constraints")
+				h.AssertEq(t, diagnostic.Severity, client.DiagnosticError)
+				h.AssertEq(t, diagnostic.Line, 4)
+			})
+
+			it("reports nested orders referencing buildpacks that aren't packaged", func() {
+				diagnostic := findDiagnostic(diagnostics, "order of buildpack 'some/composite-bp@1.0.0' references 'nested/bp@1.0.0'")
+				h.AssertEq(t, diagnostic.Severity, client.DiagnosticError)
+				h.AssertEq(t, diagnostic.Line, 4)
+			})
+
+			it("reports order groups referencing buildpacks that aren't declared", func() {
+				diagnostic := findDiagnostic(diagnostics, "buildpack 'missing/bp' is in the 'order' but isn't declared")
+				h.AssertEq(t, diagnostic.Severity, client.DiagnosticError)
+				h.AssertEq(t, diagnostic.Line, 21)
+			})
+
+			it("reports duplicate order groups and groups that may be shadowed", func() {
+				diagnostic := findDiagnostic(diagnostics, "group 2 of the 'order' may be shadowed by group 1, which passes detection whenever it does unless its build plan can't be resolved")
+				h.AssertEq(t, diagnostic.Severity, client.DiagnosticInfo)
+				h.AssertEq(t, diagnostic.Line, 12)
+
+				diagnostic = findDiagnostic(diagnostics, "group 4 of the 'order' is a duplicate of group 1")
+				h.AssertEq(t, diagnostic.Severity, client.DiagnosticWarning)
+				h.AssertEq(t, diagnostic.Line, 24)
+			})
+
+			it("sorts the diagnostics by line", func() {
+				for i := 1; i < len(diagnostics); i++ {
+					h.AssertTrue(t, diagnostics[i-1].Line <= diagnostics[i].Line)
+				}
+			})
+		})
+
+		when("the config isn't valid TOML", func() {
+			it("reports the line of the syntax error", func() {
+				writeConfig(`
+[[buildpacks]]
+  uri = "./some-bp"
+  id = some/bp
+`)
+
+				diagnostics, err := subject.ValidateBuilder(context.TODO(), client.ValidateBuilderOptions{ConfigPath: configPath})
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(diagnostics), 1)
+				h.AssertEq(t, diagnostics[0].Severity, client.DiagnosticError)
+				h.AssertEq(t, diagnostics[0].Line, 3)
+				h.AssertContains(t, diagnostics[0].Message, "invalid TOML")
+			})
+		})
+
+		when("the config doesn't exist", func() {
+			it("errors", func() {
+				_, err := subject.ValidateBuilder(context.TODO(), client.ValidateBuilderOptions{ConfigPath: filepath.Join(tmpDir, "missing.toml")})
+				h.AssertError(t, err, "opening config file")
+			})
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/lockfile"
+	"github.com/buildpacks/pack/pkg/signature"
+)
+
+// withVerification calls fn with a copy of the client that verifies the images and blobs it fetches against the
+// given policy, before anything from them is run
+func (c *Client) withVerification(policy signature.Policy, fn func(verifying *Client) error) error {
+	verifier, err := signature.NewVerifier(policy, c.keychain, c.downloader, c.logger)
+	if err != nil {
+		return err
+	}
+
+	verifying := *c
+	verifying.verifier = verifier
+	if err := fn(&verifying); err != nil {
+		return err
+	}
+
+	verified := 0
+	for _, result := range verifier.Results() {
+		if result.VerifiedBy != "" {
+			verified++
+		}
+	}
+	c.logger.Infof("Verified %d of %d images and blobs against the verification policy", verified, len(verifier.Results()))
+	return nil
+}
+
+// verifyImage verifies an image, if verifying
+func (c *Client) verifyImage(ctx context.Context, kind lockfile.Kind, ref string, img imgutil.Image) error {
+	if c.verifier == nil {
+		return nil
+	}
+	return c.verifier.VerifyImage(ctx, string(kind), ref, img)
+}
+
+// verifyLayoutImage verifies an image read from the OCI layout at layoutDir by the digest of its manifest, if verifying
+func (c *Client) verifyLayoutImage(kind lockfile.Kind, ref, layoutDir string, digest v1.Hash) error {
+	if c.verifier == nil {
+		return nil
+	}
+	path, err := layout.FromPath(layoutDir)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s %s", kind, style.Symbol(ref))
+	}
+	return c.verifier.VerifyLayoutImage(string(kind), ref, path, digest)
+}
+
+// verifyBlob verifies a blob downloaded from a remote URI, if verifying. Blobs on the local filesystem aren't
+// verified.
+func (c *Client) verifyBlob(ctx context.Context, kind lockfile.Kind, uri string, b blob.Blob) error {
+	if c.verifier == nil || !paths.IsURI(uri) || strings.HasPrefix(uri, "file:") {
+		return nil
+	}
+	return c.verifier.VerifyBlob(ctx, string(kind), uri, b)
+}
+
+// moduleVerifier returns the verifier of the buildpacks and extensions to download, if verifying
+func (c *Client) moduleVerifier() buildpack.Verifier {
+	if c.verifier == nil {
+		return nil
+	}
+	return c.verifier
+}
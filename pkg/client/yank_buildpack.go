@@ -14,7 +14,9 @@ type YankBuildpackOptions struct {
 	Version string
 	Type    string
 	URL     string
-	Yank    bool
+	// Name of the registry, used to update registries of type 'oci' or 'dir' directly
+	Name string
+	Yank bool
 }
 
 // YankBuildpack marks a buildpack on the Buildpack Registry as 'yanked'. This forbids future
@@ -24,10 +26,6 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 	if err != nil {
 		return err
 	}
-	issueURL, err := registry.GetIssueURL(opts.URL)
-	if err != nil {
-		return err
-	}
 
 	buildpack := registry.Buildpack{
 		Namespace: namespace,
@@ -36,6 +34,19 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 		Yanked:    opts.Yank,
 	}
 
+	if opts.Type == "oci" || opts.Type == "dir" {
+		registryCache, err := getRegistry(c.logger, c.keychain, opts.Name)
+		if err != nil {
+			return err
+		}
+		return registryCache.Yank(buildpack)
+	}
+
+	issueURL, err := registry.GetIssueURL(opts.URL)
+	if err != nil {
+		return err
+	}
+
 	issue, err := registry.CreateGithubIssue(buildpack)
 	if err != nil {
 		return err
@@ -0,0 +1,215 @@
+// Package lockfile records the digests that the buildpacks, extensions, lifecycle and images of a build resolve to,
+// so that later builds can verify they still resolve to the same content.
+package lockfile
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// DefaultName is the conventional name of a lockfile
+const DefaultName = "pack.lock"
+
+const header = "# This file is generated by pack and records the digests that references resolved to.\n# Commit it to make builds reproducible.\n\n"
+
+// Mode defines what happens when a reference no longer resolves to the digest in the lockfile
+type Mode int
+
+const (
+	// ModeVerify fails when a reference resolves to a different digest
+	ModeVerify Mode = iota
+	// ModeWarn warns when a reference resolves to a different digest, and keeps the locked digest
+	ModeWarn
+	// ModeUpdate records the digests references resolve to, replacing the lockfile
+	ModeUpdate
+)
+
+var nameMap = map[string]Mode{"verify": ModeVerify, "warn": ModeWarn, "update": ModeUpdate, "": ModeVerify}
+
+// ParseMode from string
+func ParseMode(mode string) (Mode, error) {
+	if val, ok := nameMap[mode]; ok {
+		return val, nil
+	}
+
+	return ModeVerify, errors.Errorf("invalid lockfile mode %s", style.Symbol(mode))
+}
+
+func (m Mode) String() string {
+	switch m {
+	case ModeVerify:
+		return "verify"
+	case ModeWarn:
+		return "warn"
+	case ModeUpdate:
+		return "update"
+	}
+
+	return ""
+}
+
+// Kind is the kind of a locked reference
+type Kind string
+
+const (
+	KindBuildpack  Kind = "buildpack"
+	KindExtension  Kind = "extension"
+	KindLifecycle  Kind = "lifecycle"
+	KindBuilder    Kind = "builder"
+	KindBuildImage Kind = "build-image"
+	KindRunImage   Kind = "run-image"
+)
+
+// Entry is a reference and the digest it resolved to
+type Entry struct {
+	Kind Kind `toml:"kind"`
+	// Ref is the reference as it was given, e.g. a registry ID with a version range, a tag or a URL
+	Ref string `toml:"ref"`
+	// Platform the reference was resolved for, as '<os>/<arch>[/<variant>]'
+	Platform string `toml:"platform,omitempty"`
+	// ID and Version of the buildpack or extension the reference resolved to
+	ID      string `toml:"id,omitempty"`
+	Version string `toml:"version,omitempty"`
+	Digest  string `toml:"digest"`
+}
+
+func (e Entry) key() string {
+	return fmt.Sprintf("%s\x00%s\x00%s", e.Kind, e.Ref, e.Platform)
+}
+
+// Lockfile is the content of a lockfile
+type Lockfile struct {
+	Entries []Entry `toml:"resolved"`
+}
+
+// Read a lockfile
+func Read(path string) (Lockfile, error) {
+	var lockfile Lockfile
+	if _, err := toml.DecodeFile(path, &lockfile); err != nil {
+		return Lockfile{}, errors.Wrapf(err, "reading lockfile %s", style.Symbol(path))
+	}
+	return lockfile, nil
+}
+
+// Write the lockfile, with its entries sorted by kind, reference and platform
+func (l Lockfile) Write(path string) error {
+	entries := append([]Entry{}, l.Entries...)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key() < entries[j].key()
+	})
+
+	buf := bytes.NewBufferString(header)
+	if err := toml.NewEncoder(buf).Encode(Lockfile{Entries: entries}); err != nil {
+		return errors.Wrap(err, "encoding lockfile")
+	}
+	return errors.Wrapf(os.WriteFile(path, buf.Bytes(), 0644), "writing lockfile %s", style.Symbol(path))
+}
+
+// Lock verifies the references resolved by a build against a lockfile, and records them to update it. It is safe for
+// concurrent use.
+type Lock struct {
+	path   string
+	mode   Mode
+	logger logging.Logger
+
+	mutex    sync.Mutex
+	locked   map[string]Entry
+	resolved map[string]Entry
+	changed  bool
+}
+
+// Open the lockfile at the given path. The lockfile doesn't need to exist, it is then created when saved.
+func Open(path string, mode Mode, logger logging.Logger) (*Lock, error) {
+	lock := &Lock{
+		path:     path,
+		mode:     mode,
+		logger:   logger,
+		locked:   map[string]Entry{},
+		resolved: map[string]Entry{},
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "reading lockfile %s", style.Symbol(path))
+		}
+		lock.changed = true
+		return lock, nil
+	}
+
+	lockfile, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range lockfile.Entries {
+		lock.locked[entry.key()] = entry
+	}
+	return lock, nil
+}
+
+// Resolve records the digest a reference resolved to. It errors when the digest doesn't match the locked one, unless
+// the lock warns about mismatches or updates the lockfile.
+func (l *Lock) Resolve(entry Entry) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	key := entry.key()
+	locked, ok := l.locked[key]
+	switch {
+	case !ok:
+		l.changed = true
+	case locked.Digest != entry.Digest && l.mode == ModeVerify:
+		return errors.Errorf("%s %s resolved to %s, which doesn't match %s in lockfile %s",
+			entry.Kind, style.Symbol(entry.Ref), style.Symbol(entry.Digest), style.Symbol(locked.Digest), style.Symbol(l.path))
+	case locked.Digest != entry.Digest && l.mode == ModeWarn:
+		l.logger.Warnf("%s %s resolved to %s, which doesn't match %s in lockfile %s",
+			entry.Kind, style.Symbol(entry.Ref), style.Symbol(entry.Digest), style.Symbol(locked.Digest), style.Symbol(l.path))
+		entry = locked
+	case locked != entry:
+		l.changed = true
+	}
+
+	l.resolved[key] = entry
+	return nil
+}
+
+// Save writes the lockfile if anything was resolved that wasn't locked yet. When updating the lockfile, the entries
+// that weren't resolved are removed.
+func (l *Lock) Save() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entries := map[string]Entry{}
+	if l.mode != ModeUpdate {
+		for key, entry := range l.locked {
+			entries[key] = entry
+		}
+	} else if len(l.locked) != len(l.resolved) {
+		l.changed = true
+	}
+	for key, entry := range l.resolved {
+		entries[key] = entry
+	}
+
+	if !l.changed {
+		return nil
+	}
+
+	var lockfile Lockfile
+	for _, entry := range entries {
+		lockfile.Entries = append(lockfile.Entries, entry)
+	}
+	if err := lockfile.Write(l.path); err != nil {
+		return err
+	}
+	l.logger.Infof("Wrote lockfile %s", style.Symbol(l.path))
+	return nil
+}
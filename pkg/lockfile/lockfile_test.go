@@ -0,0 +1,162 @@
+package lockfile_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/lockfile"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLockfile(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Lockfile", testLockfile, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLockfile(t *testing.T, when spec.G, it spec.S) {
+	var (
+		path   string
+		outBuf bytes.Buffer
+		logger logging.Logger
+
+		builderEntry = lockfile.Entry{Kind: lockfile.KindBuilder, Ref: "some/builder", Platform: "linux/amd64", Digest: "sha256:builder"}
+		nodeEntry    = lockfile.Entry{Kind: lockfile.KindBuildpack, Ref: "urn:cnb:registry:example/node@^1.4", Platform: "linux/amd64", ID: "example/node", Version: "1.4.2", Digest: "sha256:node"}
+	)
+
+	it.Before(func() {
+		path = filepath.Join(t.TempDir(), lockfile.DefaultName)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	// lock writes a lockfile with the given entries
+	lock := func(entries ...lockfile.Entry) {
+		h.AssertNil(t, lockfile.Lockfile{Entries: entries}.Write(path))
+	}
+
+	when("#ParseMode", func() {
+		it("parses the modes", func() {
+			for _, mode := range []lockfile.Mode{lockfile.ModeVerify, lockfile.ModeWarn, lockfile.ModeUpdate} {
+				parsed, err := lockfile.ParseMode(mode.String())
+				h.AssertNil(t, err)
+				h.AssertEq(t, parsed, mode)
+			}
+		})
+
+		it("errors for unknown modes", func() {
+			_, err := lockfile.ParseMode("ignore")
+			h.AssertError(t, err, "invalid lockfile mode 'ignore'")
+		})
+	})
+
+	when("the lockfile doesn't exist", func() {
+		it("writes the resolved references sorted by kind", func() {
+			l, err := lockfile.Open(path, lockfile.ModeVerify, logger)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, l.Resolve(nodeEntry))
+			h.AssertNil(t, l.Resolve(builderEntry))
+			h.AssertNil(t, l.Save())
+
+			written, err := lockfile.Read(path)
+			h.AssertNil(t, err)
+			h.AssertEq(t, written.Entries, []lockfile.Entry{builderEntry, nodeEntry})
+			h.AssertContains(t, outBuf.String(), "Wrote lockfile")
+		})
+	})
+
+	when("the lockfile exists", func() {
+		it("accepts references resolving to the locked digests without rewriting the lockfile", func() {
+			lock(builderEntry, nodeEntry)
+			h.AssertNil(t, os.Chmod(path, 0400))
+
+			l, err := lockfile.Open(path, lockfile.ModeVerify, logger)
+			h.AssertNil(t, err)
+			h.AssertNil(t, l.Resolve(builderEntry))
+			h.AssertNil(t, l.Save())
+			h.AssertNotContains(t, outBuf.String(), "Wrote lockfile")
+		})
+
+		it("adds new references and keeps the ones that weren't resolved", func() {
+			lock(builderEntry)
+
+			l, err := lockfile.Open(path, lockfile.ModeVerify, logger)
+			h.AssertNil(t, err)
+			h.AssertNil(t, l.Resolve(nodeEntry))
+			h.AssertNil(t, l.Save())
+
+			written, err := lockfile.Read(path)
+			h.AssertNil(t, err)
+			h.AssertEq(t, written.Entries, []lockfile.Entry{builderEntry, nodeEntry})
+		})
+
+		it("locks a reference for each platform", func() {
+			lock(builderEntry)
+
+			l, err := lockfile.Open(path, lockfile.ModeVerify, logger)
+			h.AssertNil(t, err)
+			armEntry := builderEntry
+			armEntry.Platform = "linux/arm64"
+			armEntry.Digest = "sha256:arm-builder"
+			h.AssertNil(t, l.Resolve(armEntry))
+		})
+
+		when("a reference resolves to a different digest", func() {
+			var changed lockfile.Entry
+
+			it.Before(func() {
+				lock(builderEntry, nodeEntry)
+				changed = nodeEntry
+				changed.Version = "1.4.3"
+				changed.Digest = "sha256:new-node"
+			})
+
+			it("errors when verifying", func() {
+				l, err := lockfile.Open(path, lockfile.ModeVerify, logger)
+				h.AssertNil(t, err)
+
+				err = l.Resolve(changed)
+				h.AssertError(t, err, "buildpack 'urn:cnb:registry:example/node@^1.4' resolved to 'sha256:new-node', which doesn't match 'sha256:node' in lockfile")
+			})
+
+			it("warns and keeps the locked digest when warning", func() {
+				l, err := lockfile.Open(path, lockfile.ModeWarn, logger)
+				h.AssertNil(t, err)
+
+				h.AssertNil(t, l.Resolve(changed))
+				h.AssertNil(t, l.Save())
+				h.AssertContains(t, outBuf.String(), "Warning: buildpack 'urn:cnb:registry:example/node@^1.4' resolved to 'sha256:new-node'")
+
+				written, err := lockfile.Read(path)
+				h.AssertNil(t, err)
+				h.AssertEq(t, written.Entries, []lockfile.Entry{builderEntry, nodeEntry})
+			})
+
+			it("replaces the lockfile with the resolved references when updating", func() {
+				l, err := lockfile.Open(path, lockfile.ModeUpdate, logger)
+				h.AssertNil(t, err)
+
+				h.AssertNil(t, l.Resolve(changed))
+				h.AssertNil(t, l.Save())
+
+				written, err := lockfile.Read(path)
+				h.AssertNil(t, err)
+				h.AssertEq(t, written.Entries, []lockfile.Entry{changed})
+			})
+		})
+
+		it("errors when the lockfile is invalid", func() {
+			h.AssertNil(t, os.WriteFile(path, []byte("not toml ["), 0600))
+
+			_, err := lockfile.Open(path, lockfile.ModeVerify, logger)
+			h.AssertError(t, err, "reading lockfile")
+		})
+	})
+}
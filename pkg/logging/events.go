@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType identifies what a build Event reports
+type EventType string
+
+const (
+	// EventPhaseStarted is reported when a lifecycle phase starts running
+	EventPhaseStarted EventType = "phase-started"
+	// EventPhaseFinished is reported when a lifecycle phase exits, along with its duration
+	EventPhaseFinished EventType = "phase-finished"
+	// EventAppUploaded is reported once the app has been copied into the build container, along with the time it took
+	EventAppUploaded EventType = "app-uploaded"
+	// EventDetectPassed is reported for each buildpack that passed detection
+	EventDetectPassed EventType = "detect-passed"
+	// EventDetectFailed is reported for each buildpack that failed detection
+	EventDetectFailed EventType = "detect-failed"
+	// EventLayerReused is reported for each layer reused from the previous image
+	EventLayerReused EventType = "layer-reused"
+	// EventCacheHit is reported for each layer restored or reused from the build cache
+	EventCacheHit EventType = "cache-hit"
+	// EventImageExported is reported with the digest of the exported image
+	EventImageExported EventType = "image-exported"
+	// EventOutput is reported for each line of output of a lifecycle phase
+	EventOutput EventType = "output"
+	// EventLog is reported for debug and info messages
+	EventLog EventType = "log"
+	// EventWarning is reported for warnings
+	EventWarning EventType = "warning"
+	// EventError is reported for errors
+	EventError EventType = "error"
+)
+
+// Event is a structured record of something that happened during a build
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	// Lifecycle phase the event belongs to, if any.
+	Phase string `json:"phase,omitempty"`
+	// Level of log and output events.
+	Level     string `json:"level,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Buildpack string `json:"buildpack,omitempty"`
+	Layer     string `json:"layer,omitempty"`
+	Image     string `json:"image,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	// Time taken by a finished phase or app upload.
+	Duration time.Duration `json:"-"`
+	// Error a phase finished with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the event, with the duration in milliseconds
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+	return json.Marshal(struct {
+		event
+		DurationMS int64 `json:"durationMs,omitempty"`
+	}{event(e), e.Duration.Milliseconds()})
+}
+
+// EventSink receives build events as they happen. Events may be reported from different goroutines, but never
+// concurrently.
+type EventSink func(event Event)
+
+type hasEventSink interface {
+	EventSink() EventSink
+}
+
+// GetEventSink retrieves the EventSink reporting build events through the logger, or nil if the logger doesn't
+// report events.
+func GetEventSink(logger Logger) EventSink {
+	if l, ok := logger.(hasEventSink); ok {
+		return l.EventSink()
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+)
+
+var _ Logger = (*JSONLogger)(nil)
+
+// JSONLogger is a logger that writes log messages and build events as JSON lines, for consumption by other tools
+type JSONLogger struct {
+	sync.Mutex
+	log.Logger
+	clock func() time.Time
+	out   io.Writer
+}
+
+// NewJSONLogger creates a logger writing JSON lines to out
+func NewJSONLogger(out io.Writer) *JSONLogger {
+	jl := &JSONLogger{
+		Logger: log.Logger{
+			Level: log.InfoLevel,
+		},
+		clock: time.Now,
+		out:   out,
+	}
+	jl.Logger.Handler = jl
+
+	return jl
+}
+
+// HandleLog handles log entries, writing them as log, warning or error events
+func (jl *JSONLogger) HandleLog(e *log.Entry) error {
+	event := Event{Type: EventLog, Message: strings.TrimSuffix(string(stripColor([]byte(e.Message))), "\n")}
+	switch e.Level {
+	case log.WarnLevel:
+		event.Type = EventWarning
+	case log.ErrorLevel, log.FatalLevel:
+		event.Type = EventError
+	default:
+		event.Level = e.Level.String()
+	}
+
+	return jl.writeEvent(event)
+}
+
+// HandleEvent writes the given build event
+func (jl *JSONLogger) HandleEvent(event Event) {
+	_ = jl.writeEvent(event)
+}
+
+// EventSink returns the sink writing build events to the logger
+func (jl *JSONLogger) EventSink() EventSink {
+	return jl.HandleEvent
+}
+
+// WriterForLevel returns a Writer reporting each line written to it as an event of the given Level
+func (jl *JSONLogger) WriterForLevel(level Level) io.Writer {
+	if jl.Level > log.Level(level) {
+		return io.Discard
+	}
+
+	return jl.newWriter(level)
+}
+
+// Writer returns a Writer reporting each line written to it as an info event
+func (jl *JSONLogger) Writer() io.Writer {
+	return jl.newWriter(InfoLevel)
+}
+
+// WantQuiet reduces the number of logs returned
+func (jl *JSONLogger) WantQuiet(f bool) {
+	if f {
+		jl.Level = quietLevel
+	}
+}
+
+// WantVerbose increases the number of logs returned
+func (jl *JSONLogger) WantVerbose(f bool) {
+	if f {
+		jl.Level = verboseLevel
+	}
+}
+
+// IsVerbose returns whether verbose logging is on
+func (jl *JSONLogger) IsVerbose() bool {
+	return jl.Level == log.DebugLevel
+}
+
+func (jl *JSONLogger) newWriter(level Level) *jsonWriter {
+	return &jsonWriter{logger: jl, level: log.Level(level).String(), buf: &bytes.Buffer{}}
+}
+
+func (jl *JSONLogger) writeEvent(event Event) error {
+	jl.Lock()
+	defer jl.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = jl.clock()
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(jl.out, "%s\n", line)
+	return err
+}
+
+// jsonWriter reports each complete line written to it as an event. Lines written through a prefixed writer are
+// reported as the output of the phase named by the prefix.
+type jsonWriter struct {
+	logger *JSONLogger
+	level  string
+	phase  string
+	buf    *bytes.Buffer
+}
+
+func (w *jsonWriter) Write(data []byte) (int, error) {
+	w.buf.Write(data)
+	for {
+		line, err := w.buf.ReadBytes('\n')
+		if err != nil {
+			// keep the incomplete line for the next write
+			w.buf.Write(line)
+			break
+		}
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Close writes any pending data in the buffer
+func (w *jsonWriter) Close() error {
+	if w.buf.Len() > 0 {
+		line := w.buf.Bytes()
+		w.buf.Reset()
+		return w.writeLine(line)
+	}
+
+	return nil
+}
+
+// WithPrefix returns a writer reporting lines as the output of the given phase
+func (w *jsonWriter) WithPrefix(prefix string) io.Writer {
+	return &jsonWriter{logger: w.logger, level: w.level, phase: prefix, buf: &bytes.Buffer{}}
+}
+
+func (w *jsonWriter) writeLine(line []byte) error {
+	line = dropCR(bytes.TrimSuffix(line, []byte{'\n'}))
+	// process any CR in message
+	if i := bytes.LastIndexByte(line, '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+
+	event := Event{Type: EventLog, Level: w.level, Message: string(stripColor(line))}
+	if w.phase != "" {
+		event.Type = EventOutput
+		event.Phase = w.phase
+	}
+	return w.logger.writeEvent(event)
+}
@@ -0,0 +1,91 @@
+package logging_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestJSONLogger(t *testing.T) {
+	spec.Run(t, "JSONLogger", testJSONLogger, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testJSONLogger(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger   *logging.JSONLogger
+		out      bytes.Buffer
+		testTime = time.Date(2019, 5, 15, 1, 1, 1, 0, time.UTC)
+	)
+
+	it.Before(func() {
+		out.Reset()
+		logger = logging.NewJSONLogger(&out)
+	})
+
+	it("writes messages as log, warning and error events", func() {
+		logger.Debug("debug_")
+		logger.Infof("info %s", color.HiBlueString("colored"))
+		logger.Warn("warn_")
+		logger.Error("error_")
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		h.AssertEq(t, len(lines), 3)
+		h.AssertContains(t, string(lines[0]), `"type":"log","level":"info","message":"info colored"`)
+		h.AssertContains(t, string(lines[1]), `"type":"warning","message":"warn_"`)
+		h.AssertContains(t, string(lines[2]), `"type":"error","message":"error_"`)
+	})
+
+	it("writes debug messages when verbose", func() {
+		logger.WantVerbose(true)
+		logger.Debug("debug_")
+
+		h.AssertTrue(t, logger.IsVerbose())
+		h.AssertContains(t, out.String(), `"type":"log","level":"debug","message":"debug_"`)
+	})
+
+	it("writes build events", func() {
+		logging.GetEventSink(logger)(logging.Event{
+			Time:     testTime,
+			Type:     logging.EventPhaseFinished,
+			Phase:    "builder",
+			Duration: 1500 * time.Millisecond,
+		})
+
+		h.AssertEq(t, out.String(), `{"time":"2019-05-15T01:01:01Z","type":"phase-finished","phase":"builder","durationMs":1500}`+"\n")
+	})
+
+	when("#WriterForLevel", func() {
+		it("writes each complete line as an event", func() {
+			w := logger.WriterForLevel(logging.ErrorLevel)
+			_, err := fmt.Fprint(w, "first\nsec")
+			h.AssertNil(t, err)
+			h.AssertContains(t, out.String(), `"type":"log","level":"error","message":"first"`)
+			h.AssertNotContains(t, out.String(), "sec")
+
+			_, err = fmt.Fprint(w, "ond\r\n")
+			h.AssertNil(t, err)
+			h.AssertContains(t, out.String(), `"message":"second"`)
+		})
+
+		it("writes the lines of prefixed writers as phase output", func() {
+			w := logging.NewPrefixedWriter(logger.WriterForLevel(logging.InfoLevel), "detector")
+			_, err := fmt.Fprintln(w, "pass: some/buildpack@1.0")
+			h.AssertNil(t, err)
+
+			h.AssertContains(t, out.String(), `"type":"output","phase":"detector","level":"info","message":"pass: some/buildpack@1.0"`)
+		})
+
+		it("discards debug output unless verbose", func() {
+			h.AssertSameInstance(t, logger.WriterForLevel(logging.DebugLevel), io.Discard)
+		})
+	})
+}
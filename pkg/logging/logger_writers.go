@@ -40,6 +40,7 @@ type LogWithWriters struct {
 	clock    func() time.Time
 	out      io.Writer
 	errOut   io.Writer
+	json     *JSONLogger
 }
 
 // NewLogWithWriters creates a logger to be used with pack CLI.
@@ -78,6 +79,10 @@ func WithVerbose() func(writers *LogWithWriters) {
 
 // HandleLog handles log events, printing entries appropriately
 func (lw *LogWithWriters) HandleLog(e *log.Entry) error {
+	if lw.json != nil {
+		return lw.json.HandleLog(e)
+	}
+
 	lw.Lock()
 	defer lw.Unlock()
 
@@ -93,6 +98,10 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 		return io.Discard
 	}
 
+	if lw.json != nil {
+		return lw.json.newWriter(level)
+	}
+
 	if level == ErrorLevel {
 		return newLogWriter(lw.errOut, lw.clock, lw.wantTime)
 	}
@@ -102,9 +111,32 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 
 // Writer returns the base Writer for the LogWithWriters
 func (lw *LogWithWriters) Writer() io.Writer {
+	if lw.json != nil {
+		return lw.json.Writer()
+	}
+
 	return lw.out
 }
 
+// EventSink returns the sink reporting build events when JSON output is on, or nil otherwise
+func (lw *LogWithWriters) EventSink() EventSink {
+	if lw.json != nil {
+		return lw.json.EventSink()
+	}
+
+	return nil
+}
+
+// WantJSON turns on writing log entries and build events to stdout as JSON lines
+func (lw *LogWithWriters) WantJSON(f bool) {
+	if f {
+		lw.json = NewJSONLogger(lw.out)
+		lw.json.clock = lw.clock
+	} else {
+		lw.json = nil
+	}
+}
+
 // WantTime turns timestamps on in log entries
 func (lw *LogWithWriters) WantTime(f bool) {
 	lw.wantTime = f
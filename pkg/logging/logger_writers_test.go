@@ -210,6 +210,29 @@ func testLogWithWriters(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("json is set to true", func() {
+		it.Before(func() {
+			logger.WantJSON(true)
+		})
+
+		it("writes messages and events to standard writer as JSON lines", func() {
+			logger.Info("info_")
+			logger.Error("error_")
+			logging.GetEventSink(logger)(logging.Event{Type: logging.EventPhaseStarted, Phase: "detector"})
+
+			h.AssertEq(t, fOut(), `{"time":"2019-05-15T01:01:01Z","type":"log","level":"info","message":"info_"}
+{"time":"2019-05-15T01:01:01Z","type":"error","message":"error_"}
+{"time":"2019-05-15T01:01:01Z","type":"phase-started","phase":"detector"}
+`)
+			h.AssertEq(t, fErr(), "")
+		})
+
+		it("doesn't report events otherwise", func() {
+			logger.WantJSON(false)
+			h.AssertTrue(t, logging.GetEventSink(logger) == nil)
+		})
+	})
+
 	it("will convert an empty string to a line feed", func() {
 		logger.Info("")
 		expected := "\n"
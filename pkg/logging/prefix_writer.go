@@ -43,6 +43,21 @@ func NewPrefixWriter(w io.Writer, prefix string, opts ...PrefixWriterOption) *Pr
 	return writer
 }
 
+type isPrefixable interface {
+	WithPrefix(prefix string) io.Writer
+}
+
+// NewPrefixedWriter prefixes the lines written by w, unless w attaches the prefix to its output itself.
+//
+// See NewPrefixWriter
+func NewPrefixedWriter(w io.Writer, prefix string) io.Writer {
+	if p, ok := w.(isPrefixable); ok {
+		return p.WithPrefix(prefix)
+	}
+
+	return NewPrefixWriter(w, prefix)
+}
+
 // Write writes bytes to the embedded log function
 func (w *PrefixWriter) Write(data []byte) (int, error) {
 	scanner := bufio.NewScanner(w.readerFactory(data))
@@ -0,0 +1,114 @@
+package sbom
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BuildpackProperty is the property of merged CycloneDX components holding the escaped ID of the buildpack that
+// listed them
+const BuildpackProperty = "io.buildpacks.buildpack.id"
+
+type cycloneDXDocument struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    *cycloneDXMetadata   `json:"metadata,omitempty"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp,omitempty"`
+	Tools     []cycloneDXTool     `json:"tools,omitempty"`
+	Component *cycloneDXComponent `json:"component,omitempty"`
+}
+
+type cycloneDXTool struct {
+	Vendor  string `json:"vendor"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Type       string              `json:"type"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Licenses   []cycloneDXLicense  `json:"licenses,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	License *struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"license,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func decodeCycloneDX(contents []byte) ([]Component, error) {
+	var doc cycloneDXDocument
+	if err := json.Unmarshal(contents, &doc); err != nil {
+		return nil, err
+	}
+
+	var components []Component
+	for _, c := range doc.Components {
+		component := Component{Name: c.Name, Version: c.Version, Type: c.Type, PURL: c.PURL}
+		for _, l := range c.Licenses {
+			switch {
+			case l.Expression != "":
+				component.Licenses = append(component.Licenses, l.Expression)
+			case l.License != nil && l.License.ID != "":
+				component.Licenses = append(component.Licenses, l.License.ID)
+			case l.License != nil && l.License.Name != "":
+				component.Licenses = append(component.Licenses, l.License.Name)
+			}
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+func (d Document) cycloneDX(toolVersion string) cycloneDXDocument {
+	doc := cycloneDXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+		Metadata: &cycloneDXMetadata{
+			Tools:     []cycloneDXTool{{Vendor: "Cloud Native Buildpacks", Name: "pack", Version: toolVersion}},
+			Component: &cycloneDXComponent{Type: "container", Name: d.Name},
+		},
+		Components: []cycloneDXComponent{},
+	}
+	if !d.Created.IsZero() {
+		doc.Metadata.Timestamp = d.Created.Format(time.RFC3339)
+	}
+
+	for _, c := range d.Components {
+		component := cycloneDXComponent{
+			BOMRef:  c.key(),
+			Type:    c.Type,
+			Name:    c.Name,
+			Version: c.Version,
+			PURL:    c.PURL,
+		}
+		if component.Type == "" {
+			component.Type = "library"
+		}
+		for _, l := range c.Licenses {
+			component.Licenses = append(component.Licenses, cycloneDXLicense{Expression: l})
+		}
+		if c.Buildpack != "" {
+			component.Properties = []cycloneDXProperty{{Name: BuildpackProperty, Value: c.Buildpack}}
+		}
+		doc.Components = append(doc.Components, component)
+	}
+	return doc
+}
@@ -0,0 +1,239 @@
+// Package sbom reads the SBOM files buildpacks write in the CycloneDX, SPDX and Syft formats, and merges them into a
+// single document.
+package sbom
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Format is a format SBOMs are merged into
+type Format int
+
+const (
+	// FormatCycloneDXJSON is the CycloneDX JSON format
+	FormatCycloneDXJSON Format = iota
+	// FormatSPDXJSON is the SPDX JSON format
+	FormatSPDXJSON
+)
+
+var nameMap = map[string]Format{"cyclonedx-json": FormatCycloneDXJSON, "spdx-json": FormatSPDXJSON}
+
+// ParseFormat from string
+func ParseFormat(format string) (Format, error) {
+	if val, ok := nameMap[format]; ok {
+		return val, nil
+	}
+
+	return FormatCycloneDXJSON, errors.Errorf("invalid SBOM format %s, must be one of cyclonedx-json or spdx-json", style.Symbol(format))
+}
+
+func (f Format) String() string {
+	switch f {
+	case FormatCycloneDXJSON:
+		return "cyclonedx-json"
+	case FormatSPDXJSON:
+		return "spdx-json"
+	}
+
+	return ""
+}
+
+// Extension is the extension the lifecycle gives SBOM files of the format
+func (f Format) Extension() string {
+	switch f {
+	case FormatSPDXJSON:
+		return ".spdx.json"
+	default:
+		return ".cdx.json"
+	}
+}
+
+// Component is a package listed in an SBOM
+type Component struct {
+	Name    string
+	Version string
+	// Type is the type of the component, such as 'library' or 'application'
+	Type string
+	// PURL is the package URL of the component
+	PURL string
+	// Licenses are SPDX license IDs or expressions, or license names
+	Licenses []string
+	// Buildpack is the ID of the buildpack whose SBOM listed the component, as escaped in the SBOM layer
+	Buildpack string
+}
+
+func (c Component) key() string {
+	if c.PURL != "" {
+		return c.PURL
+	}
+	return c.Name + "@" + c.Version
+}
+
+// ID identifies the component regardless of its version: its package URL without version, qualifiers and subpath, or
+// its name when it has none
+func (c Component) ID() string {
+	if c.PURL == "" {
+		return c.Name
+	}
+	id := c.PURL
+	if i := strings.IndexAny(id, "?#"); i >= 0 {
+		id = id[:i]
+	}
+	if i := strings.LastIndex(id, "@"); i > strings.LastIndex(id, "/") {
+		id = id[:i]
+	}
+	return id
+}
+
+// Document lists the components of the SBOMs of an image
+type Document struct {
+	// Name of the image the SBOMs describe
+	Name string
+	// Created is when the document was created
+	Created    time.Time
+	Components []Component
+}
+
+const (
+	// ScopeLaunch is the directory of the SBOMs of the dependencies of the app image
+	ScopeLaunch = "launch"
+	// ScopeBuild is the directory of the SBOMs of the dependencies used during the build
+	ScopeBuild = "build"
+)
+
+// File is an SBOM file found in the SBOM layer of an image
+type File struct {
+	// Path of the file in the SBOM layer, such as 'layers/sbom/launch/paketo-buildpacks_node-engine/node/sbom.cdx.json'
+	Path     string
+	Contents []byte
+}
+
+// Buildpack returns the escaped ID of the buildpack that wrote the file, or an empty string when the file isn't in the
+// directory of a buildpack
+func (f File) Buildpack() string {
+	_, bp := f.scopeAndBuildpack()
+	return bp
+}
+
+// Scope returns ScopeLaunch or ScopeBuild depending on the dependencies the file describes, or an empty string when the
+// file isn't in the directory of a buildpack
+func (f File) Scope() string {
+	scope, _ := f.scopeAndBuildpack()
+	return scope
+}
+
+func (f File) scopeAndBuildpack() (string, string) {
+	parts := strings.Split(strings.TrimPrefix(path.Clean("/"+f.Path), "/"), "/")
+	for i, part := range parts {
+		if (part == ScopeLaunch || part == ScopeBuild) && i+2 < len(parts) {
+			return part, parts[i+1]
+		}
+	}
+	return "", ""
+}
+
+// IsSBOM returns true when the file is in one of the formats components are read from
+func (f File) IsSBOM() bool {
+	for _, ext := range []string{".cdx.json", ".spdx.json", ".syft.json"} {
+		if strings.HasSuffix(f.Path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesBuildpacks returns true when the file was written by one of the buildpacks, or when no buildpacks are given
+func (f File) MatchesBuildpacks(buildpackIDs []string) bool {
+	if len(buildpackIDs) == 0 {
+		return true
+	}
+	bp := f.Buildpack()
+	for _, id := range buildpackIDs {
+		if bp == launch.EscapeID(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge reads the components of SBOM files, listing each component once. Components are identified by their package
+// URL, or their name and version when they have none.
+func Merge(name string, files []File) (Document, error) {
+	doc := Document{Name: name, Created: time.Now().UTC()}
+	seen := map[string]bool{}
+	for _, f := range files {
+		if !f.IsSBOM() {
+			continue
+		}
+		components, err := f.Components()
+		if err != nil {
+			return Document{}, err
+		}
+		for _, c := range components {
+			if seen[c.key()] {
+				continue
+			}
+			seen[c.key()] = true
+			doc.Components = append(doc.Components, c)
+		}
+	}
+
+	sort.SliceStable(doc.Components, func(i, j int) bool {
+		if doc.Components[i].Name != doc.Components[j].Name {
+			return doc.Components[i].Name < doc.Components[j].Name
+		}
+		return doc.Components[i].Version < doc.Components[j].Version
+	})
+	return doc, nil
+}
+
+// Components reads the named components of the file, attributing them to the buildpack that wrote it. Files that
+// aren't SBOMs have no components.
+func (f File) Components() ([]Component, error) {
+	var (
+		components []Component
+		err        error
+	)
+	switch {
+	case strings.HasSuffix(f.Path, ".cdx.json"):
+		components, err = decodeCycloneDX(f.Contents)
+	case strings.HasSuffix(f.Path, ".spdx.json"):
+		components, err = decodeSPDX(f.Contents)
+	case strings.HasSuffix(f.Path, ".syft.json"):
+		components, err = decodeSyft(f.Contents)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM %s", style.Symbol(f.Path))
+	}
+
+	var named []Component
+	for _, c := range components {
+		if c.Name == "" {
+			continue
+		}
+		c.Buildpack = f.Buildpack()
+		named = append(named, c)
+	}
+	return named, nil
+}
+
+// Encode writes the document in the format
+func (d Document) Encode(format Format, toolVersion string) ([]byte, error) {
+	var v interface{}
+	switch format {
+	case FormatSPDXJSON:
+		v = d.spdx(toolVersion)
+	default:
+		v = d.cycloneDX(toolVersion)
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
@@ -0,0 +1,178 @@
+package sbom_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SBOM", testSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+const (
+	cycloneDXSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [
+    {"type": "library", "name": "node", "version": "18.0.0", "purl": "pkg:generic/node@18.0.0", "licenses": [{"license": {"id": "MIT"}}]}
+  ]
+}`
+	spdxSBOM = `{
+  "spdxVersion": "SPDX-2.2",
+  "packages": [
+    {"name": "node", "versionInfo": "18.0.0", "licenseDeclared": "MIT", "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:generic/node@18.0.0"}]},
+    {"name": "yarn", "versionInfo": "1.22.0", "licenseDeclared": "NOASSERTION", "licenseConcluded": "BSD-2-Clause"}
+  ]
+}`
+	syftSBOM = `{
+  "artifacts": [
+    {"name": "express", "version": "4.18.0", "type": "npm", "purl": "pkg:npm/express@4.18.0", "licenses": [{"value": "MIT", "spdxExpression": "MIT"}]},
+    {"name": "lodash", "version": "4.17.21", "type": "npm", "purl": "pkg:npm/lodash@4.17.21", "licenses": ["MIT"]}
+  ]
+}`
+)
+
+func testSBOM(t *testing.T, when spec.G, it spec.S) {
+	files := []sbom.File{
+		{Path: "layers/sbom/launch/some-org_node/node/sbom.cdx.json", Contents: []byte(cycloneDXSBOM)},
+		{Path: "layers/sbom/launch/some-org_node/node/sbom.spdx.json", Contents: []byte(spdxSBOM)},
+		{Path: "layers/sbom/launch/some-org_npm/sbom.syft.json", Contents: []byte(syftSBOM)},
+		{Path: "layers/sbom/launch/some-org_npm/sbom.toml", Contents: []byte(`not = "json"`)},
+	}
+
+	when("#ParseFormat", func() {
+		it("parses the formats", func() {
+			format, err := sbom.ParseFormat("spdx-json")
+			h.AssertNil(t, err)
+			h.AssertEq(t, format, sbom.FormatSPDXJSON)
+			h.AssertEq(t, format.Extension(), ".spdx.json")
+		})
+
+		it("errors on other formats", func() {
+			_, err := sbom.ParseFormat("xml")
+			h.AssertError(t, err, "invalid SBOM format 'xml', must be one of cyclonedx-json or spdx-json")
+		})
+	})
+
+	when("File#MatchesBuildpacks", func() {
+		it("matches the escaped IDs of buildpacks", func() {
+			h.AssertTrue(t, files[0].MatchesBuildpacks(nil))
+			h.AssertTrue(t, files[0].MatchesBuildpacks([]string{"some-org/node"}))
+			h.AssertFalse(t, files[0].MatchesBuildpacks([]string{"some-org/npm"}))
+			h.AssertEq(t, files[2].Buildpack(), "some-org_npm")
+		})
+	})
+
+	when("File#Scope", func() {
+		it("returns the scope of the SBOM", func() {
+			h.AssertEq(t, files[0].Scope(), sbom.ScopeLaunch)
+			h.AssertEq(t, sbom.File{Path: "layers/sbom/build/some-org_npm/sbom.cdx.json"}.Scope(), sbom.ScopeBuild)
+			h.AssertEq(t, sbom.File{Path: "layers/sbom/build/some-org_npm/sbom.cdx.json"}.Buildpack(), "some-org_npm")
+			h.AssertEq(t, sbom.File{Path: "sbom.cdx.json"}.Scope(), "")
+		})
+	})
+
+	when("Component#ID", func() {
+		it("identifies the component regardless of its version", func() {
+			h.AssertEq(t, sbom.Component{Name: "node", Version: "18.0.0"}.ID(), "node")
+			h.AssertEq(t, sbom.Component{Name: "node", PURL: "pkg:generic/node@18.0.0"}.ID(), "pkg:generic/node")
+			h.AssertEq(t, sbom.Component{Name: "core", PURL: "pkg:npm/%40angular/core@16.0.0?arch=all#lib"}.ID(), "pkg:npm/%40angular/core")
+			h.AssertEq(t, sbom.Component{Name: "node", PURL: "pkg:generic/node"}.ID(), "pkg:generic/node")
+		})
+	})
+
+	when("#Merge", func() {
+		it("lists the components of each format once", func() {
+			doc, err := sbom.Merge("some/app", files)
+			h.AssertNil(t, err)
+
+			var names []string
+			for _, c := range doc.Components {
+				names = append(names, c.Name+"@"+c.Version)
+			}
+			h.AssertEq(t, names, []string{"express@4.18.0", "lodash@4.17.21", "node@18.0.0", "yarn@1.22.0"})
+			h.AssertEq(t, doc.Components[0].Licenses, []string{"MIT"})
+			h.AssertEq(t, doc.Components[0].Buildpack, "some-org_npm")
+			h.AssertEq(t, doc.Components[2].PURL, "pkg:generic/node@18.0.0")
+			h.AssertEq(t, doc.Components[3].Licenses, []string{"BSD-2-Clause"})
+		})
+
+		it("errors when an SBOM is malformed", func() {
+			_, err := sbom.Merge("some/app", []sbom.File{{Path: "layers/sbom/launch/bp/sbom.cdx.json", Contents: []byte("{")}})
+			h.AssertError(t, err, "reading SBOM 'layers/sbom/launch/bp/sbom.cdx.json'")
+		})
+	})
+
+	when("Document#Encode", func() {
+		var doc sbom.Document
+
+		it.Before(func() {
+			var err error
+			doc, err = sbom.Merge("some/app", files)
+			h.AssertNil(t, err)
+		})
+
+		it("writes CycloneDX JSON", func() {
+			contents, err := doc.Encode(sbom.FormatCycloneDXJSON, "1.2.3")
+			h.AssertNil(t, err)
+
+			var decoded struct {
+				BOMFormat  string `json:"bomFormat"`
+				Components []struct {
+					Name       string `json:"name"`
+					PURL       string `json:"purl"`
+					Properties []struct {
+						Name  string `json:"name"`
+						Value string `json:"value"`
+					} `json:"properties"`
+				} `json:"components"`
+			}
+			h.AssertNil(t, json.Unmarshal(contents, &decoded))
+			h.AssertEq(t, decoded.BOMFormat, "CycloneDX")
+			h.AssertEq(t, len(decoded.Components), 4)
+			h.AssertEq(t, decoded.Components[2].PURL, "pkg:generic/node@18.0.0")
+			h.AssertEq(t, decoded.Components[2].Properties[0].Name, sbom.BuildpackProperty)
+			h.AssertEq(t, decoded.Components[2].Properties[0].Value, "some-org_node")
+
+			roundTripped, err := sbom.Merge("some/app", []sbom.File{{Path: "sbom.cdx.json", Contents: contents}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(roundTripped.Components), 4)
+		})
+
+		it("writes SPDX JSON", func() {
+			contents, err := doc.Encode(sbom.FormatSPDXJSON, "1.2.3")
+			h.AssertNil(t, err)
+
+			var decoded struct {
+				SPDXVersion  string `json:"spdxVersion"`
+				CreationInfo struct {
+					Creators []string `json:"creators"`
+				} `json:"creationInfo"`
+				Packages []struct {
+					Name            string `json:"name"`
+					SPDXID          string `json:"SPDXID"`
+					LicenseDeclared string `json:"licenseDeclared"`
+				} `json:"packages"`
+			}
+			h.AssertNil(t, json.Unmarshal(contents, &decoded))
+			h.AssertEq(t, decoded.SPDXVersion, "SPDX-2.3")
+			h.AssertEq(t, decoded.CreationInfo.Creators, []string{"Tool: pack-1.2.3"})
+			h.AssertEq(t, len(decoded.Packages), 4)
+			h.AssertEq(t, decoded.Packages[0].SPDXID, "SPDXRef-Package-1")
+			h.AssertEq(t, decoded.Packages[0].LicenseDeclared, "MIT")
+
+			roundTripped, err := sbom.Merge("some/app", []sbom.File{{Path: "sbom.spdx.json", Contents: contents}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(roundTripped.Components), 4)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package sbom
+
+import (
+	"encoding/json"
+)
+
+type syftDocument struct {
+	Artifacts []syftArtifact `json:"artifacts"`
+}
+
+type syftArtifact struct {
+	Name     string          `json:"name"`
+	Version  string          `json:"version"`
+	Type     string          `json:"type"`
+	PURL     string          `json:"purl"`
+	Licenses json.RawMessage `json:"licenses"`
+}
+
+func decodeSyft(contents []byte) ([]Component, error) {
+	var doc syftDocument
+	if err := json.Unmarshal(contents, &doc); err != nil {
+		return nil, err
+	}
+
+	var components []Component
+	for _, a := range doc.Artifacts {
+		components = append(components, Component{
+			Name:     a.Name,
+			Version:  a.Version,
+			PURL:     a.PURL,
+			Licenses: syftLicenses(a.Licenses),
+		})
+	}
+	return components, nil
+}
+
+// syftLicenses reads the licenses of an artifact, which older versions of syft list as strings and newer versions as
+// objects
+func syftLicenses(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(raw, &values); err == nil {
+		return values
+	}
+
+	var licenses []struct {
+		Value          string `json:"value"`
+		SPDXExpression string `json:"spdxExpression"`
+	}
+	if err := json.Unmarshal(raw, &licenses); err != nil {
+		return nil
+	}
+	var names []string
+	for _, l := range licenses {
+		if l.SPDXExpression != "" {
+			names = append(names, l.SPDXExpression)
+		} else if l.Value != "" {
+			names = append(names, l.Value)
+		}
+	}
+	return names
+}
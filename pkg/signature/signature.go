@@ -0,0 +1,102 @@
+// Package signature verifies cosign-style signatures of images and blobs against public keys, without contacting a
+// transparency log, and the verification policy builds enforce with them.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// SimpleSigningMediaType is the media type of the layers of a signature image, whose content is a Payload
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// SignatureAnnotation is the annotation of a signature layer holding the base64 encoded signature of its payload
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// PayloadType is the type of the payloads of image signatures
+	PayloadType = "cosign container image signature"
+	// BlobSignatureSuffix is appended to the URI of a blob to find its signature
+	BlobSignatureSuffix = ".sig"
+)
+
+// Payload is the simple signing payload that is signed for an image
+type Payload struct {
+	Critical Critical          `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+type Critical struct {
+	Identity Identity `json:"identity"`
+	Image    Image    `json:"image"`
+	Type     string   `json:"type"`
+}
+
+type Identity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+type Image struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// Tag returns the tag where the signatures of the manifest with the given digest are stored
+func Tag(repo name.Repository, digest string) name.Tag {
+	return repo.Tag(strings.Replace(digest, ":", "-", 1) + ".sig")
+}
+
+// PublicKey is a public key signatures are verified with
+type PublicKey struct {
+	// Path the key was read from, identifying it in reports
+	Path string
+	Key  crypto.PublicKey
+}
+
+// LoadPublicKey reads a PEM encoded ECDSA, RSA or Ed25519 public key, such as a cosign.pub file
+func LoadPublicKey(path string) (PublicKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return PublicKey{}, errors.Wrapf(err, "reading public key %s", style.Symbol(path))
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return PublicKey{}, errors.Errorf("public key %s is not PEM encoded", style.Symbol(path))
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return PublicKey{}, errors.Wrapf(err, "parsing public key %s", style.Symbol(path))
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return PublicKey{Path: path, Key: key}, nil
+	default:
+		return PublicKey{}, errors.Errorf("public key %s has unsupported type %T", style.Symbol(path), key)
+	}
+}
+
+// Verify checks that sig is a signature of payload made with the private key of k
+func (k PublicKey) Verify(payload, sig []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch key := k.Key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, sig)
+	default:
+		return false
+	}
+}
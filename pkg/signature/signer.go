@@ -0,0 +1,189 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// SignatureArtifactType is the artifact type of the referrers holding the signatures of an image
+	SignatureArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	// PasswordEnvVar is the environment variable holding the password of an encrypted cosign private key
+	PasswordEnvVar = "COSIGN_PASSWORD"
+)
+
+// PEM block types of the encrypted private keys generated by cosign
+var encryptedKeyTypes = map[string]bool{
+	"ENCRYPTED SIGSTORE PRIVATE KEY": true,
+	"ENCRYPTED COSIGN PRIVATE KEY":   true,
+}
+
+// PrivateKey is a private key images and attestations are signed with
+type PrivateKey struct {
+	// Path the key was read from, identifying it in logs
+	Path string
+	Key  crypto.Signer
+}
+
+// LoadPrivateKey reads a PEM encoded ECDSA, RSA or Ed25519 private key. Keys generated by 'cosign generate-key-pair'
+// are decrypted with the password.
+func LoadPrivateKey(path string, password []byte) (PrivateKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return PrivateKey{}, errors.Wrapf(err, "reading private key %s", style.Symbol(path))
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return PrivateKey{}, errors.Errorf("private key %s is not PEM encoded", style.Symbol(path))
+	}
+
+	var key interface{}
+	switch {
+	case encryptedKeyTypes[block.Type]:
+		der, err := decryptCosignKey(block.Bytes, password)
+		if err != nil {
+			return PrivateKey{}, errors.Wrapf(err, "decrypting private key %s", style.Symbol(path))
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return PrivateKey{}, errors.Wrapf(err, "parsing private key %s", style.Symbol(path))
+		}
+	case block.Type == "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case block.Type == "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return PrivateKey{}, errors.Wrapf(err, "parsing private key %s", style.Symbol(path))
+	}
+
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey, ed25519.PrivateKey:
+		return PrivateKey{Path: path, Key: key.(crypto.Signer)}, nil
+	default:
+		return PrivateKey{}, errors.Errorf("private key %s has unsupported type %T", style.Symbol(path), key)
+	}
+}
+
+// encryptedKey is the content of an encrypted cosign private key
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decryptCosignKey(contents, password []byte) ([]byte, error) {
+	var encrypted encryptedKey
+	if err := json.Unmarshal(contents, &encrypted); err != nil {
+		return nil, err
+	}
+	if encrypted.KDF.Name != "scrypt" || encrypted.Cipher.Name != "nacl/secretbox" {
+		return nil, errors.Errorf("unsupported encryption %s with %s", encrypted.Cipher.Name, encrypted.KDF.Name)
+	}
+	if len(encrypted.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid nonce")
+	}
+
+	params := encrypted.KDF.Params
+	secret, err := scrypt.Key(password, encrypted.KDF.Salt, params.N, params.R, params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		nonce [24]byte
+		key   [32]byte
+	)
+	copy(nonce[:], encrypted.Cipher.Nonce)
+	copy(key[:], secret)
+
+	der, ok := secretbox.Open(nil, encrypted.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, errors.Errorf("incorrect password, set it with %s", style.Symbol(PasswordEnvVar))
+	}
+	return der, nil
+}
+
+// Sign signs payload the way PublicKey.Verify expects
+func (k PrivateKey) Sign(payload []byte) ([]byte, error) {
+	if _, ok := k.Key.(ed25519.PrivateKey); ok {
+		return k.Key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(payload)
+	return k.Key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// PublicKey of the private key
+func (k PrivateKey) PublicKey() PublicKey {
+	return PublicKey{Path: k.Path, Key: k.Key.Public()}
+}
+
+// NewSignatureImage returns a referrer of subject holding a signature of the manifest of subject, for the image
+// reference dockerReference, made with key
+func NewSignatureImage(key PrivateKey, dockerReference string, subject v1.Descriptor) (v1.Image, error) {
+	payload, err := json.Marshal(Payload{Critical: Critical{
+		Identity: Identity{DockerReference: dockerReference},
+		Image:    Image{DockerManifestDigest: subject.Digest.String()},
+		Type:     PayloadType,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := key.Sign(payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signing with %s", style.Symbol(key.Path))
+	}
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(payload, SimpleSigningMediaType),
+		Annotations: map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewReferrer(img, SignatureArtifactType, subject), nil
+}
+
+// NewReferrer turns img into an OCI manifest referring to subject, whose artifact type is the media type of its config
+func NewReferrer(img v1.Image, artifactType string, subject v1.Descriptor) v1.Image {
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.MediaType(artifactType))
+	return mutate.Subject(img, v1.Descriptor{
+		MediaType: subject.MediaType,
+		Digest:    subject.Digest,
+		Size:      subject.Size,
+	}).(v1.Image)
+}
@@ -0,0 +1,199 @@
+package signature_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signature"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSigner(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Signer", testSigner, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSigner(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		tmpDir = t.TempDir()
+	})
+
+	writePEM := func(fileName, blockType string, der []byte) string {
+		path := filepath.Join(tmpDir, fileName)
+		h.AssertNil(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+		return path
+	}
+
+	when("#LoadPrivateKey", func() {
+		it("loads PKCS8 keys that sign verifiable payloads", func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			h.AssertNil(t, err)
+
+			privateKey, err := signature.LoadPrivateKey(writePEM("cosign.key", "PRIVATE KEY", der), nil)
+			h.AssertNil(t, err)
+
+			sig, err := privateKey.Sign([]byte("payload"))
+			h.AssertNil(t, err)
+			h.AssertTrue(t, privateKey.PublicKey().Verify([]byte("payload"), sig))
+			h.AssertFalse(t, privateKey.PublicKey().Verify([]byte("other payload"), sig))
+		})
+
+		it("loads Ed25519 keys", func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			h.AssertNil(t, err)
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			h.AssertNil(t, err)
+
+			privateKey, err := signature.LoadPrivateKey(writePEM("ed25519.key", "PRIVATE KEY", der), nil)
+			h.AssertNil(t, err)
+
+			sig, err := privateKey.Sign([]byte("payload"))
+			h.AssertNil(t, err)
+			h.AssertTrue(t, privateKey.PublicKey().Verify([]byte("payload"), sig))
+		})
+
+		when("the key is encrypted by cosign", func() {
+			var path string
+
+			it.Before(func() {
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				der, err := x509.MarshalPKCS8PrivateKey(key)
+				h.AssertNil(t, err)
+				path = writePEM("cosign.key", "ENCRYPTED SIGSTORE PRIVATE KEY", encryptKey(t, der, []byte("s3cr3t")))
+			})
+
+			it("decrypts it with the password", func() {
+				_, err := signature.LoadPrivateKey(path, []byte("s3cr3t"))
+				h.AssertNil(t, err)
+			})
+
+			it("errors when the password is incorrect", func() {
+				_, err := signature.LoadPrivateKey(path, []byte("wrong"))
+				h.AssertError(t, err, "incorrect password, set it with 'COSIGN_PASSWORD'")
+			})
+		})
+
+		it("errors when the key isn't PEM encoded", func() {
+			path := filepath.Join(tmpDir, "key.txt")
+			h.AssertNil(t, os.WriteFile(path, []byte("not a key"), 0600))
+
+			_, err := signature.LoadPrivateKey(path, nil)
+			h.AssertError(t, err, "is not PEM encoded")
+		})
+	})
+
+	when("#NewSignatureImage", func() {
+		var (
+			server *httptest.Server
+			repo   name.Repository
+		)
+
+		it.Before(func() {
+			server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+			var err error
+			repo, err = name.NewRepository(strings.TrimPrefix(server.URL, "http://") + "/some/image")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("creates a referrer the verifier accepts", func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			h.AssertNil(t, err)
+			privateKey, err := signature.LoadPrivateKey(writePEM("cosign.key", "PRIVATE KEY", der), nil)
+			h.AssertNil(t, err)
+			publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			h.AssertNil(t, err)
+			publicKeyPath := writePEM("cosign.pub", "PUBLIC KEY", publicDER)
+
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(repo.Tag("latest"), img))
+			subject, err := partial.Descriptor(img)
+			h.AssertNil(t, err)
+
+			sigImage, err := signature.NewSignatureImage(privateKey, repo.Name(), *subject)
+			h.AssertNil(t, err)
+			manifest, err := sigImage.Manifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, manifest.Subject.Digest, subject.Digest)
+			h.AssertEq(t, string(manifest.Config.MediaType), signature.SignatureArtifactType)
+
+			sigDigest, err := sigImage.Digest()
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(repo.Digest(sigDigest.String()), sigImage))
+
+			var outBuf bytes.Buffer
+			verifier, err := signature.NewVerifier(signature.Policy{Keys: []string{publicKeyPath}}, authn.DefaultKeychain, nil, logging.NewLogWithWriters(&outBuf, &outBuf))
+			h.AssertNil(t, err)
+			h.AssertNil(t, verifier.VerifyImage(context.TODO(), "builder", repo.Tag("latest").Name(), fakes.NewImage(repo.Tag("latest").Name(), "", repo.Digest(subject.Digest.String()))))
+			h.AssertEq(t, verifier.Results()[0].VerifiedBy, publicKeyPath)
+		})
+	})
+}
+
+// encryptKey encrypts a PKCS8 key the way 'cosign generate-key-pair' does
+func encryptKey(t *testing.T, der, password []byte) []byte {
+	t.Helper()
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	h.AssertNil(t, err)
+	var nonce [24]byte
+	_, err = rand.Read(nonce[:])
+	h.AssertNil(t, err)
+
+	secret, err := scrypt.Key(password, salt, 1024, 8, 1, 32)
+	h.AssertNil(t, err)
+	var key [32]byte
+	copy(key[:], secret)
+
+	contents, err := json.Marshal(map[string]interface{}{
+		"kdf": map[string]interface{}{
+			"name":   "scrypt",
+			"params": map[string]int{"N": 1024, "r": 8, "p": 1},
+			"salt":   salt,
+		},
+		"cipher":     map[string]interface{}{"name": "nacl/secretbox", "nonce": nonce[:]},
+		"ciphertext": secretbox.Seal(nil, der, &nonce, &key),
+	})
+	h.AssertNil(t, err)
+	return contents
+}
@@ -0,0 +1,495 @@
+package signature
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// Mode defines what happens when an image or blob fails verification
+type Mode int
+
+const (
+	// ModeEnforce fails when an image or blob fails verification
+	ModeEnforce Mode = iota
+	// ModeWarn warns when an image or blob fails verification
+	ModeWarn
+)
+
+var nameMap = map[string]Mode{"enforce": ModeEnforce, "warn": ModeWarn, "": ModeEnforce}
+
+// ParseMode from string
+func ParseMode(mode string) (Mode, error) {
+	if val, ok := nameMap[mode]; ok {
+		return val, nil
+	}
+
+	return ModeEnforce, errors.Errorf("invalid verification mode %s", style.Symbol(mode))
+}
+
+func (m Mode) String() string {
+	switch m {
+	case ModeEnforce:
+		return "enforce"
+	case ModeWarn:
+		return "warn"
+	}
+
+	return ""
+}
+
+// Policy lists what the images and blobs used by a build are verified against. An image or blob passes verification
+// when its digest is allowed, or when it is signed by one of the keys.
+type Policy struct {
+	Mode Mode
+	// Keys are the paths of the PEM encoded public keys of trusted signers
+	Keys []string
+	// AllowedDigests are the digests of images and blobs that are trusted without a signature
+	AllowedDigests []string
+}
+
+// Downloader downloads the signatures of blobs
+type Downloader interface {
+	Download(ctx context.Context, pathOrURI string) (blob.Blob, error)
+}
+
+// Result is the outcome of the verification of an image or blob
+type Result struct {
+	Kind string
+	Ref  string
+	// Digest of the manifest of the image, the image ID of a daemon image, or the digest of the blob
+	Digest string
+	// VerifiedBy is the path of the key that signed it, or 'allowed digest'. It is empty when verification failed.
+	VerifiedBy string
+	// Reasons verification failed
+	Reasons []string
+}
+
+// Error is returned when an image or blob fails verification in enforce mode
+type Error struct {
+	Result Result
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %s (%s) failed verification", e.Result.Kind, style.Symbol(e.Result.Ref), e.Result.Digest)
+	for _, reason := range e.Result.Reasons {
+		msg += "\n  - " + reason
+	}
+	return msg
+}
+
+// Verifier verifies images and blobs against a policy. It is safe for concurrent use.
+type Verifier struct {
+	mode       Mode
+	keys       []PublicKey
+	allowed    map[string]bool
+	keychain   authn.Keychain
+	downloader Downloader
+	logger     logging.Logger
+
+	mutex   sync.Mutex
+	results []Result
+}
+
+// NewVerifier loads the keys of a policy. Signatures of images are read from registries with the keychain, and
+// signatures of blobs with the downloader.
+func NewVerifier(policy Policy, keychain authn.Keychain, downloader Downloader, logger logging.Logger) (*Verifier, error) {
+	if len(policy.Keys) == 0 && len(policy.AllowedDigests) == 0 {
+		return nil, errors.New("verification policy must list trusted keys or allowed digests")
+	}
+
+	verifier := &Verifier{
+		mode:       policy.Mode,
+		allowed:    map[string]bool{},
+		keychain:   keychain,
+		downloader: downloader,
+		logger:     logger,
+	}
+	for _, path := range policy.Keys {
+		key, err := LoadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verifier.keys = append(verifier.keys, key)
+	}
+	for _, digest := range policy.AllowedDigests {
+		if _, err := v1.NewHash(digest); err != nil {
+			return nil, errors.Wrapf(err, "parsing allowed digest %s", style.Symbol(digest))
+		}
+		verifier.allowed[digest] = true
+	}
+	return verifier, nil
+}
+
+// Results of the verifications so far
+func (v *Verifier) Results() []Result {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return append([]Result{}, v.results...)
+}
+
+// VerifyImage verifies an image fetched for a reference. The image passes when the digest of its manifest, or of the
+// index its manifest was selected from, is allowed or signed by a trusted key. Images in the daemon are matched to
+// the manifests of the reference in its registry by their image ID.
+func (v *Verifier) VerifyImage(ctx context.Context, kind, ref string, img imgutil.Image) error {
+	imageRef, err := name.ParseReference(ref, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s reference %s", kind, style.Symbol(ref))
+	}
+	id, err := img.Identifier()
+	if err != nil {
+		return errors.Wrapf(err, "getting identifier of %s %s", kind, style.Symbol(ref))
+	}
+
+	result := Result{Kind: kind, Ref: ref}
+	var manifestDigests []string
+	switch id := id.(type) {
+	case local.IDIdentifier:
+		result.Digest = "sha256:" + strings.TrimPrefix(id.ImageID, "sha256:")
+	default:
+		result.Digest = id.String()
+		if i := strings.LastIndex(result.Digest, "@"); i >= 0 {
+			result.Digest = result.Digest[i+1:]
+		}
+		manifestDigests = append(manifestDigests, result.Digest)
+	}
+	if v.allowed[result.Digest] {
+		return v.pass(result, "allowed digest")
+	}
+
+	resolved, err := v.resolveManifests(ctx, imageRef, result.Digest, len(manifestDigests) == 0)
+	if err != nil {
+		result.Reasons = append(result.Reasons, err.Error())
+	}
+	for _, digest := range resolved {
+		if digest != result.Digest {
+			manifestDigests = append(manifestDigests, digest)
+		}
+	}
+
+	for _, digest := range manifestDigests {
+		if v.allowed[digest] {
+			return v.pass(result, "allowed digest")
+		}
+	}
+	if len(v.allowed) > 0 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("digest %s is not allowed", strings.Join(manifestDigestsOr(manifestDigests, result.Digest), ", ")))
+	}
+
+	if len(v.keys) > 0 {
+		for _, digest := range manifestDigests {
+			key, err := v.findImageSignature(ctx, imageRef.Context(), digest)
+			if err == nil {
+				return v.pass(result, key.Path)
+			}
+			result.Reasons = append(result.Reasons, err.Error())
+		}
+	}
+	return v.fail(result)
+}
+
+// VerifyLayoutImage verifies an image read from an OCI layout, such as a builder given as 'oci:<path>'. The image
+// passes when the digest of its manifest is allowed, or when one of the signatures attached to it in the layout is
+// from a trusted key.
+func (v *Verifier) VerifyLayoutImage(kind, ref string, path layout.Path, digest v1.Hash) error {
+	result := Result{Kind: kind, Ref: ref, Digest: digest.String()}
+	if v.allowed[result.Digest] {
+		return v.pass(result, "allowed digest")
+	}
+	if len(v.allowed) > 0 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("digest %s is not allowed", result.Digest))
+	}
+	if len(v.keys) == 0 {
+		return v.fail(result)
+	}
+
+	sigImages, err := layoutSignatures(path, digest)
+	if err != nil {
+		return errors.Wrapf(err, "reading signatures of %s %s", kind, style.Symbol(ref))
+	}
+	if len(sigImages) == 0 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("no signature found in the referrers of %s in the OCI layout", style.Symbol(result.Digest)))
+		return v.fail(result)
+	}
+	for _, sigImage := range sigImages {
+		key, ok, err := v.verifySignatureImage(sigImage, result.Digest)
+		if err != nil {
+			return errors.Wrapf(err, "reading signatures of %s %s", kind, style.Symbol(ref))
+		}
+		if ok {
+			return v.pass(result, key.Path)
+		}
+	}
+	result.Reasons = append(result.Reasons, fmt.Sprintf("no signature of %s in the OCI layout is from a trusted key", style.Symbol(result.Digest)))
+	return v.fail(result)
+}
+
+// layoutSignatures returns the signatures referring to the manifest with the given digest in an OCI layout
+func layoutSignatures(path layout.Path, digest v1.Hash) ([]v1.Image, error) {
+	index, err := path.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var sigImages []v1.Image
+	for _, desc := range indexManifest.Manifests {
+		if desc.MediaType.IsIndex() {
+			continue
+		}
+		img, err := index.Image(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := img.Manifest()
+		if err != nil {
+			return nil, err
+		}
+		if manifest.Subject != nil && manifest.Subject.Digest == digest && manifest.Config.MediaType == SignatureArtifactType {
+			sigImages = append(sigImages, img)
+		}
+	}
+	return sigImages, nil
+}
+
+// resolveManifests returns the digests of the manifest of the reference matching the digest of the image, followed by
+// the digest of the index the manifest is in. When byImageID is true, the digest is an image ID matched against the
+// configs of the manifests.
+func (v *Verifier) resolveManifests(ctx context.Context, ref name.Reference, digest string, byImageID bool) ([]string, error) {
+	options := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(v.keychain)}
+	desc, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving %s in its registry", style.Symbol(ref.Name()))
+	}
+
+	matches := func(manifestDigest v1.Hash) (bool, error) {
+		if !byImageID {
+			return manifestDigest.String() == digest, nil
+		}
+		img, err := remote.Image(ref.Context().Digest(manifestDigest.String()), options...)
+		if err != nil {
+			return false, err
+		}
+		config, err := img.ConfigName()
+		if err != nil {
+			return false, err
+		}
+		return config.String() == digest, nil
+	}
+
+	if !desc.MediaType.IsIndex() {
+		ok, err := matches(desc.Digest)
+		if err != nil || !ok {
+			return nil, errors.Errorf("image doesn't match %s in its registry", style.Symbol(ref.Name()))
+		}
+		return []string{desc.Digest.String()}, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading index %s", style.Symbol(ref.Name()))
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading index %s", style.Symbol(ref.Name()))
+	}
+	for _, m := range manifest.Manifests {
+		if ok, err := matches(m.Digest); err == nil && ok {
+			return []string{m.Digest.String(), desc.Digest.String()}, nil
+		}
+	}
+	return nil, errors.Errorf("image isn't in index %s in its registry", style.Symbol(ref.Name()))
+}
+
+// findImageSignature returns the trusted key that signed the manifest with the given digest. Signatures are read from
+// the tag cosign stores them in, and from the referrers of the manifest.
+func (v *Verifier) findImageSignature(ctx context.Context, repo name.Repository, digest string) (PublicKey, error) {
+	options := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(v.keychain)}
+
+	tag := Tag(repo, digest)
+	var sigImages []v1.Image
+	if sigImage, err := remote.Image(tag, options...); err == nil {
+		sigImages = append(sigImages, sigImage)
+	}
+	if referrers, err := remote.Referrers(repo.Digest(digest), options...); err == nil {
+		if manifest, err := referrers.IndexManifest(); err == nil {
+			for _, desc := range manifest.Manifests {
+				if desc.ArtifactType != SignatureArtifactType {
+					continue
+				}
+				if sigImage, err := remote.Image(repo.Digest(desc.Digest.String()), options...); err == nil {
+					sigImages = append(sigImages, sigImage)
+				}
+			}
+		}
+	}
+	if len(sigImages) == 0 {
+		return PublicKey{}, errors.Errorf("no signature found at %s or in the referrers of %s", style.Symbol(tag.Name()), style.Symbol(digest))
+	}
+
+	for _, sigImage := range sigImages {
+		key, ok, err := v.verifySignatureImage(sigImage, digest)
+		if err != nil {
+			return PublicKey{}, errors.Wrapf(err, "reading signatures of %s", style.Symbol(repo.Digest(digest).Name()))
+		}
+		if ok {
+			return key, nil
+		}
+	}
+	return PublicKey{}, errors.Errorf("no signature of %s is from a trusted key", style.Symbol(repo.Digest(digest).Name()))
+}
+
+// verifySignatureImage returns the trusted key that made one of the signatures in a signature image, of the manifest
+// with the given digest
+func (v *Verifier) verifySignatureImage(sigImage v1.Image, digest string) (PublicKey, bool, error) {
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return PublicKey{}, false, err
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != SimpleSigningMediaType {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(layer.Annotations[SignatureAnnotation])
+		if err != nil {
+			continue
+		}
+		payload, err := readLayer(sigImage, layer.Digest)
+		if err != nil {
+			return PublicKey{}, false, err
+		}
+
+		for _, key := range v.keys {
+			if !key.Verify(payload, sig) {
+				continue
+			}
+			var p Payload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				continue
+			}
+			if p.Critical.Type == PayloadType && p.Critical.Image.DockerManifestDigest == digest {
+				return key, true, nil
+			}
+		}
+	}
+	return PublicKey{}, false, nil
+}
+
+func readLayer(img v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// VerifyBlob verifies a blob downloaded from a URI. The blob passes when its digest is allowed, or when the signature
+// next to it, at the URI with the suffix '.sig', is from a trusted key.
+func (v *Verifier) VerifyBlob(ctx context.Context, kind, uri string, b blob.Blob) error {
+	content, err := readBlob(b)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s %s", kind, style.Symbol(uri))
+	}
+	digest := sha256.Sum256(content)
+
+	result := Result{Kind: kind, Ref: uri, Digest: "sha256:" + hex.EncodeToString(digest[:])}
+	if v.allowed[result.Digest] {
+		return v.pass(result, "allowed digest")
+	}
+	if len(v.allowed) > 0 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("digest %s is not allowed", result.Digest))
+	}
+
+	if len(v.keys) == 0 {
+		return v.fail(result)
+	}
+	sigBlob, err := v.downloader.Download(ctx, uri+BlobSignatureSuffix)
+	if err != nil {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("no signature found at %s", style.Symbol(uri+BlobSignatureSuffix)))
+		return v.fail(result)
+	}
+	encoded, err := readBlob(sigBlob)
+	if err != nil {
+		return errors.Wrapf(err, "reading signature of %s %s", kind, style.Symbol(uri))
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		sig = encoded
+	}
+
+	for _, key := range v.keys {
+		if key.Verify(content, sig) {
+			return v.pass(result, key.Path)
+		}
+	}
+	result.Reasons = append(result.Reasons, fmt.Sprintf("signature at %s is not from a trusted key", style.Symbol(uri+BlobSignatureSuffix)))
+	return v.fail(result)
+}
+
+func readBlob(b blob.Blob) ([]byte, error) {
+	rc, err := b.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func (v *Verifier) pass(result Result, verifiedBy string) error {
+	result.VerifiedBy = verifiedBy
+	result.Reasons = nil
+	v.record(result)
+	v.logger.Debugf("Verified %s %s (%s): %s", result.Kind, style.Symbol(result.Ref), result.Digest, verifiedBy)
+	return nil
+}
+
+func (v *Verifier) fail(result Result) error {
+	v.record(result)
+	err := &Error{Result: result}
+	if v.mode == ModeWarn {
+		v.logger.Warn(err.Error())
+		return nil
+	}
+	return err
+}
+
+func (v *Verifier) record(result Result) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.results = append(v.results, result)
+}
+
+func manifestDigestsOr(digests []string, digest string) []string {
+	if len(digests) == 0 {
+		return []string{digest}
+	}
+	return digests
+}
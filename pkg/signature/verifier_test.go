@@ -0,0 +1,338 @@
+package signature_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signature"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVerifier(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Verifier", testVerifier, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVerifier(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server     *httptest.Server
+		repo       name.Repository
+		tmpDir     string
+		outBuf     bytes.Buffer
+		logger     logging.Logger
+		key        *ecdsa.PrivateKey
+		keyPath    string
+		otherKey   *ecdsa.PrivateKey
+		downloader fakeDownloader
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+		var err error
+		repo, err = name.NewRepository(strings.TrimPrefix(server.URL, "http://") + "/some/image")
+		h.AssertNil(t, err)
+
+		tmpDir = t.TempDir()
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		key, keyPath = generateKey(t, tmpDir, "cosign.pub")
+		otherKey, _ = generateKey(t, tmpDir, "other.pub")
+		downloader = fakeDownloader{}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	newVerifier := func(policy signature.Policy) *signature.Verifier {
+		verifier, err := signature.NewVerifier(policy, authn.DefaultKeychain, downloader, logger)
+		h.AssertNil(t, err)
+		return verifier
+	}
+
+	pushImage := func(tag string) v1.Image {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(repo.Tag(tag), img))
+		return img
+	}
+
+	remoteImage := func(tag string, img v1.Image) *fakes.Image {
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		return fakes.NewImage(repo.Tag(tag).Name(), "", repo.Digest(digest.String()))
+	}
+
+	when("#NewVerifier", func() {
+		it("errors when the policy trusts nothing", func() {
+			_, err := signature.NewVerifier(signature.Policy{}, authn.DefaultKeychain, downloader, logger)
+			h.AssertError(t, err, "verification policy must list trusted keys or allowed digests")
+		})
+
+		it("errors when a key can't be read", func() {
+			notPEM := filepath.Join(tmpDir, "key.txt")
+			h.AssertNil(t, os.WriteFile(notPEM, []byte("not a key"), 0600))
+
+			_, err := signature.NewVerifier(signature.Policy{Keys: []string{notPEM}}, authn.DefaultKeychain, downloader, logger)
+			h.AssertError(t, err, "is not PEM encoded")
+		})
+
+		it("errors when an allowed digest is invalid", func() {
+			_, err := signature.NewVerifier(signature.Policy{AllowedDigests: []string{"latest"}}, authn.DefaultKeychain, downloader, logger)
+			h.AssertError(t, err, "parsing allowed digest 'latest'")
+		})
+	})
+
+	when("#VerifyImage", func() {
+		it("passes images signed by a trusted key", func() {
+			img := pushImage("signed")
+			signImage(t, repo, img, key)
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			h.AssertNil(t, verifier.VerifyImage(context.TODO(), "builder", repo.Tag("signed").Name(), remoteImage("signed", img)))
+
+			results := verifier.Results()
+			h.AssertEq(t, len(results), 1)
+			h.AssertEq(t, results[0].VerifiedBy, keyPath)
+		})
+
+		it("fails unsigned images", func() {
+			img := pushImage("unsigned")
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			err := verifier.VerifyImage(context.TODO(), "builder", repo.Tag("unsigned").Name(), remoteImage("unsigned", img))
+			h.AssertError(t, err, "failed verification")
+			h.AssertError(t, err, "no signature found at")
+
+			var verificationErr *signature.Error
+			h.AssertTrue(t, errors.As(err, &verificationErr))
+			h.AssertEq(t, verificationErr.Result.Kind, "builder")
+		})
+
+		it("fails images signed by another key", func() {
+			img := pushImage("other")
+			signImage(t, repo, img, otherKey)
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			err := verifier.VerifyImage(context.TODO(), "run-image", repo.Tag("other").Name(), remoteImage("other", img))
+			h.AssertError(t, err, "is from a trusted key")
+		})
+
+		it("passes images whose index is signed", func() {
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			index := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+				Add:        img,
+				Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+			})
+			h.AssertNil(t, remote.WriteIndex(repo.Tag("multi"), index))
+			indexDigest, err := index.Digest()
+			h.AssertNil(t, err)
+			signDigest(t, repo, indexDigest.String(), key)
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			h.AssertNil(t, verifier.VerifyImage(context.TODO(), "run-image", repo.Tag("multi").Name(), remoteImage("multi", img)))
+		})
+
+		it("matches daemon images to their manifest by image ID", func() {
+			img := pushImage("daemon")
+			signImage(t, repo, img, key)
+			config, err := img.ConfigName()
+			h.AssertNil(t, err)
+			daemonImage := fakes.NewImage(repo.Tag("daemon").Name(), "", local.IDIdentifier{ImageID: strings.TrimPrefix(config.String(), "sha256:")})
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			h.AssertNil(t, verifier.VerifyImage(context.TODO(), "lifecycle", repo.Tag("daemon").Name(), daemonImage))
+		})
+
+		it("passes images with an allowed digest without a signature", func() {
+			img := pushImage("allowed")
+			digest, err := img.Digest()
+			h.AssertNil(t, err)
+
+			verifier := newVerifier(signature.Policy{AllowedDigests: []string{digest.String()}})
+			h.AssertNil(t, verifier.VerifyImage(context.TODO(), "builder", repo.Tag("allowed").Name(), remoteImage("allowed", img)))
+			h.AssertEq(t, verifier.Results()[0].VerifiedBy, "allowed digest")
+		})
+
+		it("warns instead of failing in warn mode", func() {
+			img := pushImage("warn")
+
+			verifier := newVerifier(signature.Policy{Mode: signature.ModeWarn, Keys: []string{keyPath}})
+			h.AssertNil(t, verifier.VerifyImage(context.TODO(), "builder", repo.Tag("warn").Name(), remoteImage("warn", img)))
+			h.AssertContains(t, outBuf.String(), "Warning: builder")
+			h.AssertContains(t, outBuf.String(), "failed verification")
+		})
+	})
+
+	when("#VerifyLayoutImage", func() {
+		var (
+			layoutPath layout.Path
+			digest     v1.Hash
+		)
+
+		it.Before(func() {
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			layoutPath, err = layout.Write(filepath.Join(tmpDir, "builder"), empty.Index)
+			h.AssertNil(t, err)
+			h.AssertNil(t, layoutPath.AppendImage(img))
+			digest, err = img.Digest()
+			h.AssertNil(t, err)
+		})
+
+		signInLayout := func(signingKey *ecdsa.PrivateKey) {
+			sigImage, err := signature.NewSignatureImage(signature.PrivateKey{Path: "cosign.key", Key: signingKey}, "some/builder", v1.Descriptor{
+				MediaType: types.OCIManifestSchema1,
+				Digest:    digest,
+			})
+			h.AssertNil(t, err)
+			h.AssertNil(t, layoutPath.AppendImage(sigImage))
+		}
+
+		it("passes images signed by a trusted key in the layout", func() {
+			signInLayout(key)
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			h.AssertNil(t, verifier.VerifyLayoutImage("builder", "oci:builder", layoutPath, digest))
+			h.AssertEq(t, verifier.Results()[0].Digest, digest.String())
+			h.AssertEq(t, verifier.Results()[0].VerifiedBy, keyPath)
+		})
+
+		it("fails images signed by another key", func() {
+			signInLayout(otherKey)
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			h.AssertError(t, verifier.VerifyLayoutImage("builder", "oci:builder", layoutPath, digest), "in the OCI layout is from a trusted key")
+		})
+
+		it("fails unsigned images", func() {
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			h.AssertError(t, verifier.VerifyLayoutImage("builder", "oci:builder", layoutPath, digest), "no signature found in the referrers of")
+		})
+
+		it("passes images with an allowed digest", func() {
+			verifier := newVerifier(signature.Policy{AllowedDigests: []string{digest.String()}})
+			h.AssertNil(t, verifier.VerifyLayoutImage("builder", "oci:builder", layoutPath, digest))
+			h.AssertEq(t, verifier.Results()[0].VerifiedBy, "allowed digest")
+		})
+	})
+
+	when("#VerifyBlob", func() {
+		var content = []byte("buildpack")
+
+		it("passes blobs whose signature is from a trusted key", func() {
+			digest := sha256.Sum256(content)
+			sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			h.AssertNil(t, err)
+			downloader["https://example.com/bp.tgz.sig"] = []byte(base64.StdEncoding.EncodeToString(sig))
+
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			h.AssertNil(t, verifier.VerifyBlob(context.TODO(), "buildpack", "https://example.com/bp.tgz", fakeBlob(content)))
+		})
+
+		it("fails blobs without a signature", func() {
+			verifier := newVerifier(signature.Policy{Keys: []string{keyPath}})
+			err := verifier.VerifyBlob(context.TODO(), "buildpack", "https://example.com/bp.tgz", fakeBlob(content))
+			h.AssertError(t, err, "no signature found at 'https://example.com/bp.tgz.sig'")
+		})
+
+		it("passes blobs with an allowed digest", func() {
+			digest := sha256.Sum256(content)
+			verifier := newVerifier(signature.Policy{AllowedDigests: []string{"sha256:" + hex.EncodeToString(digest[:])}})
+			h.AssertNil(t, verifier.VerifyBlob(context.TODO(), "lifecycle", "https://example.com/lifecycle.tgz", fakeBlob(content)))
+		})
+	})
+}
+
+func generateKey(t *testing.T, dir, fileName string) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	h.AssertNil(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	h.AssertNil(t, err)
+
+	path := filepath.Join(dir, fileName)
+	h.AssertNil(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	return key, path
+}
+
+func signImage(t *testing.T, repo name.Repository, img v1.Image, key *ecdsa.PrivateKey) {
+	t.Helper()
+	digest, err := img.Digest()
+	h.AssertNil(t, err)
+	signDigest(t, repo, digest.String(), key)
+}
+
+// signDigest stores a signature of the manifest with the given digest the way cosign does
+func signDigest(t *testing.T, repo name.Repository, digest string, key *ecdsa.PrivateKey) {
+	t.Helper()
+	payload, err := json.Marshal(signature.Payload{Critical: signature.Critical{
+		Identity: signature.Identity{DockerReference: repo.Name()},
+		Image:    signature.Image{DockerManifestDigest: digest},
+		Type:     signature.PayloadType,
+	}})
+	h.AssertNil(t, err)
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	h.AssertNil(t, err)
+
+	sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(payload, types.MediaType(signature.SimpleSigningMediaType)),
+		Annotations: map[string]string{signature.SignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+	})
+	h.AssertNil(t, err)
+	h.AssertNil(t, remote.Write(signature.Tag(repo, digest), sigImage))
+}
+
+type fakeDownloader map[string][]byte
+
+func (d fakeDownloader) Download(_ context.Context, uri string) (blob.Blob, error) {
+	content, ok := d[uri]
+	if !ok {
+		return nil, errors.Errorf("%s not found", uri)
+	}
+	return fakeBlob(content), nil
+}
+
+type fakeBlob []byte
+
+func (b fakeBlob) Open() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
@@ -3,9 +3,15 @@ package registry
 const (
 	TypeGit    = "git"
 	TypeGitHub = "github"
+	// TypeOCI is a registry whose index is an OCI artifact in a container registry, its URL being the image reference
+	TypeOCI = "oci"
+	// TypeDir is a registry whose index is a local directory, its URL being the path of the directory
+	TypeDir = "dir"
 )
 
 var Types = []string{
 	TypeGit,
 	TypeGitHub,
+	TypeOCI,
+	TypeDir,
 }
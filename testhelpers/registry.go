@@ -76,6 +76,18 @@ func CreateRegistryFixture(t *testing.T, tmpDir, fixturePath string) string {
 	return registryFixtureCopy
 }
 
+// CreateBareRegistryFixture creates a bare clone of a registry fixture, which commits can be pushed to
+func CreateBareRegistryFixture(t *testing.T, tmpDir, fixturePath string) string {
+	t.Helper()
+	registryFixtureCopy := CreateRegistryFixture(t, tmpDir, fixturePath)
+
+	registryFixtureBare := filepath.Join(tmpDir, "registryBare")
+	_, err := git.PlainClone(registryFixtureBare, true, &git.CloneOptions{URL: registryFixtureCopy})
+	AssertNil(t, err)
+
+	return registryFixtureBare
+}
+
 func RunRegistry(t *testing.T) *TestRegistryConfig {
 	t.Log("run registry")
 	t.Helper()